@@ -19,15 +19,24 @@ package v1alpha1
 import (
 	"encoding/json"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +k8s:defaulter-gen=true
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced
 
-// EndpointPickerConfig is the Schema for the endpointpickerconfigs API
+// EndpointPickerConfig is the Schema for the endpointpickerconfigs API.
+//
+// It is either loaded from the configuration file of the endpoint picker, or
+// stored in the cluster as a custom resource referenced by an InferencePool.
+// The metadata and status are only used by the custom resource.
 type EndpointPickerConfig struct {
 	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// +required
 	// +kubebuilder:validation:Required
@@ -39,6 +48,32 @@ type EndpointPickerConfig struct {
 	// SchedulingProfiles is the list of named SchedulingProfiles
 	// that will be created.
 	SchedulingProfiles []SchedulingProfile `json:"schedulingProfiles"`
+
+	// +optional
+	// SaturationDetector configures the detection of the saturation of the
+	// pool, which sheds the sheddable requests. It is applied to the reloaded
+	// versions of the configuration as well.
+	SaturationDetector *SaturationDetectorConfig `json:"saturationDetector,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// CustomMetrics is the list of the metrics scraped from the model servers
+	// in addition to the built-in ones, which plugins reference by name.
+	CustomMetrics []CustomMetric `json:"customMetrics,omitempty"`
+
+	// +optional
+	// Status defines the observed state of the EndpointPickerConfig.
+	Status EndpointPickerConfigStatus `json:"status,omitempty"`
+}
+
+// EndpointPickerConfigList contains a list of EndpointPickerConfig.
+//
+// +kubebuilder:object:root=true
+type EndpointPickerConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EndpointPickerConfig `json:"items"`
 }
 
 // PluginSpec contains the information that describes a plugin that
@@ -55,6 +90,8 @@ type PluginSpec struct {
 	Type string `json:"type"`
 
 	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
 	// Parameters are the set of parameters to be passed to the plugin's
 	// factory function. The factory function is responsible
 	// to parse the parameters.
@@ -73,8 +110,70 @@ type SchedulingProfile struct {
 	// Plugins is the list of plugins for this SchedulingProfile. They are assigned
 	// to the appropriate "slots" based on their type.
 	Plugins []SchedulingPlugin `json:"plugins"`
+
+	// +optional
+	// ScoreCombination specifies how the scores of the Scorers of this
+	// SchedulingProfile are combined into the score of each pod. By default,
+	// the raw scores multiplied by the weights of the Scorers are summed.
+	ScoreCombination *ScoreCombination `json:"scoreCombination,omitempty"`
 }
 
+// ScoreCombination specifies how the scores of the Scorers of a
+// SchedulingProfile are combined.
+type ScoreCombination struct {
+	// +optional
+	// +kubebuilder:validation:Enum=None;MinMax;Rank
+	// Normalization is applied to the scores of each Scorer, across the
+	// candidate pods, before they are combined. MinMax rescales the scores
+	// so that the lowest is 0 and the highest is 1. Rank replaces each score
+	// with the fraction of the other pods that have a lower score.
+	// Defaults to None.
+	Normalization ScoreNormalization `json:"normalization,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Enum=Sum;Product;Lexicographic
+	// Strategy combines the normalized scores. Sum adds the scores multiplied
+	// by the weights. Product multiplies the scores raised to the power of
+	// the weights. Lexicographic orders the pods by the score of the first
+	// Scorer, then by the next Scorers to break ties, and ignores the weights.
+	// Defaults to Sum.
+	Strategy ScoreCombinationStrategy `json:"strategy,omitempty"`
+}
+
+// ScoreNormalization is a normalization of the scores of a Scorer.
+type ScoreNormalization string
+
+const (
+	// NoScoreNormalization keeps the scores returned by the Scorers.
+	NoScoreNormalization ScoreNormalization = "None"
+	// MinMaxScoreNormalization rescales the scores so that the lowest is 0
+	// and the highest is 1. If all the pods have the same score, they all
+	// get 1.
+	MinMaxScoreNormalization ScoreNormalization = "MinMax"
+	// RankScoreNormalization replaces each score with the fraction of the
+	// other pods that have a lower score.
+	RankScoreNormalization ScoreNormalization = "Rank"
+)
+
+// ScoreCombinationStrategy is a strategy to combine the scores of Scorers.
+type ScoreCombinationStrategy string
+
+const (
+	// SumScoreCombination adds the scores multiplied by the weights of the
+	// Scorers.
+	SumScoreCombination ScoreCombinationStrategy = "Sum"
+	// ProductScoreCombination multiplies the scores raised to the power of
+	// the weights of the Scorers. A pod with a score of 0 from any Scorer
+	// with a positive weight gets a score of 0.
+	ProductScoreCombination ScoreCombinationStrategy = "Product"
+	// LexicographicScoreCombination orders the pods by the score of the
+	// first Scorer, then by the scores of the next Scorers to break ties.
+	// The weights are ignored. The combined score is the fraction of the
+	// other pods that are ordered lower, so pods with the same scores get
+	// the same combined score.
+	LexicographicScoreCombination ScoreCombinationStrategy = "Lexicographic"
+)
+
 // SchedulingPlugin describes a plugin that will be associated with a
 // SchedulingProfile entry.
 type SchedulingPlugin struct {
@@ -87,6 +186,105 @@ type SchedulingPlugin struct {
 	PluginRef string `json:"pluginRef"`
 
 	// +optional
-	// Weight is the weight fo be used if this plugin is a Scorer.
-	Weight *int `json:"weight"`
+	// Weight is the weight fo be used if this plugin is a Scorer. It may be
+	// fractional, e.g. 0.5, and must not be negative.
+	Weight *resource.Quantity `json:"weight"`
 }
+
+// SaturationDetectorConfig configures the detection of the saturation of
+// the pool. The unset fields keep their default values.
+type SaturationDetectorConfig struct {
+	// +optional
+	// QueueDepthThreshold is the waiting queue size above which a pod is
+	// considered to have insufficient capacity for new requests.
+	QueueDepthThreshold *int `json:"queueDepthThreshold,omitempty"`
+
+	// +optional
+	// KVCacheUtilThreshold is the KV cache utilization, between 0 and 1,
+	// above which a pod is considered to have insufficient capacity.
+	KVCacheUtilThreshold *resource.Quantity `json:"kvCacheUtilThreshold,omitempty"`
+
+	// +optional
+	// MetricsStalenessThreshold is how old the metrics of a pod can be
+	// before the pod is considered to have insufficient capacity.
+	MetricsStalenessThreshold *metav1.Duration `json:"metricsStalenessThreshold,omitempty"`
+}
+
+// CustomMetric declares a metric scraped from the model servers into the
+// custom metrics of the pods.
+type CustomMetric struct {
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Name is the name plugins reference the metric by.
+	Name string `json:"name"`
+
+	// +required
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Metric is the Prometheus metric, optionally with the labels of the
+	// series, e.g. `vllm:time_to_first_token_seconds{model_name=llama}`.
+	Metric string `json:"metric"`
+
+	// +optional
+	// Aggregation reduces the matching series to a single value. It is one of
+	// `last` (the default, the latest series), `sum`, `min`, `max`, `avg`,
+	// `rate` for the per-second rate of counters, or `histogram_quantile(q)`
+	// for the q quantile of a histogram, with q between 0 and 1. The rates and
+	// quantiles are computed over the recent scrapes, within the rate window
+	// of the EPP.
+	Aggregation string `json:"aggregation,omitempty"`
+}
+
+// EndpointPickerConfigStatus defines the observed state of EndpointPickerConfig.
+type EndpointPickerConfigStatus struct {
+	// Conditions track the state of the EndpointPickerConfig.
+	//
+	// Known condition types are:
+	//
+	// * "Accepted"
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=8
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// EndpointPickerConfigConditionType is a type of condition for the EndpointPickerConfig.
+type EndpointPickerConfigConditionType string
+
+// EndpointPickerConfigReason is the reason for a given EndpointPickerConfigConditionType.
+type EndpointPickerConfigReason string
+
+const (
+	// This condition indicates whether the EndpointPickerConfig has been
+	// accepted or rejected by the endpoint picker of the InferencePool
+	// referencing it, and why.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Accepted"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "InvalidPlugin"
+	//
+	// Possible reasons for this condition to be Unknown are:
+	//
+	// * "Pending"
+	EndpointPickerConfigConditionAccepted EndpointPickerConfigConditionType = "Accepted"
+
+	// This reason is used with the "Accepted" condition when the configuration
+	// has been applied by the endpoint picker.
+	EndpointPickerConfigReasonAccepted EndpointPickerConfigReason = "Accepted"
+
+	// This reason is used with the "Accepted" condition when the configuration
+	// failed the validation, or one of its plugins could not be instantiated.
+	// The message of the condition holds the validation error.
+	EndpointPickerConfigReasonInvalidPlugin EndpointPickerConfigReason = "InvalidPlugin"
+
+	// This reason is used with the "Accepted" condition when the endpoint picker
+	// has not yet reconciled the EndpointPickerConfig.
+	EndpointPickerConfigReasonPending EndpointPickerConfigReason = "Pending"
+)
@@ -102,6 +102,15 @@ type InferenceModelSpec struct {
 	//
 	// +kubebuilder:validation:Required
 	PoolRef PoolObjectReference `json:"poolRef"`
+
+	// SchedulingProfile is the name of the scheduling profile of the EndpointPicker used to schedule the requests
+	// for this model, when the EndpointPicker is configured with a profile handler that selects the profile per
+	// request. If not specified, or if the EndpointPicker has no profile with this name, the profile is selected by
+	// the profile handler, e.g. the default profile.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	SchedulingProfile string `json:"schedulingProfile,omitempty"`
 }
 
 // PoolObjectReference identifies an API object within the namespace of the
@@ -71,10 +71,117 @@ type InferencePoolSpec struct {
 	// EndpointPickerConfig specifies the configuration needed by the proxy to discover and connect to the endpoint
 	// picker service that picks endpoints for the requests routed to this pool.
 	EndpointPickerConfig `json:",inline"`
+
+	// EndpointPickerConfigRef references the EndpointPickerConfig, in the namespace of
+	// the InferencePool, that configures the scheduling of the endpoint picker. When
+	// specified, the endpoint picker watches the referenced configuration and applies
+	// its changes without restarting.
+	//
+	// +optional
+	EndpointPickerConfigRef *EndpointPickerConfigReference `json:"endpointPickerConfigRef,omitempty"`
+
+	// Metrics configures how the endpoint picker scrapes the metrics of the
+	// selected model servers. The fields that aren't specified fall back to the
+	// flags of the endpoint picker, which default to scraping
+	// "http://<pod IP>:<targetPortNumber>/metrics".
+	//
+	// +optional
+	Metrics *MetricsEndpoint `json:"metrics,omitempty"`
+}
+
+// MetricsEndpoint configures the metrics endpoint of the model servers.
+type MetricsEndpoint struct {
+	// Path is the HTTP path of the metrics endpoint.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// Port is the port of the metrics endpoint, when the model servers expose
+	// their metrics on another port than the TargetPortNumber.
+	//
+	// +optional
+	Port *PortNumber `json:"port,omitempty"`
+
+	// Scheme is the scheme of the metrics endpoint, HTTP or HTTPS.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	Scheme MetricsScheme `json:"scheme,omitempty"`
+
+	// TLS configures the TLS connection to the metrics endpoint, when the
+	// scheme is HTTPS.
+	//
+	// +optional
+	TLS *MetricsTLSConfig `json:"tls,omitempty"`
+
+	// AuthTokenSecretRef references the key of a Secret, in the namespace of the
+	// InferencePool, holding the bearer token sent to the metrics endpoint.
+	//
+	// +optional
+	AuthTokenSecretRef *SecretKeyReference `json:"authTokenSecretRef,omitempty"`
+}
+
+// MetricsScheme is the scheme of a metrics endpoint.
+type MetricsScheme string
+
+const (
+	// MetricsSchemeHTTP scrapes the metrics over HTTP.
+	MetricsSchemeHTTP MetricsScheme = "HTTP"
+	// MetricsSchemeHTTPS scrapes the metrics over HTTPS.
+	MetricsSchemeHTTPS MetricsScheme = "HTTPS"
+)
+
+// MetricsTLSConfig configures the TLS connection to a metrics endpoint.
+type MetricsTLSConfig struct {
+	// InsecureSkipVerify disables the verification of the certificates of the
+	// model servers.
+	//
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CASecretRef references the key of a Secret, in the namespace of the
+	// InferencePool, holding the PEM encoded certificates of the authorities
+	// the certificates of the model servers are verified with. The system
+	// certificate authorities are used when it isn't specified.
+	//
+	// +optional
+	CASecretRef *SecretKeyReference `json:"caSecretRef,omitempty"`
+}
+
+// SecretKeyReference is a reference to a key of a Secret in the namespace of
+// the InferencePool.
+type SecretKeyReference struct {
+	// Name is the name of the Secret.
+	//
+	// +kubebuilder:validation:Required
+	Name ObjectName `json:"name"`
+
+	// Key is the key of the value in the Secret.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key"`
+}
+
+// EndpointPickerConfigReference is a reference to an EndpointPickerConfig in the
+// namespace of the InferencePool.
+type EndpointPickerConfigReference struct {
+	// Name is the name of the EndpointPickerConfig.
+	//
+	// +kubebuilder:validation:Required
+	Name ObjectName `json:"name"`
 }
 
 // EndpointPickerConfig specifies the configuration needed by the proxy to discover and connect to the endpoint picker extension.
 // This type is intended to be a union of mutually exclusive configuration options that we may add in the future.
+//
+// It is not a version of the EndpointPickerConfig custom resource of the config
+// API, which has the same kind name in the same group.
+//
+// +kubebuilder:skipversion
 type EndpointPickerConfig struct {
 	// Extension configures an endpoint picker as an extension service.
 	//
@@ -25,10 +25,11 @@ import (
 // InferenceModelSpecApplyConfiguration represents a declarative configuration of the InferenceModelSpec type for use
 // with apply.
 type InferenceModelSpecApplyConfiguration struct {
-	ModelName    *string                                `json:"modelName,omitempty"`
-	Criticality  *apiv1alpha2.Criticality               `json:"criticality,omitempty"`
-	TargetModels []TargetModelApplyConfiguration        `json:"targetModels,omitempty"`
-	PoolRef      *PoolObjectReferenceApplyConfiguration `json:"poolRef,omitempty"`
+	ModelName         *string                                `json:"modelName,omitempty"`
+	Criticality       *apiv1alpha2.Criticality               `json:"criticality,omitempty"`
+	TargetModels      []TargetModelApplyConfiguration        `json:"targetModels,omitempty"`
+	PoolRef           *PoolObjectReferenceApplyConfiguration `json:"poolRef,omitempty"`
+	SchedulingProfile *string                                `json:"schedulingProfile,omitempty"`
 }
 
 // InferenceModelSpecApplyConfiguration constructs a declarative configuration of the InferenceModelSpec type for use with
@@ -73,3 +74,11 @@ func (b *InferenceModelSpecApplyConfiguration) WithPoolRef(value *PoolObjectRefe
 	b.PoolRef = value
 	return b
 }
+
+// WithSchedulingProfile sets the SchedulingProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulingProfile field is set to the value of the last call.
+func (b *InferenceModelSpecApplyConfiguration) WithSchedulingProfile(value string) *InferenceModelSpecApplyConfiguration {
+	b.SchedulingProfile = &value
+	return b
+}
@@ -28,6 +28,8 @@ type InferencePoolSpecApplyConfiguration struct {
 	Selector                               map[apiv1alpha2.LabelKey]apiv1alpha2.LabelValue `json:"selector,omitempty"`
 	TargetPortNumber                       *int32                                          `json:"targetPortNumber,omitempty"`
 	EndpointPickerConfigApplyConfiguration `json:",inline"`
+	EndpointPickerConfigRef                *EndpointPickerConfigReferenceApplyConfiguration `json:"endpointPickerConfigRef,omitempty"`
+	Metrics                                *MetricsEndpointApplyConfiguration               `json:"metrics,omitempty"`
 }
 
 // InferencePoolSpecApplyConfiguration constructs a declarative configuration of the InferencePoolSpec type for use with
@@ -65,3 +67,19 @@ func (b *InferencePoolSpecApplyConfiguration) WithExtensionRef(value *ExtensionA
 	b.EndpointPickerConfigApplyConfiguration.ExtensionRef = value
 	return b
 }
+
+// WithEndpointPickerConfigRef sets the EndpointPickerConfigRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndpointPickerConfigRef field is set to the value of the last call.
+func (b *InferencePoolSpecApplyConfiguration) WithEndpointPickerConfigRef(value *EndpointPickerConfigReferenceApplyConfiguration) *InferencePoolSpecApplyConfiguration {
+	b.EndpointPickerConfigRef = value
+	return b
+}
+
+// WithMetrics sets the Metrics field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Metrics field is set to the value of the last call.
+func (b *InferencePoolSpecApplyConfiguration) WithMetrics(value *MetricsEndpointApplyConfiguration) *InferencePoolSpecApplyConfiguration {
+	b.Metrics = value
+	return b
+}
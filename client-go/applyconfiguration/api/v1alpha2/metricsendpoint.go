@@ -0,0 +1,79 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	apiv1alpha2 "sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+)
+
+// MetricsEndpointApplyConfiguration represents a declarative configuration of the MetricsEndpoint type for use
+// with apply.
+type MetricsEndpointApplyConfiguration struct {
+	Path               *string                               `json:"path,omitempty"`
+	Port               *apiv1alpha2.PortNumber               `json:"port,omitempty"`
+	Scheme             *apiv1alpha2.MetricsScheme            `json:"scheme,omitempty"`
+	TLS                *MetricsTLSConfigApplyConfiguration   `json:"tls,omitempty"`
+	AuthTokenSecretRef *SecretKeyReferenceApplyConfiguration `json:"authTokenSecretRef,omitempty"`
+}
+
+// MetricsEndpointApplyConfiguration constructs a declarative configuration of the MetricsEndpoint type for use with
+// apply.
+func MetricsEndpoint() *MetricsEndpointApplyConfiguration {
+	return &MetricsEndpointApplyConfiguration{}
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *MetricsEndpointApplyConfiguration) WithPath(value string) *MetricsEndpointApplyConfiguration {
+	b.Path = &value
+	return b
+}
+
+// WithPort sets the Port field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Port field is set to the value of the last call.
+func (b *MetricsEndpointApplyConfiguration) WithPort(value apiv1alpha2.PortNumber) *MetricsEndpointApplyConfiguration {
+	b.Port = &value
+	return b
+}
+
+// WithScheme sets the Scheme field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Scheme field is set to the value of the last call.
+func (b *MetricsEndpointApplyConfiguration) WithScheme(value apiv1alpha2.MetricsScheme) *MetricsEndpointApplyConfiguration {
+	b.Scheme = &value
+	return b
+}
+
+// WithTLS sets the TLS field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TLS field is set to the value of the last call.
+func (b *MetricsEndpointApplyConfiguration) WithTLS(value *MetricsTLSConfigApplyConfiguration) *MetricsEndpointApplyConfiguration {
+	b.TLS = value
+	return b
+}
+
+// WithAuthTokenSecretRef sets the AuthTokenSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AuthTokenSecretRef field is set to the value of the last call.
+func (b *MetricsEndpointApplyConfiguration) WithAuthTokenSecretRef(value *SecretKeyReferenceApplyConfiguration) *MetricsEndpointApplyConfiguration {
+	b.AuthTokenSecretRef = value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha2
+
+// MetricsTLSConfigApplyConfiguration represents a declarative configuration of the MetricsTLSConfig type for use
+// with apply.
+type MetricsTLSConfigApplyConfiguration struct {
+	InsecureSkipVerify *bool                                 `json:"insecureSkipVerify,omitempty"`
+	CASecretRef        *SecretKeyReferenceApplyConfiguration `json:"caSecretRef,omitempty"`
+}
+
+// MetricsTLSConfigApplyConfiguration constructs a declarative configuration of the MetricsTLSConfig type for use with
+// apply.
+func MetricsTLSConfig() *MetricsTLSConfigApplyConfiguration {
+	return &MetricsTLSConfigApplyConfiguration{}
+}
+
+// WithInsecureSkipVerify sets the InsecureSkipVerify field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InsecureSkipVerify field is set to the value of the last call.
+func (b *MetricsTLSConfigApplyConfiguration) WithInsecureSkipVerify(value bool) *MetricsTLSConfigApplyConfiguration {
+	b.InsecureSkipVerify = &value
+	return b
+}
+
+// WithCASecretRef sets the CASecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CASecretRef field is set to the value of the last call.
+func (b *MetricsTLSConfigApplyConfiguration) WithCASecretRef(value *SecretKeyReferenceApplyConfiguration) *MetricsTLSConfigApplyConfiguration {
+	b.CASecretRef = value
+	return b
+}
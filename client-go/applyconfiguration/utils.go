@@ -34,6 +34,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 	// Group=inference.networking.x-k8s.io, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithKind("EndpointPickerConfig"):
 		return &apiv1alpha2.EndpointPickerConfigApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("EndpointPickerConfigReference"):
+		return &apiv1alpha2.EndpointPickerConfigReferenceApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("Extension"):
 		return &apiv1alpha2.ExtensionApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("ExtensionConnection"):
@@ -52,10 +54,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &apiv1alpha2.InferencePoolSpecApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("InferencePoolStatus"):
 		return &apiv1alpha2.InferencePoolStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("MetricsEndpoint"):
+		return &apiv1alpha2.MetricsEndpointApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("MetricsTLSConfig"):
+		return &apiv1alpha2.MetricsTLSConfigApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("PoolObjectReference"):
 		return &apiv1alpha2.PoolObjectReferenceApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("PoolStatus"):
 		return &apiv1alpha2.PoolStatusApplyConfiguration{}
+	case v1alpha2.SchemeGroupVersion.WithKind("SecretKeyReference"):
+		return &apiv1alpha2.SecretKeyReferenceApplyConfiguration{}
 	case v1alpha2.SchemeGroupVersion.WithKind("TargetModel"):
 		return &apiv1alpha2.TargetModelApplyConfiguration{}
 
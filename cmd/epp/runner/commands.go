@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/common/config/loader"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+)
+
+// Commands are the subcommands of the EPP. A subcommand is run instead of the EPP when its name is the first
+// argument. The subcommands use the plugins registered before they are run.
+var Commands = map[string]func(args []string, out io.Writer) error{
+	"validate-config": ValidateConfig,
+	"explain-plugins": ExplainPlugins,
+}
+
+// ValidateConfig checks a configuration offline: the configuration is strictly decoded, the parameters of the plugins
+// are validated against their schemas, the plugins are instantiated and the scheduling profiles are built.
+func ValidateConfig(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("validate-config", flag.ContinueOnError)
+	configFile := flags.String("configFile", "", "The path to the configuration file")
+	configText := flags.String("configText", "", "The configuration specified as text, in lieu of a file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if (len(*configText) == 0) == (len(*configFile) == 0) {
+		return errors.New("exactly one of the configFile and configText flags must be set")
+	}
+
+	theConfig, err := loader.LoadConfig([]byte(*configText), *configFile)
+	if err != nil {
+		return err
+	}
+	handle := NewEppHandle(nil, loader.CustomMetricNames(theConfig.CustomMetrics))
+	if err := loader.LoadPluginReferences(theConfig.Plugins, handle); err != nil {
+		return err
+	}
+	if _, err := loader.LoadSchedulerConfig(theConfig.SchedulingProfiles, handle); err != nil {
+		return fmt.Errorf("the scheduling profiles are invalid. Error: %s", err)
+	}
+	_, err = fmt.Fprintf(out, "The configuration is valid: %d plugins, %d scheduling profiles\n",
+		len(theConfig.Plugins), len(theConfig.SchedulingProfiles))
+	return err
+}
+
+// pluginExplanation describes a registered plugin type.
+type pluginExplanation struct {
+	Type            string                   `json:"type"`
+	ExtensionPoints []string                 `json:"extensionPoints"`
+	Parameters      *plugins.ParameterSchema `json:"parameters,omitempty"`
+}
+
+// ExplainPlugins prints the registered plugin types, with the extension points declared on their registration and the schema of
+// their parameters.
+func ExplainPlugins(args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("explain-plugins", flag.ContinueOnError)
+	output := flags.String("output", "text", "The output format, one of text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *output != "text" && *output != "json" {
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	explanations := []pluginExplanation{}
+	for _, pluginType := range slices.Sorted(maps.Keys(plugins.Registry)) {
+		registration := plugins.Registry[pluginType]
+		explanations = append(explanations, pluginExplanation{
+			Type:            pluginType,
+			ExtensionPoints: registration.ExtensionPoints,
+			Parameters:      registration.Parameters,
+		})
+	}
+
+	if *output == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(explanations)
+	}
+	var sb strings.Builder
+	for _, explanation := range explanations {
+		fmt.Fprintf(&sb, "%s\n", explanation.Type)
+		if len(explanation.ExtensionPoints) == 0 {
+			sb.WriteString("  Extension points: not declared\n")
+		} else {
+			fmt.Fprintf(&sb, "  Extension points: %s\n", strings.Join(explanation.ExtensionPoints, ", "))
+		}
+		switch {
+		case explanation.Parameters == nil:
+			sb.WriteString("  Parameters: no schema published\n")
+		case len(explanation.Parameters.Properties) == 0:
+			sb.WriteString("  Parameters: none\n")
+		default:
+			sb.WriteString("  Parameters:\n")
+			writeParameters(&sb, explanation.Parameters, "    ")
+		}
+	}
+	_, err := io.WriteString(out, sb.String())
+	return err
+}
+
+func writeParameters(sb *strings.Builder, schema *plugins.ParameterSchema, indent string) {
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		property := schema.Properties[name]
+		fmt.Fprintf(sb, "%s%s (%s", indent, name, property.Type)
+		if property.Default != nil {
+			fmt.Fprintf(sb, ", default %v", property.Default)
+		}
+		sb.WriteString(")")
+		if property.Description != "" {
+			fmt.Fprintf(sb, ": %s", property.Description)
+		}
+		sb.WriteString("\n")
+		writeParameters(sb, property, indent+"  ")
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+)
+
+const validConfigText = `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: hash
+  type: consistent-hash
+  parameters:
+    keyHeader: x-session-id
+- name: queue
+  type: queue
+- name: picker
+  type: max-score
+- name: profileHandler
+  type: single-profile
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: hash
+    weight: 2
+  - pluginRef: queue
+    weight: 1
+  - pluginRef: picker
+`
+
+func TestValidateConfig(t *testing.T) {
+	RegisterAllPlugins()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configFile, []byte(validConfigText), 0o600); err != nil {
+		t.Fatalf("Unexpected error writing the configuration file: %v", err)
+	}
+
+	customMetricConfigText := strings.Replace(validConfigText, "- name: picker\n",
+		"- name: running\n  type: custom-metric\n  parameters:\n    metric: running\n- name: picker\n", 1)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantOut string
+		wantErr string
+	}{
+		{
+			name:    "valid configuration text",
+			args:    []string{"-configText", validConfigText},
+			wantOut: "The configuration is valid: 4 plugins, 1 scheduling profiles\n",
+		},
+		{
+			name:    "valid configuration file",
+			args:    []string{"-configFile", configFile},
+			wantOut: "The configuration is valid: 4 plugins, 1 scheduling profiles\n",
+		},
+		{
+			name:    "unknown plugin type",
+			args:    []string{"-configText", strings.Replace(validConfigText, "type: queue", "type: unknown", 1)},
+			wantErr: "unknown",
+		},
+		{
+			name:    "unknown plugin reference",
+			args:    []string{"-configText", strings.Replace(validConfigText, "pluginRef: queue", "pluginRef: missing", 1)},
+			wantErr: "is a reference to an undefined Plugin",
+		},
+		{
+			name:    "declared custom metric",
+			args:    []string{"-configText", customMetricConfigText + "customMetrics:\n- name: running\n  metric: vllm:num_requests_running\n"},
+			wantOut: "The configuration is valid: 5 plugins, 1 scheduling profiles\n",
+		},
+		{
+			name:    "undeclared custom metric",
+			args:    []string{"-configText", customMetricConfigText},
+			wantErr: "is not declared in the customMetrics of the configuration",
+		},
+		{
+			name:    "no configuration",
+			args:    []string{},
+			wantErr: "exactly one of the configFile and configText flags must be set",
+		},
+		{
+			name:    "both configuration flags",
+			args:    []string{"-configFile", configFile, "-configText", validConfigText},
+			wantErr: "exactly one of the configFile and configText flags must be set",
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-unknown"},
+			wantErr: "flag provided but not defined",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := ValidateConfig(test.args, &out)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("Expected an error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if out.String() != test.wantOut {
+				t.Errorf("Unexpected output %q, want %q", out.String(), test.wantOut)
+			}
+		})
+	}
+}
+
+func TestExplainPlugins(t *testing.T) {
+	RegisterAllPlugins()
+
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := ExplainPlugins([]string{}, &out); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, want := range []string{
+			"consistent-hash\n  Extension points: Scorer\n",
+			"decision-tree\n  Extension points: Filter\n",
+			"prefix-cache\n  Extension points: Scorer, PostCycle\n",
+			"shadow-profile\n  Extension points: ProfileHandler\n",
+			"max-score\n  Extension points: Picker\n  Parameters: none\n",
+		} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("Expected the output to contain %q, got:\n%s", want, out.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := ExplainPlugins([]string{"-output", "json"}, &out); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		explanations := []pluginExplanation{}
+		if err := json.Unmarshal(out.Bytes(), &explanations); err != nil {
+			t.Fatalf("Unexpected error decoding the output: %v", err)
+		}
+		if len(explanations) != len(plugins.Registry) {
+			t.Errorf("Expected %d plugins, got %d", len(plugins.Registry), len(explanations))
+		}
+		for _, explanation := range explanations {
+			if len(explanation.ExtensionPoints) == 0 {
+				t.Errorf("Expected the extension points of %s to be declared", explanation.Type)
+			}
+			if explanation.Type == "custom-metric" &&
+				(len(explanation.ExtensionPoints) != 1 || explanation.ExtensionPoints[0] != framework.ScorerPluginType) {
+				t.Errorf("Unexpected extension points of custom-metric: %v", explanation.ExtensionPoints)
+			}
+		}
+	})
+
+	t.Run("undeclared extension points", func(t *testing.T) {
+		plugins.Register("undeclared", nil)
+		defer delete(plugins.Registry, "undeclared")
+		var out bytes.Buffer
+		if err := ExplainPlugins([]string{}, &out); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := "undeclared\n  Extension points: not declared\n  Parameters: no schema published\n"; !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the output to contain %q, got:\n%s", want, out.String())
+		}
+	})
+
+	t.Run("unknown output format", func(t *testing.T) {
+		if err := ExplainPlugins([]string{"-output", "yaml"}, &bytes.Buffer{}); err == nil {
+			t.Error("Expected an error for an unknown output format")
+		}
+	})
+}
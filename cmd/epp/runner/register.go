@@ -17,7 +17,14 @@ limitations under the License.
 package runner
 
 import (
+	"k8s.io/apimachinery/pkg/types"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/loadreport"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/filter"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
@@ -25,23 +32,37 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
 )
 
-// RegisterAllPlugins registers the factory functions of all known plugins
+// RegisterAllPlugins registers the factory functions of all known plugins, with the schemas of their parameters and the
+// extension points they implement
 func RegisterAllPlugins() {
-	plugins.Register(filter.LeastKVCacheFilterType, filter.LeastKVCacheFilterFactory)
-	plugins.Register(filter.LeastQueueFilterType, filter.LeastQueueFilterFactory)
-	plugins.Register(filter.LoraAffinityFilterType, filter.LoraAffinityFilterFactory)
-	plugins.Register(filter.LowQueueFilterType, filter.LowQueueFilterFactory)
-	plugins.Register(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory)
-	plugins.Register(picker.MaxScorePickerType, picker.MaxScorePickerFactory)
-	plugins.Register(picker.RandomPickerType, picker.RandomPickerFactory)
-	plugins.Register(profile.SingleProfileHandlerType, profile.SingleProfileHandlerFactory)
-	plugins.Register(scorer.KvCacheScorerType, scorer.KvCacheScorerFactory)
-	plugins.Register(scorer.QueueScorerType, scorer.QueueScorerFactory)
+	plugins.RegisterWithSchema(loadreport.OrcaLoadReportPluginType, loadreport.OrcaLoadReportPluginFactory, loadreport.OrcaLoadReportPluginParameterSchema, requestcontrol.PostResponsePluginType)
+	plugins.RegisterWithSchema(filter.DecisionTreeFilterType, filter.DecisionTreeFilterFactory, filter.DecisionTreeFilterParameterSchema, framework.FilterPluginType)
+	plugins.RegisterWithSchema(filter.LeastKVCacheFilterType, filter.LeastKVCacheFilterFactory, plugins.NoParameters, framework.FilterPluginType)
+	plugins.RegisterWithSchema(filter.LeastQueueFilterType, filter.LeastQueueFilterFactory, plugins.NoParameters, framework.FilterPluginType)
+	plugins.RegisterWithSchema(filter.LoraAffinityFilterType, filter.LoraAffinityFilterFactory, filter.LoraAffinityFilterParameterSchema, framework.FilterPluginType)
+	plugins.RegisterWithSchema(filter.LowQueueFilterType, filter.LowQueueFilterFactory, filter.LowQueueFilterParameterSchema, framework.FilterPluginType)
+	plugins.RegisterWithSchema(filter.MetricsStalenessFilterType, filter.MetricsStalenessFilterFactory, filter.MetricsStalenessFilterParameterSchema, framework.FilterPluginType)
+	plugins.RegisterWithSchema(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory, prefix.PrefixCachePluginParameterSchema, framework.ScorerPluginType, framework.PostCyclePluginType)
+	plugins.RegisterWithSchema(picker.MaxScorePickerType, picker.MaxScorePickerFactory, plugins.NoParameters, framework.PickerPluginType)
+	plugins.RegisterWithSchema(picker.PowerOfTwoPickerType, picker.PowerOfTwoPickerFactory, picker.PowerOfTwoPickerParameterSchema, framework.PickerPluginType)
+	plugins.RegisterWithSchema(picker.RandomPickerType, picker.RandomPickerFactory, plugins.NoParameters, framework.PickerPluginType)
+	plugins.RegisterWithSchema(picker.TopKPickerType, picker.TopKPickerFactory, picker.TopKPickerParameterSchema, framework.PickerPluginType)
+	plugins.RegisterWithSchema(picker.WeightedRandomPickerType, picker.WeightedRandomPickerFactory, picker.WeightedRandomPickerParameterSchema, framework.PickerPluginType)
+	plugins.RegisterWithSchema(profile.RuleBasedProfileHandlerType, profile.RuleBasedProfileHandlerFactory, profile.RuleBasedProfileHandlerParameterSchema, framework.ProfileHandlerPluginType)
+	plugins.RegisterWithSchema(profile.ShadowProfileHandlerType, profile.ShadowProfileHandlerFactory, profile.ShadowProfileHandlerParameterSchema, framework.ProfileHandlerPluginType)
+	plugins.RegisterWithSchema(profile.SingleProfileHandlerType, profile.SingleProfileHandlerFactory, plugins.NoParameters, framework.ProfileHandlerPluginType)
+	plugins.RegisterWithSchema(scorer.ConsistentHashScorerType, scorer.ConsistentHashScorerFactory, scorer.ConsistentHashScorerParameterSchema, framework.ScorerPluginType)
+	plugins.RegisterWithSchema(scorer.CustomMetricScorerType, scorer.CustomMetricScorerFactory, scorer.CustomMetricScorerParameterSchema, framework.ScorerPluginType)
+	plugins.RegisterWithSchema(scorer.KvCacheScorerType, scorer.KvCacheScorerFactory, plugins.NoParameters, framework.ScorerPluginType)
+	plugins.RegisterWithSchema(scorer.MetricsFreshnessScorerType, scorer.MetricsFreshnessScorerFactory, scorer.MetricsFreshnessScorerParameterSchema, framework.ScorerPluginType)
+	plugins.RegisterWithSchema(scorer.QueueScorerType, scorer.QueueScorerFactory, plugins.NoParameters, framework.ScorerPluginType)
 }
 
 // eppHandle is an implementation of the interface plugins.Handle
 type eppHandle struct {
-	plugins plugins.HandlePlugins
+	plugins       plugins.HandlePlugins
+	datastore     datastore.Datastore
+	customMetrics []string
 }
 
 // Plugins returns the sub-handle for working with instantiated plugins
@@ -49,6 +70,19 @@ func (h *eppHandle) Plugins() plugins.HandlePlugins {
 	return h.plugins
 }
 
+// PodGet returns the metrics of the named pod of the datastore, or nil if the handle has no datastore
+func (h *eppHandle) PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics {
+	if h.datastore == nil {
+		return nil
+	}
+	return h.datastore.PodGet(namespacedName)
+}
+
+// CustomMetrics returns the names of the custom metrics declared in the configuration
+func (h *eppHandle) CustomMetrics() []string {
+	return h.customMetrics
+}
+
 // eppHandlePlugins implements the set of APIs to work with instantiated plugins
 type eppHandlePlugins struct {
 	thePlugins map[string]plugins.Plugin
@@ -78,10 +112,14 @@ func (h *eppHandlePlugins) GetAllPluginsWithNames() map[string]plugins.Plugin {
 	return h.thePlugins
 }
 
-func newEppHandle() *eppHandle {
+// NewEppHandle returns an empty plugins.Handle, to be populated with the plugins instantiated from the configuration.
+// The datastore is nil when the plugins are not instantiated to serve requests.
+func NewEppHandle(datastore datastore.Datastore, customMetrics []string) plugins.Handle {
 	return &eppHandle{
 		plugins: &eppHandlePlugins{
 			thePlugins: map[string]plugins.Plugin{},
 		},
+		datastore:     datastore,
+		customMetrics: customMetrics,
 	}
 }
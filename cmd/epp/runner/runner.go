@@ -20,6 +20,8 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
@@ -29,28 +31,27 @@ import (
 	healthPb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	conformance_epp "sigs.k8s.io/gateway-api-inference-extension/conformance/testing-epp"
 	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/common/config/loader"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/loadreport"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics/collectors"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
-	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
-	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
-	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
-	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
-	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
 	runserver "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/server"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	envutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/env"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -66,6 +67,32 @@ var (
 		"The port used for gRPC liveness and readiness probes")
 	metricsPort = flag.Int(
 		"metricsPort", 9090, "The metrics port")
+	httpProxyPort = flag.Int(
+		"httpProxyPort",
+		runserver.DefaultHTTPProxyPort,
+		"The port of an OpenAI-compatible HTTP listener that proxies requests to the selected endpoint without a gateway. "+
+			"The listener is disabled when set to 0.")
+	httpProxyBackendTLS = flag.Bool("httpProxyBackendTLS", false,
+		"Whether the HTTP listener proxies the requests to the model servers over HTTPS.")
+	httpProxyBackendInsecureSkipVerify = flag.Bool("httpProxyBackendInsecureSkipVerify", false,
+		"Disables the verification of the certificates of the model servers the HTTP listener proxies the requests to over HTTPS.")
+	httpProxyMaxRequestBodyBytes = flag.Int64("httpProxyMaxRequestBodyBytes", runserver.DefaultHTTPProxyMaxRequestBodyBytes,
+		"The maximum size of the body of the requests of the HTTP listener. Larger requests are rejected with a 413 status.")
+	loadReportPort = flag.Int(
+		"loadReportPort",
+		runserver.DefaultLoadReportPort,
+		"The port of the HTTP endpoint the model servers, or their sidecars, push their load reports to, in lieu of "+
+			"having their metrics scraped. A load report is only accepted from the address of the pod it reports the "+
+			"load of. The endpoint is disabled when set to 0.")
+	loadReportAuthTokenFile = flag.String("loadReportAuthTokenFile", "",
+		"The path to a file holding the bearer token the load reports must carry. If empty, the load reports are "+
+			"not authenticated.")
+	orcaLoadReports = flag.Bool("orcaLoadReports", false,
+		"Updates the metrics of the pods with the ORCA load reports attached to the '"+loadreport.OrcaHeaderKey+
+			"' headers of their responses, in addition to scraping them, with the default metrics mapping of the '"+
+			loadreport.OrcaLoadReportPluginType+"' plugin. Configure the plugin instead to map other metrics.")
+	loadReportPushTimeout = flag.Duration("loadReportPushTimeout", backendmetrics.DefaultPushTimeout,
+		"The time the scraping of a pod is skipped for after it pushed a load report.")
 	destinationEndpointHintKey = flag.String(
 		"destinationEndpointHintKey",
 		runserver.DefaultDestinationEndpointHintKey,
@@ -87,6 +114,16 @@ var (
 		"refreshMetricsInterval",
 		runserver.DefaultRefreshMetricsInterval,
 		"interval to refresh metrics")
+	scrapeConcurrency = flag.Int("scrapeConcurrency", backendmetrics.DefaultScrapeConcurrency,
+		"The maximum number of model servers scraped concurrently.")
+	scrapeTimeout = flag.Duration("scrapeTimeout", backendmetrics.DefaultScrapeTimeout,
+		"The timeout of a scrape of the metrics of a model server.")
+	scrapeJitter = flag.Float64("scrapeJitter", backendmetrics.DefaultScrapeJitter,
+		"The fraction of the refresh interval the scrapes are randomly shifted by, so that the model servers are not "+
+			"all scraped at once.")
+	maxScrapeBackoff = flag.Duration("maxScrapeBackoff", backendmetrics.DefaultMaxScrapeBackoff,
+		"The maximum interval between the scrapes of a model server that fails to be scraped. The interval doubles "+
+			"with every failure, starting from the refresh interval.")
 	refreshPrometheusMetricsInterval = flag.Duration(
 		"refreshPrometheusMetricsInterval",
 		runserver.DefaultRefreshPrometheusMetricsInterval,
@@ -103,22 +140,75 @@ var (
 	totalQueuedRequestsMetric = flag.String("totalQueuedRequestsMetric",
 		"vllm:num_requests_waiting",
 		"Prometheus metric for the number of queued requests.")
+	totalRunningRequestsMetric = flag.String("totalRunningRequestsMetric",
+		"vllm:num_requests_running",
+		"Prometheus metric for the number of running requests.")
 	kvCacheUsagePercentageMetric = flag.String("kvCacheUsagePercentageMetric",
 		"vllm:gpu_cache_usage_perc",
 		"Prometheus metric for the fraction of KV-cache blocks currently in use (from 0 to 1).")
+	kvCacheInfoMetric = flag.String("kvCacheInfoMetric",
+		"vllm:cache_config_info",
+		"Prometheus metric for the KV-cache block size and number of GPU blocks (must be in vLLM label format). "+
+			"The KV-cache token capacity is their product.")
+	// model server metrics endpoint flags, overridden by the metrics field of the InferencePool
+	modelServerMetricsPath = flag.String("modelServerMetricsPath", backendmetrics.DefaultMetricsPath,
+		"The HTTP path of the metrics endpoint of the model servers.")
+	modelServerMetricsPort = flag.Int("modelServerMetricsPort", 0,
+		"The port of the metrics endpoint of the model servers. If 0, the target port of the InferencePool is used.")
+	modelServerMetricsScheme = flag.String("modelServerMetricsScheme", string(v1alpha2.MetricsSchemeHTTP),
+		"The scheme of the metrics endpoint of the model servers, HTTP or HTTPS.")
+	modelServerMetricsInsecureSkipVerify = flag.Bool("modelServerMetricsInsecureSkipVerify", false,
+		"Disables the verification of the certificates of the HTTPS metrics endpoints of the model servers.")
+	modelServerMetricsCASecret = flag.String("modelServerMetricsCASecret", "",
+		"The name of the Secret, in the namespace of the InferencePool, holding the certificate authorities of the "+
+			"HTTPS metrics endpoints of the model servers under the key '"+backendmetrics.DefaultCASecretKey+"'.")
+	modelServerMetricsAuthTokenSecret = flag.String("modelServerMetricsAuthTokenSecret", "",
+		"The name of the Secret, in the namespace of the InferencePool, holding the bearer token sent to the "+
+			"metrics endpoints of the model servers under the key '"+backendmetrics.DefaultAuthTokenSecretKey+"'.")
+	modelServerMetricsFormats = flag.String("modelServerMetricsFormats", scrapeFormatsString(backendmetrics.DefaultScrapeFormats),
+		"The comma-separated exposition formats accepted from the metrics endpoints of the model servers, by "+
+			"decreasing preference. Each one of protobuf, openmetrics and text.")
 	// LoRA metrics
 	loraInfoMetric = flag.String("loraInfoMetric",
 		"vllm:lora_requests_info",
 		"Prometheus metric for the LoRA info metrics (must be in vLLM label format).")
+	metricPreset = flag.String("metricPreset", "",
+		"The metric preset of the model servers, in lieu of the metric flags. One of "+
+			strings.Join(backendmetrics.MetricPresetNames(), ", ")+".")
+	metricPresetKey = flag.String("metricPresetKey", backendmetrics.DefaultMetricPresetKey,
+		"The label or annotation of the pods naming the metric preset of their model server, overriding the "+
+			"metric flags so that a pool can mix model servers. If empty, the pods are scraped alike.")
+	customMetricsRateWindow = flag.Duration("customMetricsRateWindow", backendmetrics.DefaultRateWindow,
+		"The window the rates and histogram quantiles of the custom metrics are computed over.")
 	// configuration flags
-	configFile = flag.String("configFile", "", "The path to the configuration file")
-	configText = flag.String("configText", "", "The configuration specified as text, in lieu of a file")
+	configFile   = flag.String("configFile", "", "The path to the configuration file")
+	configText   = flag.String("configText", "", "The configuration specified as text, in lieu of a file")
+	configPreset = flag.String("configPreset", "",
+		"The name of a built-in configuration preset, in lieu of a file. One of "+strings.Join(loader.PresetNames(), ", ")+".")
+	configReloadInterval = flag.Duration("configReloadInterval", 0,
+		"The interval at which the configuration file is checked for changes, which are applied without restarting. Disabled if zero. Requires configFile.")
+	watchConfigResource = flag.Bool("watchConfigResource", false,
+		"Watches the EndpointPickerConfig resource referenced by the InferencePool, and applies its changes without restarting. "+
+			"The EndpointPickerConfig CRD must be installed.")
+	// tracing flags
+	tracingEnabled  = flag.Bool("tracing", false, "Enables the OpenTelemetry tracing of the requests, exported over OTLP gRPC.")
+	tracingEndpoint = flag.String("tracingEndpoint", "",
+		"The address of the OTLP gRPC collector. If not set, the OTEL_EXPORTER_OTLP_ENDPOINT environment variable is used, with a fallback to localhost:4317.")
+	tracingInsecure = flag.Bool("tracingInsecure", false, "Disables the transport security of the connection to the OTLP collector.")
+	tracingSampler  = flag.String("tracingSampler", tracing.ParentBasedTraceIDRatioSampler,
+		"The trace sampler, one of always_on, always_off, traceidratio, parentbased_always_on, parentbased_always_off and parentbased_traceidratio.")
+	tracingSamplingRatio = flag.Float64("tracingSamplingRatio", 0.1, "The ratio of sampled traces for the ratio based samplers, between 0 and 1.")
+	// scheduling decision trace flags
+	schedulingTrace = flag.Bool("schedulingTrace", false,
+		"Records the scheduling decision trace of every request (candidate pods, filtered pods, scores and picked pod) and logs it.")
+	schedulingTraceHeader = flag.Bool("schedulingTraceHeader", false,
+		"Returns the scheduling decision trace in the x-gateway-scheduling-trace response header of the requests that set the "+
+			"x-gateway-scheduling-trace request header to true. Requires schedulingTrace. The trace exposes the pods of the pool, "+
+			"so this is meant for debugging only and should not be enabled in production.")
 
 	setupLog = ctrl.Log.WithName("setup")
 
 	// Environment variables
-	schedulerV2                       = envutil.GetEnvBool("EXPERIMENTAL_USE_SCHEDULER_V2", false, setupLog)
-	prefixCacheScheduling             = envutil.GetEnvBool("ENABLE_PREFIX_CACHE_SCHEDULING", false, setupLog)
 	reqHeaderBasedSchedulerForTesting = envutil.GetEnvBool("ENABLE_REQ_HEADER_BASED_SCHEDULER_FOR_TESTING", false, setupLog)
 )
 
@@ -166,8 +256,24 @@ func (r *Runner) Run(ctx context.Context) error {
 	})
 	setupLog.Info("Flags processed", "flags", flags)
 
-	// --- Load Configurations from Environment Variables ---
-	sdConfig := saturationdetector.LoadConfigFromEnv()
+	// --- Setup Tracing ---
+	if *tracingEnabled {
+		shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+			Endpoint:      *tracingEndpoint,
+			Insecure:      *tracingInsecure,
+			Sampler:       *tracingSampler,
+			SamplingRatio: *tracingSamplingRatio,
+		})
+		if err != nil {
+			setupLog.Error(err, "Failed to setup tracing")
+			return err
+		}
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				setupLog.Error(err, "Failed to shutdown tracing")
+			}
+		}()
+	}
 
 	// --- Get Kubernetes Config ---
 	cfg, err := ctrl.GetConfig()
@@ -177,17 +283,46 @@ func (r *Runner) Run(ctx context.Context) error {
 	}
 
 	// --- Setup Datastore ---
-	mapping, err := backendmetrics.NewMetricMapping(
-		*totalQueuedRequestsMetric,
-		*kvCacheUsagePercentageMetric,
-		*loraInfoMetric,
-	)
+	var mapping *backendmetrics.MetricMapping
+	if *metricPreset != "" {
+		mapping, err = backendmetrics.MetricPreset(*metricPreset)
+	} else {
+		mapping, err = backendmetrics.NewMetricMapping(
+			*totalQueuedRequestsMetric,
+			*totalRunningRequestsMetric,
+			*kvCacheUsagePercentageMetric,
+			*kvCacheInfoMetric,
+			*loraInfoMetric,
+		)
+	}
 	if err != nil {
 		setupLog.Error(err, "Failed to create metric mapping from flags.")
 		return err
 	}
 	verifyMetricMapping(*mapping, setupLog)
-	pmf := backendmetrics.NewPodMetricsFactory(&backendmetrics.PodMetricsClientImpl{MetricMapping: mapping}, *refreshMetricsInterval)
+	metricsEndpoint, err := modelServerMetricsEndpoint()
+	if err != nil {
+		setupLog.Error(err, "Failed to configure the metrics endpoint of the model servers")
+		return err
+	}
+	scrapeFormats, err := backendmetrics.ParseScrapeFormats(*modelServerMetricsFormats)
+	if err != nil {
+		setupLog.Error(err, "Failed to parse the metrics formats of the model servers")
+		return err
+	}
+	metricsClient := &backendmetrics.PodMetricsClientImpl{
+		MetricMapping:   mapping,
+		MetricPresetKey: *metricPresetKey,
+		Endpoint:        metricsEndpoint,
+		RateWindow:      *customMetricsRateWindow,
+		ScrapeFormats:   scrapeFormats,
+	}
+	pmf := backendmetrics.NewPodMetricsFactory(metricsClient, *refreshMetricsInterval)
+	pmf.PushTimeout = *loadReportPushTimeout
+	pmf.ScrapeConcurrency = *scrapeConcurrency
+	pmf.ScrapeTimeout = *scrapeTimeout
+	pmf.ScrapeJitter = *scrapeJitter
+	pmf.MaxScrapeBackoff = *maxScrapeBackoff
 
 	datastore := datastore.NewDatastore(ctx, pmf)
 
@@ -214,15 +349,55 @@ func (r *Runner) Run(ctx context.Context) error {
 		setupLog.Error(err, "Failed to create controller manager")
 		return err
 	}
+	// The referenced Secrets are read through the uncached API reader, so that the Secrets of the namespace are neither
+	// listed nor watched.
+	metricsClient.SecretReader = backendmetrics.NewSecretReader(mgr.GetAPIReader(), backendmetrics.DefaultSecretTTL)
 
-	if len(*configText) != 0 || len(*configFile) != 0 {
-		theConfig, err := loader.LoadConfig([]byte(*configText), *configFile)
+	r.requestControlConfig.WithSchedulingTraceHeader(*schedulingTraceHeader)
+	if *orcaLoadReports {
+		orcaMapping, err := loadreport.ParseOrcaMapping(loadreport.DefaultOrcaMapping)
 		if err != nil {
-			setupLog.Error(err, "Failed to load the configuration")
+			setupLog.Error(err, "Failed to parse the ORCA metrics mapping")
 			return err
 		}
+		r.requestControlConfig.AddPlugins(loadreport.NewOrcaLoadReportPlugin(datastore, orcaMapping))
+	}
 
-		epp := newEppHandle()
+	// The applier applies the new versions of the configuration, from the reloaded file or from the
+	// EndpointPickerConfig resource referenced by the InferencePool.
+	configApplier := &loader.Applier{
+		RequestControlConfig: r.requestControlConfig.Clone(),
+		NewHandle:            func(customMetrics []string) plugins.Handle { return NewEppHandle(datastore, customMetrics) },
+		DecisionTrace:        *schedulingTrace,
+		MetricsClient:        metricsClient,
+	}
+	var reloader *loader.Reloader
+	var configBytes []byte
+	var theConfig *configapi.EndpointPickerConfig
+	switch {
+	case len(*configText) != 0 || len(*configFile) != 0:
+		configBytes = []byte(*configText)
+		if *configReloadInterval > 0 {
+			// Read the file here, so that the reloader compares the next versions with the one that is loaded.
+			configBytes, err = os.ReadFile(*configFile)
+			if err != nil {
+				setupLog.Error(err, "Failed to read the configuration file")
+				return err
+			}
+		}
+		theConfig, err = loader.LoadConfig(configBytes, *configFile)
+	case len(*configPreset) != 0:
+		theConfig, err = loader.LoadPreset(*configPreset)
+	default:
+		// For backwards compatibility, the deprecated environment variables are mapped onto a preset.
+		theConfig, err = loader.LoadConfigFromEnv(setupLog)
+	}
+	if err != nil {
+		setupLog.Error(err, "Failed to load the configuration")
+		return err
+	}
+	if theConfig != nil {
+		epp := NewEppHandle(datastore, loader.CustomMetricNames(theConfig.CustomMetrics))
 
 		err = loader.LoadPluginReferences(theConfig.Plugins, epp)
 		if err != nil {
@@ -236,20 +411,49 @@ func (r *Runner) Run(ctx context.Context) error {
 			return err
 		}
 
+		customMetrics, err := loader.LoadCustomMetrics(theConfig.CustomMetrics)
+		if err != nil {
+			setupLog.Error(err, "Failed to load the custom metrics")
+			return err
+		}
+		metricsClient.SetCustomMetrics(customMetrics)
+
+		if *configReloadInterval > 0 {
+			reloader = &loader.Reloader{
+				FileName: *configFile,
+				Interval: *configReloadInterval,
+				Applier:  configApplier,
+			}
+			reloader.SetCurrent(configBytes)
+		}
+		configApplier.SetCurrent(theConfig, epp)
+
 		// Add requestControl plugins
 		r.requestControlConfig.AddPlugins(epp.Plugins().GetAllPlugins()...)
 	}
 
+	// --- Load the Saturation Detector Configuration ---
+	// The deprecated environment variables are overridden by the configuration.
+	sdEnvConfig := saturationdetector.LoadConfigFromEnv()
+	sdConfig := sdEnvConfig
+	if theConfig != nil {
+		sdConfig = saturationdetector.ApplyAPIConfig(sdEnvConfig, theConfig.SaturationDetector)
+	}
+
 	// --- Initialize Core EPP Components ---
 	scheduler, err := r.initializeScheduler()
 	if err != nil {
 		setupLog.Error(err, "Failed to create scheduler")
 		return err
 	}
+	scheduler.WithDecisionTrace(*schedulingTrace)
 
 	saturationDetector := saturationdetector.NewDetector(sdConfig, datastore, ctrl.Log)
 
 	director := requestcontrol.NewDirectorWithConfig(datastore, scheduler, saturationDetector, r.requestControlConfig)
+	configApplier.Director = director
+	configApplier.SaturationDetector = saturationDetector
+	configApplier.SaturationDetectorConfig = sdEnvConfig
 
 	// --- Setup ExtProc Server Runner ---
 	serverRunner := &runserver.ExtProcServerRunner{
@@ -265,6 +469,9 @@ func (r *Runner) Run(ctx context.Context) error {
 		Director:                                 director,
 		SaturationDetector:                       saturationDetector,
 	}
+	if *watchConfigResource {
+		serverRunner.ConfigApplier = configApplier
+	}
 	if err := serverRunner.SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "Failed to setup EPP controllers")
 		return err
@@ -276,11 +483,33 @@ func (r *Runner) Run(ctx context.Context) error {
 		return err
 	}
 
+	// Register the configuration reloader.
+	if reloader != nil {
+		if err := mgr.Add(reloader.AsRunnable()); err != nil {
+			setupLog.Error(err, "Failed to register the configuration reloader")
+			return err
+		}
+	}
+
 	// Register ext-proc server.
 	if err := registerExtProcServer(mgr, serverRunner, ctrl.Log.WithName("ext-proc")); err != nil {
 		return err
 	}
 
+	// Register HTTP reverse-proxy server.
+	if *httpProxyPort != 0 {
+		if err := registerHTTPProxyServer(mgr, serverRunner, *httpProxyPort); err != nil {
+			return err
+		}
+	}
+
+	// Register load report server.
+	if *loadReportPort != 0 {
+		if err := registerLoadReportServer(mgr, serverRunner, *loadReportPort); err != nil {
+			return err
+		}
+	}
+
 	// --- Start Manager ---
 	// This blocks until a signal is received.
 	setupLog.Info("Controller manager starting")
@@ -299,25 +528,6 @@ func (r *Runner) initializeScheduler() (*scheduling.Scheduler, error) {
 
 	// otherwise, no one configured from outside scheduler config. use existing configuration
 	scheduler := scheduling.NewScheduler()
-	if schedulerV2 {
-		queueScorerWeight := envutil.GetEnvInt("QUEUE_SCORE_WEIGHT", scorer.DefaultQueueScorerWeight, setupLog)
-		kvCacheScorerWeight := envutil.GetEnvInt("KV_CACHE_SCORE_WEIGHT", scorer.DefaultKVCacheScorerWeight, setupLog)
-
-		schedulerProfile := framework.NewSchedulerProfile().
-			WithScorers(framework.NewWeightedScorer(scorer.NewQueueScorer(), queueScorerWeight),
-				framework.NewWeightedScorer(scorer.NewKVCacheScorer(), kvCacheScorerWeight)).
-			WithPicker(picker.NewMaxScorePicker())
-
-		if prefixCacheScheduling {
-			prefixScorerWeight := envutil.GetEnvInt("PREFIX_CACHE_SCORE_WEIGHT", prefix.DefaultScorerWeight, setupLog)
-			if err := schedulerProfile.AddPlugins(framework.NewWeightedScorer(prefix.New(loadPrefixCacheConfig()), prefixScorerWeight)); err != nil {
-				return nil, fmt.Errorf("Failed to register scheduler plugins - %w", err)
-			}
-		}
-
-		schedulerConfig := scheduling.NewSchedulerConfig(profile.NewSingleProfileHandler(), map[string]*framework.SchedulerProfile{"schedulerv2": schedulerProfile})
-		scheduler = scheduling.NewSchedulerWithConfig(schedulerConfig)
-	}
 
 	if reqHeaderBasedSchedulerForTesting {
 		scheduler = conformance_epp.NewReqHeaderBasedScheduler()
@@ -344,16 +554,6 @@ func initLogging(opts *zap.Options) {
 	ctrl.SetLogger(logger)
 }
 
-func loadPrefixCacheConfig() prefix.Config {
-	baseLogger := log.Log.WithName("env-config")
-
-	return prefix.Config{
-		HashBlockSize:          envutil.GetEnvInt("PREFIX_CACHE_HASH_BLOCK_SIZE", prefix.DefaultHashBlockSize, baseLogger),
-		MaxPrefixBlocksToMatch: envutil.GetEnvInt("PREFIX_CACHE_MAX_PREFIX_BLOCKS", prefix.DefaultMaxPrefixBlocks, baseLogger),
-		LRUCapacityPerServer:   envutil.GetEnvInt("PREFIX_CACHE_LRU_CAPACITY_PER_SERVER", prefix.DefaultLRUCapacityPerServer, baseLogger),
-	}
-}
-
 // registerExtProcServer adds the ExtProcServerRunner as a Runnable to the manager.
 func registerExtProcServer(mgr manager.Manager, runner *runserver.ExtProcServerRunner, logger logr.Logger) error {
 	if err := mgr.Add(runner.AsRunnable(logger)); err != nil {
@@ -364,6 +564,36 @@ func registerExtProcServer(mgr manager.Manager, runner *runserver.ExtProcServerR
 	return nil
 }
 
+// registerHTTPProxyServer adds the HTTP reverse-proxy server as a Runnable to the manager.
+func registerHTTPProxyServer(mgr manager.Manager, runner *runserver.ExtProcServerRunner, port int) error {
+	if err := mgr.Add(runner.AsHTTPProxyRunnable(port, *httpProxyBackendTLS, *httpProxyBackendInsecureSkipVerify,
+		*httpProxyMaxRequestBodyBytes)); err != nil {
+		setupLog.Error(err, "Failed to register HTTP proxy server runnable")
+		return err
+	}
+	setupLog.Info("HTTP proxy server added to manager.", "port", port)
+	return nil
+}
+
+// registerLoadReportServer adds the load report server as a Runnable to the manager.
+func registerLoadReportServer(mgr manager.Manager, runner *runserver.ExtProcServerRunner, port int) error {
+	var authToken string
+	if *loadReportAuthTokenFile != "" {
+		token, err := os.ReadFile(*loadReportAuthTokenFile)
+		if err != nil {
+			setupLog.Error(err, "Failed to read the auth token of the load reports")
+			return err
+		}
+		authToken = strings.TrimSpace(string(token))
+	}
+	if err := mgr.Add(runner.AsLoadReportRunnable(port, authToken)); err != nil {
+		setupLog.Error(err, "Failed to register load report server runnable")
+		return err
+	}
+	setupLog.Info("Load report server added to manager.", "port", port)
+	return nil
+}
+
 // registerHealthServer adds the Health gRPC server as a Runnable to the given manager.
 func registerHealthServer(mgr manager.Manager, logger logr.Logger, ds datastore.Datastore, port int) error {
 	srv := grpc.NewServer()
@@ -383,9 +613,42 @@ func validateFlags() error {
 	if *poolName == "" {
 		return fmt.Errorf("required %q flag not set", "poolName")
 	}
+	if *httpProxyPort < 0 {
+		return fmt.Errorf("invalid %q flag value %d", "httpProxyPort", *httpProxyPort)
+	}
+	if *httpProxyMaxRequestBodyBytes <= 0 {
+		return fmt.Errorf("invalid %q flag value %d", "httpProxyMaxRequestBodyBytes", *httpProxyMaxRequestBodyBytes)
+	}
+	if *scrapeConcurrency <= 0 {
+		return fmt.Errorf("invalid %q flag value %d", "scrapeConcurrency", *scrapeConcurrency)
+	}
+	if *scrapeJitter < 0 || *scrapeJitter >= 1 {
+		return fmt.Errorf("invalid %q flag value %v, must be in [0, 1)", "scrapeJitter", *scrapeJitter)
+	}
+	if *loadReportPort < 0 {
+		return fmt.Errorf("invalid %q flag value %d", "loadReportPort", *loadReportPort)
+	}
 	if len(*configText) != 0 && len(*configFile) != 0 {
 		return fmt.Errorf("both the %s and %s flags can not be set at the same time", "configText", "configFile")
 	}
+	if len(*configPreset) != 0 && (len(*configText) != 0 || len(*configFile) != 0) {
+		return fmt.Errorf("the %s flag can not be set with the %s or %s flag", "configPreset", "configText", "configFile")
+	}
+	if *configReloadInterval < 0 {
+		return fmt.Errorf("invalid %q flag value %v", "configReloadInterval", *configReloadInterval)
+	}
+	if *configReloadInterval > 0 && len(*configFile) == 0 {
+		return fmt.Errorf("the %s flag requires the %s flag", "configReloadInterval", "configFile")
+	}
+	if *configReloadInterval > 0 && *watchConfigResource {
+		return fmt.Errorf("both the %s and %s flags can not be set at the same time", "configReloadInterval", "watchConfigResource")
+	}
+	if *schedulingTraceHeader && !*schedulingTrace {
+		return fmt.Errorf("the %s flag requires the %s flag", "schedulingTraceHeader", "schedulingTrace")
+	}
+	if *tracingSamplingRatio < 0 || *tracingSamplingRatio > 1 {
+		return fmt.Errorf("invalid %q flag value %v", "tracingSamplingRatio", *tracingSamplingRatio)
+	}
 
 	return nil
 }
@@ -394,10 +657,49 @@ func verifyMetricMapping(mapping backendmetrics.MetricMapping, logger logr.Logge
 	if mapping.TotalQueuedRequests == nil {
 		logger.Info("Not scraping metric: TotalQueuedRequests")
 	}
+	if mapping.TotalRunningRequests == nil {
+		logger.Info("Not scraping metric: TotalRunningRequests")
+	}
 	if mapping.KVCacheUtilization == nil {
 		logger.Info("Not scraping metric: KVCacheUtilization")
 	}
+	if mapping.KVCacheMaxTokenCapacity == nil && mapping.KVCacheInfo == nil &&
+		(mapping.KVCacheBlockSize == nil || mapping.KVCacheNumGPUBlocks == nil) {
+		logger.Info("Not scraping metric: KVCacheMaxTokenCapacity")
+	}
 	if mapping.LoraRequestInfo == nil {
 		logger.Info("Not scraping metric: LoraRequestInfo")
 	}
 }
+
+// scrapeFormatsString returns the flag value of the given scrape formats.
+func scrapeFormatsString(formats []backendmetrics.ScrapeFormat) string {
+	names := make([]string, 0, len(formats))
+	for _, format := range formats {
+		names = append(names, string(format))
+	}
+	return strings.Join(names, ",")
+}
+
+// modelServerMetricsEndpoint returns the configuration of the metrics endpoint of the model servers set by the flags.
+func modelServerMetricsEndpoint() (backendmetrics.MetricsEndpointConfig, error) {
+	endpoint := backendmetrics.MetricsEndpointConfig{
+		Path:               *modelServerMetricsPath,
+		Port:               int32(*modelServerMetricsPort),
+		Scheme:             v1alpha2.MetricsScheme(*modelServerMetricsScheme),
+		InsecureSkipVerify: *modelServerMetricsInsecureSkipVerify,
+	}
+	if *modelServerMetricsCASecret != "" {
+		endpoint.CASecretRef = &v1alpha2.SecretKeyReference{
+			Name: v1alpha2.ObjectName(*modelServerMetricsCASecret),
+			Key:  backendmetrics.DefaultCASecretKey,
+		}
+	}
+	if *modelServerMetricsAuthTokenSecret != "" {
+		endpoint.AuthTokenSecretRef = &v1alpha2.SecretKeyReference{
+			Name: v1alpha2.ObjectName(*modelServerMetricsAuthTokenSecret),
+			Key:  backendmetrics.DefaultAuthTokenSecretKey,
+		}
+	}
+	return endpoint, endpoint.Validate()
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// The simulator replays a recorded request trace against a simulated pool of model servers, using the scheduler
+// built from an EndpointPickerConfig, and reports the latency, the prefix cache hit rate and the load balance
+// across the pods. It runs entirely offline, e.g.:
+//
+//	go run ./cmd/simulator -trace trace.jsonl -configFile config.yaml -numPods 4
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"sigs.k8s.io/gateway-api-inference-extension/cmd/epp/runner"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/common/config/loader"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/simulator"
+)
+
+var (
+	defaultConfig = simulator.DefaultConfig()
+	// The simulator uses its own flag set, the EPP runner registers its flags in the default one.
+	flags = flag.NewFlagSet("simulator", flag.ExitOnError)
+
+	traceFile  = flags.String("trace", "", "The path to the request trace, a file of JSON lines with the fields timestamp, model, promptTokens, outputTokens and optionally prefixId and prefixTokens")
+	configFile = flags.String("configFile", "", "The path to the EndpointPickerConfig file. If not set, the default scheduler is used")
+	configText = flags.String("configText", "", "The EndpointPickerConfig specified as text, in lieu of a file")
+	output     = flags.String("output", "text", "The format of the report, one of text or json")
+
+	numPods                = flags.Int("numPods", defaultConfig.NumPods, "The number of model server pods in the simulated pool")
+	baseModel              = flags.String("baseModel", "", "The base model served by the pods, requests for other models are LoRA adapter requests. If not set, LoRA adapter limits are not simulated")
+	maxNumSeqs             = flags.Int("maxNumSeqs", defaultConfig.Pod.MaxNumSeqs, "The maximum number of sequences in a batch")
+	maxNumBatchedTokens    = flags.Int("maxNumBatchedTokens", defaultConfig.Pod.MaxNumBatchedTokens, "The maximum number of prompt tokens in a prefill step")
+	blockSize              = flags.Int("blockSize", defaultConfig.Pod.BlockSize, "The number of tokens in a KV-cache block")
+	numGPUBlocks           = flags.Int("numGPUBlocks", defaultConfig.Pod.NumGPUBlocks, "The number of KV-cache blocks of a pod")
+	maxLoRAs               = flags.Int("maxLoRAs", defaultConfig.Pod.MaxLoRAs, "The maximum number of LoRA adapters in a batch")
+	refreshMetricsInterval = flags.Duration("refreshMetricsInterval", defaultConfig.MetricsRefreshInterval, "The simulated interval of the metrics refresh. If zero, the scheduler always sees up to date metrics")
+	logVerbosity           = flags.Int("v", 0, "number for the log level verbosity")
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		return err
+	}
+	opts := zap.Options{Development: true, Level: uberzap.NewAtomicLevelAt(zapcore.Level(int8(-1 * *logVerbosity)))}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if *traceFile == "" {
+		return errors.New("the trace flag is required")
+	}
+	if *output != "text" && *output != "json" {
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	trace, err := simulator.LoadTraceFile(*traceFile)
+	if err != nil {
+		return err
+	}
+
+	scheduler, err := newScheduler()
+	if err != nil {
+		return err
+	}
+
+	config := defaultConfig
+	config.NumPods = *numPods
+	config.BaseModel = *baseModel
+	config.Pod.MaxNumSeqs = *maxNumSeqs
+	config.Pod.MaxNumBatchedTokens = *maxNumBatchedTokens
+	config.Pod.BlockSize = *blockSize
+	config.Pod.NumGPUBlocks = *numGPUBlocks
+	config.Pod.MaxLoRAs = *maxLoRAs
+	config.MetricsRefreshInterval = *refreshMetricsInterval
+	sim, err := simulator.New(scheduler, config)
+	if err != nil {
+		return err
+	}
+
+	report, err := sim.Run(ctrl.SetupSignalHandler(), trace)
+	if err != nil {
+		return fmt.Errorf("simulation failed: %w", err)
+	}
+
+	if *output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return report.WriteText(os.Stdout)
+}
+
+// newScheduler builds the scheduler the same way the EPP does.
+func newScheduler() (*scheduling.Scheduler, error) {
+	if len(*configText) == 0 && len(*configFile) == 0 {
+		return scheduling.NewScheduler(), nil
+	}
+
+	runner.RegisterAllPlugins()
+	theConfig, err := loader.LoadConfig([]byte(*configText), *configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the configuration: %w", err)
+	}
+	handle := runner.NewEppHandle(nil, loader.CustomMetricNames(theConfig.CustomMetrics))
+	if err := loader.LoadPluginReferences(theConfig.Plugins, handle); err != nil {
+		return nil, fmt.Errorf("failed to instantiate the plugins: %w", err)
+	}
+	schedulerConfig, err := loader.LoadSchedulerConfig(theConfig.SchedulingProfiles, handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the scheduler configuration: %w", err)
+	}
+	return scheduling.NewSchedulerWithConfig(schedulerConfig), nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	httpShutdownTimeout = 30 * time.Second
+	// httpReadHeaderTimeout bounds the time a client may take to send the headers of a request.
+	httpReadHeaderTimeout = 10 * time.Second
+)
+
+// HTTPServer converts the given HTTP handler into a runnable serving on the given port.
+// The server name is just being used for logging.
+func HTTPServer(name string, handler http.Handler, port int) manager.Runnable {
+	return manager.RunnableFunc(func(ctx context.Context) error {
+		// Use "name" key as that is what manager.Server does as well.
+		log := ctrl.Log.WithValues("name", name)
+		log.Info("HTTP server starting")
+
+		// Start listening.
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+		if err != nil {
+			log.Error(err, "HTTP server failed to listen")
+			return err
+		}
+
+		log.Info("HTTP server listening", "port", port)
+
+		srv := &http.Server{Handler: handler, ReadHeaderTimeout: httpReadHeaderTimeout}
+
+		// Shutdown on context closed.
+		// Make sure the goroutine does not leak.
+		doneCh := make(chan struct{})
+		defer close(doneCh)
+		go func() {
+			select {
+			case <-ctx.Done():
+				log.Info("HTTP server shutting down")
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), httpShutdownTimeout)
+				defer cancel()
+				if err := srv.Shutdown(shutdownCtx); err != nil {
+					log.Error(err, "HTTP server failed to shut down gracefully")
+				}
+			case <-doneCh:
+			}
+		}()
+
+		// Keep serving until terminated.
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(err, "HTTP server failed")
+			return err
+		}
+		log.Info("HTTP server terminated")
+		return nil
+	})
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"go.uber.org/multierr"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CustomMetricAggregation reduces the series of a metric matching the labels of its spec to a single value.
+type CustomMetricAggregation string
+
+const (
+	// LastAggregation is the value of the latest series.
+	LastAggregation CustomMetricAggregation = "last"
+	SumAggregation  CustomMetricAggregation = "sum"
+	MinAggregation  CustomMetricAggregation = "min"
+	MaxAggregation  CustomMetricAggregation = "max"
+	AvgAggregation  CustomMetricAggregation = "avg"
+	// RateAggregation is the per-second rate of the counters summed, over the rate window.
+	RateAggregation CustomMetricAggregation = "rate"
+	// HistogramQuantileAggregation is the quantile of the histogram merged from the series, over the rate window.
+	HistogramQuantileAggregation CustomMetricAggregation = "histogram_quantile"
+
+	// DefaultRateWindow is the default window the rates and histogram quantiles are computed over.
+	DefaultRateWindow = 30 * time.Second
+)
+
+// CustomMetricSpec is a metric scraped into the custom metrics of the pods.
+type CustomMetricSpec struct {
+	// Name is the key of the metric in the custom metrics of the pods.
+	Name        string
+	Spec        MetricSpec
+	Aggregation CustomMetricAggregation
+	// Quantile is the quantile of the histogram quantile aggregation.
+	Quantile float64
+}
+
+// NewCustomMetricSpec creates a CustomMetricSpec from string values, e.g. "ttft_p90",
+// "vllm:time_to_first_token_seconds" and "histogram_quantile(0.9)". The aggregation defaults to the last value.
+func NewCustomMetricSpec(name, metricStr, aggregationStr string) (*CustomMetricSpec, error) {
+	if name == "" {
+		return nil, errors.New("empty custom metric name")
+	}
+	spec, err := stringToMetricSpec(metricStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing the custom metric '%s': %w", name, err)
+	}
+	if spec == nil {
+		return nil, fmt.Errorf("empty metric of the custom metric '%s'", name)
+	}
+
+	customSpec := &CustomMetricSpec{Name: name, Spec: *spec, Aggregation: LastAggregation}
+	aggregationStr = strings.TrimSpace(aggregationStr)
+	switch aggregation := CustomMetricAggregation(aggregationStr); aggregation {
+	case "":
+	case LastAggregation, SumAggregation, MinAggregation, MaxAggregation, AvgAggregation, RateAggregation:
+		customSpec.Aggregation = aggregation
+	default:
+		quantileStr, ok := strings.CutPrefix(aggregationStr, string(HistogramQuantileAggregation)+"(")
+		if ok {
+			quantileStr, ok = strings.CutSuffix(quantileStr, ")")
+		}
+		if !ok {
+			return nil, fmt.Errorf("invalid aggregation '%s' of the custom metric '%s'", aggregationStr, name)
+		}
+		quantile, err := strconv.ParseFloat(strings.TrimSpace(quantileStr), 64)
+		if err != nil || quantile < 0 || quantile > 1 {
+			return nil, fmt.Errorf("invalid quantile '%s' of the custom metric '%s', must be between 0 and 1", quantileStr, name)
+		}
+		customSpec.Aggregation = HistogramQuantileAggregation
+		customSpec.Quantile = quantile
+	}
+	return customSpec, nil
+}
+
+// SetCustomMetrics sets the custom metrics scraped from the pods, from the next scrape on.
+func (p *PodMetricsClientImpl) SetCustomMetrics(specs []*CustomMetricSpec) {
+	p.customMetrics.Store(&specs)
+}
+
+// DeletePodState deletes the scrape history of the pod.
+func (p *PodMetricsClientImpl) DeletePodState(pod types.NamespacedName) {
+	p.scrapeHistory.Delete(pod)
+}
+
+// customMetricSample is the sample of a windowed custom metric in a scrape: the sum of the counters, or the buckets
+// of the histogram.
+type customMetricSample struct {
+	value   float64
+	buckets []histogramBucket
+}
+
+// scrapeSnapshot holds the samples of the windowed custom metrics of a scrape, by name.
+type scrapeSnapshot struct {
+	time    time.Time
+	samples map[string]customMetricSample
+}
+
+// updateCustomMetrics sets the custom metrics of the updated metrics from the scraped metrics. A metric that fails to
+// be scraped keeps its previous value, like the built-in metrics.
+//
+// The rates and histogram quantiles are computed from the differences with the scrape of the pod at the start of the
+// rate window, or the oldest one in the window. The rates need two scrapes, while the histogram quantiles are computed
+// over all the observations until then.
+func (p *PodMetricsClientImpl) updateCustomMetrics(metricFamilies map[string]*dto.MetricFamily, updated *MetricsState,
+	pod types.NamespacedName, now time.Time) error {
+	specs := p.customMetrics.Load()
+	if specs == nil || len(*specs) == 0 {
+		updated.Custom = nil
+		p.scrapeHistory.Delete(pod)
+		return nil
+	}
+
+	var history []scrapeSnapshot
+	if value, ok := p.scrapeHistory.Load(pod); ok {
+		history = p.trimHistory(value.([]scrapeSnapshot), now)
+	}
+	var baseline *scrapeSnapshot
+	if len(history) > 0 {
+		baseline = &history[0]
+	}
+	current := scrapeSnapshot{time: now, samples: map[string]customMetricSample{}}
+
+	var errs error
+	custom := make(map[string]float64, len(*specs))
+	for _, spec := range *specs {
+		value, sample, err := customMetricValue(metricFamilies, spec, baseline, now)
+		if sample != nil {
+			current.samples[spec.Name] = *sample
+		}
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			if previous, ok := updated.Custom[spec.Name]; ok {
+				custom[spec.Name] = previous
+			}
+			continue
+		}
+		custom[spec.Name] = value
+	}
+	updated.Custom = custom
+
+	if len(current.samples) > 0 {
+		p.scrapeHistory.Store(pod, append(history, current))
+	} else {
+		p.scrapeHistory.Delete(pod)
+	}
+	return errs
+}
+
+// trimHistory drops the snapshots of the history of the pod that are no longer needed: the oldest one kept is the most
+// recent one at the start of the rate window, or the oldest one in the window.
+func (p *PodMetricsClientImpl) trimHistory(history []scrapeSnapshot, now time.Time) []scrapeSnapshot {
+	window := p.RateWindow
+	if window <= 0 {
+		window = DefaultRateWindow
+	}
+	windowStart := now.Add(-window)
+	for len(history) > 1 && !history[1].time.After(windowStart) {
+		history = history[1:]
+	}
+	// copied so that the history stored for the pod isn't shared with the appended one
+	return slices.Clone(history)
+}
+
+// customMetricValue aggregates the series of the metric family matching the labels of the spec. For the windowed
+// aggregations, it also returns the sample of the scrape.
+func customMetricValue(metricFamilies map[string]*dto.MetricFamily, spec *CustomMetricSpec, baseline *scrapeSnapshot,
+	now time.Time) (float64, *customMetricSample, error) {
+	mf, ok := metricFamilies[spec.Spec.MetricName]
+	if !ok {
+		return 0, nil, fmt.Errorf("metric family %q not found", spec.Spec.MetricName)
+	}
+	metricType := mf.GetType()
+	applies := metricType != dto.MetricType_HISTOGRAM
+	switch spec.Aggregation {
+	case HistogramQuantileAggregation:
+		applies = metricType == dto.MetricType_HISTOGRAM
+	case RateAggregation:
+		applies = metricType == dto.MetricType_COUNTER || metricType == dto.MetricType_UNTYPED
+	}
+	if !applies {
+		return 0, nil, fmt.Errorf("the aggregation %s of the custom metric %q doesn't apply to the %s metric %q",
+			spec.Aggregation, spec.Name, strings.ToLower(metricType.String()), spec.Spec.MetricName)
+	}
+	if spec.Aggregation == LastAggregation {
+		latest, err := getLatestMetric(mf, &spec.Spec)
+		if err != nil {
+			return 0, nil, err
+		}
+		return metricValue(latest), nil, nil
+	}
+
+	var matching []*dto.Metric
+	for _, m := range mf.GetMetric() {
+		if labelsMatch(m.GetLabel(), spec.Spec.Labels) {
+			matching = append(matching, m)
+		}
+	}
+	if len(matching) == 0 {
+		return 0, nil, fmt.Errorf("no matching metric found for %q with labels %+v", spec.Spec.MetricName, spec.Spec.Labels)
+	}
+
+	var previous *customMetricSample
+	var elapsed time.Duration
+	if baseline != nil {
+		if sample, ok := baseline.samples[spec.Name]; ok {
+			previous = &sample
+			elapsed = now.Sub(baseline.time)
+		}
+	}
+
+	switch spec.Aggregation {
+	case HistogramQuantileAggregation:
+		sample := &customMetricSample{buckets: mergeHistogramBuckets(matching)}
+		buckets := sample.buckets
+		if previous != nil {
+			buckets = histogramBucketDeltas(sample.buckets, previous.buckets)
+		}
+		value, err := histogramQuantile(spec.Quantile, buckets)
+		return value, sample, err
+	case RateAggregation:
+		sample := &customMetricSample{value: sumMetricValues(matching)}
+		if previous == nil || elapsed <= 0 {
+			return 0, sample, fmt.Errorf("no previous scrape to compute the rate of the custom metric %q", spec.Name)
+		}
+		delta := sample.value - previous.value
+		if delta < 0 {
+			// the counter was reset, e.g. by a restart of the model server
+			delta = sample.value
+		}
+		return delta / elapsed.Seconds(), sample, nil
+	case MinAggregation, MaxAggregation:
+		values := make([]float64, 0, len(matching))
+		for _, m := range matching {
+			values = append(values, metricValue(m))
+		}
+		if spec.Aggregation == MinAggregation {
+			return slices.Min(values), nil, nil
+		}
+		return slices.Max(values), nil, nil
+	case AvgAggregation:
+		return sumMetricValues(matching) / float64(len(matching)), nil, nil
+	default:
+		return sumMetricValues(matching), nil, nil
+	}
+}
+
+func sumMetricValues(metrics []*dto.Metric) float64 {
+	sum := 0.0
+	for _, m := range metrics {
+		sum += metricValue(m)
+	}
+	return sum
+}
+
+// histogramBucket is a cumulative bucket of a histogram.
+type histogramBucket struct {
+	upperBound float64
+	count      float64
+}
+
+// mergeHistogramBuckets returns the cumulative buckets of the histograms summed, sorted by upper bound.
+func mergeHistogramBuckets(metrics []*dto.Metric) []histogramBucket {
+	counts := map[float64]float64{}
+	for _, m := range metrics {
+		histogram := m.GetHistogram()
+		for _, bucket := range histogram.GetBucket() {
+			if !math.IsInf(bucket.GetUpperBound(), 1) {
+				counts[bucket.GetUpperBound()] += float64(bucket.GetCumulativeCount())
+			}
+		}
+		// the +Inf bucket is the sample count, whether it is exposed or not
+		counts[math.Inf(1)] += float64(histogram.GetSampleCount())
+	}
+	buckets := make([]histogramBucket, 0, len(counts))
+	for upperBound, count := range counts {
+		buckets = append(buckets, histogramBucket{upperBound: upperBound, count: count})
+	}
+	slices.SortFunc(buckets, func(a, b histogramBucket) int { return cmp.Compare(a.upperBound, b.upperBound) })
+	return buckets
+}
+
+// histogramBucketDeltas returns the observations of the histogram since the previous buckets. If the buckets changed
+// or the histogram was reset, it returns the current buckets.
+func histogramBucketDeltas(current, previous []histogramBucket) []histogramBucket {
+	if len(current) != len(previous) {
+		return current
+	}
+	deltas := make([]histogramBucket, len(current))
+	for i := range current {
+		if current[i].upperBound != previous[i].upperBound || current[i].count < previous[i].count {
+			return current
+		}
+		deltas[i] = histogramBucket{upperBound: current[i].upperBound, count: current[i].count - previous[i].count}
+	}
+	return deltas
+}
+
+// histogramQuantile returns the quantile of the cumulative buckets, interpolating linearly within the bucket the
+// quantile falls into, like the histogram_quantile function of Prometheus. A quantile falling into the +Inf bucket is
+// the upper bound of the highest finite bucket.
+func histogramQuantile(quantile float64, buckets []histogramBucket) (float64, error) {
+	if len(buckets) == 0 || buckets[len(buckets)-1].count == 0 {
+		return 0, errors.New("no observations in the histogram")
+	}
+	rank := quantile * buckets[len(buckets)-1].count
+	lowerBound, lowerCount := 0.0, 0.0
+	for i, bucket := range buckets {
+		if bucket.count < rank {
+			lowerBound, lowerCount = bucket.upperBound, bucket.count
+			continue
+		}
+		if math.IsInf(bucket.upperBound, 1) {
+			if i == 0 {
+				return 0, errors.New("the histogram has no finite bucket")
+			}
+			return buckets[i-1].upperBound, nil
+		}
+		if i == 0 && bucket.upperBound <= 0 {
+			return bucket.upperBound, nil
+		}
+		if bucket.count == lowerCount {
+			return bucket.upperBound, nil
+		}
+		return lowerBound + (bucket.upperBound-lowerBound)*(rank-lowerCount)/(bucket.count-lowerCount), nil
+	}
+	return buckets[len(buckets)-1].upperBound, nil
+}
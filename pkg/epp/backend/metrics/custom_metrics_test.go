@@ -0,0 +1,284 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const customMetricsText = `# TYPE vllm:time_to_first_token_seconds histogram
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="0.1"} 10
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="0.5"} 50
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="1"} 90
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="+Inf"} 100
+vllm:time_to_first_token_seconds_sum{model_name="llama"} 40
+vllm:time_to_first_token_seconds_count{model_name="llama"} 100
+vllm:time_to_first_token_seconds_bucket{model_name="lora",le="0.1"} 0
+vllm:time_to_first_token_seconds_bucket{model_name="lora",le="0.5"} 0
+vllm:time_to_first_token_seconds_bucket{model_name="lora",le="1"} 0
+vllm:time_to_first_token_seconds_bucket{model_name="lora",le="+Inf"} 100
+vllm:time_to_first_token_seconds_sum{model_name="lora"} 400
+vllm:time_to_first_token_seconds_count{model_name="lora"} 100
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{model_name="llama"} 3
+vllm:num_requests_running{model_name="lora"} 5
+# TYPE vllm:request_success_total counter
+vllm:request_success_total{finished_reason="length"} 20
+vllm:request_success_total{finished_reason="stop"} 80
+`
+
+func TestCustomMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		metric      string
+		aggregation string
+		want        float64
+		wantErr     bool
+	}{
+		{name: "last", metric: "vllm:num_requests_running{model_name=lora}", want: 5},
+		{name: "sum", metric: "vllm:num_requests_running", aggregation: "sum", want: 8},
+		{name: "min", metric: "vllm:num_requests_running", aggregation: "min", want: 3},
+		{name: "max", metric: "vllm:num_requests_running", aggregation: "max", want: 5},
+		{name: "avg", metric: "vllm:num_requests_running", aggregation: "avg", want: 4},
+		{name: "counter", metric: "vllm:request_success_total{finished_reason=stop}", want: 80},
+		{
+			name:        "histogram quantile",
+			metric:      "vllm:time_to_first_token_seconds{model_name=llama}",
+			aggregation: "histogram_quantile(0.9)",
+			want:        1,
+		},
+		{
+			name:        "interpolated histogram quantile",
+			metric:      "vllm:time_to_first_token_seconds{model_name=llama}",
+			aggregation: "histogram_quantile(0.3)",
+			want:        0.3,
+		},
+		{
+			name:        "histogram quantile of merged series",
+			metric:      "vllm:time_to_first_token_seconds",
+			aggregation: "histogram_quantile(0.5)",
+			want:        1,
+		},
+		{
+			name:        "histogram quantile in the +Inf bucket",
+			metric:      "vllm:time_to_first_token_seconds{model_name=lora}",
+			aggregation: "histogram_quantile(0.5)",
+			want:        1,
+		},
+		{
+			name:        "quantile of a gauge",
+			metric:      "vllm:num_requests_running",
+			aggregation: "histogram_quantile(0.5)",
+			wantErr:     true,
+		},
+		{
+			name:    "last of a histogram",
+			metric:  "vllm:time_to_first_token_seconds",
+			wantErr: true,
+		},
+		{name: "rate without a previous scrape", metric: "vllm:request_success_total", aggregation: "rate", wantErr: true},
+		{name: "rate of a gauge", metric: "vllm:num_requests_running", aggregation: "rate", wantErr: true},
+		{name: "unknown metric", metric: "vllm:unknown", wantErr: true},
+		{name: "unknown labels", metric: "vllm:num_requests_running{model_name=unknown}", aggregation: "sum", wantErr: true},
+	}
+
+	parser := expfmt.TextParser{}
+	metricFamilies, err := parser.TextToMetricFamilies(strings.NewReader(customMetricsText))
+	if err != nil {
+		t.Fatalf("Failed to parse the metrics: %v", err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec, err := NewCustomMetricSpec("custom", test.metric, test.aggregation)
+			if err != nil {
+				t.Fatalf("NewCustomMetricSpec() unexpected error: %v", err)
+			}
+			p := &PodMetricsClientImpl{}
+			p.SetCustomMetrics([]*CustomMetricSpec{spec})
+
+			updated := &MetricsState{Custom: map[string]float64{"custom": 42, "removed": 1}}
+			err = p.updateCustomMetrics(metricFamilies, updated, types.NamespacedName{Name: "pod"}, time.Now())
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("updateCustomMetrics() expected an error, got nil")
+				}
+				// the previous value is kept
+				if diff := cmp.Diff(map[string]float64{"custom": 42}, updated.Custom); diff != "" {
+					t.Errorf("Unexpected custom metrics (-want +got): %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("updateCustomMetrics() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(map[string]float64{"custom": test.want}, updated.Custom, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected custom metrics (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestWindowedCustomMetrics(t *testing.T) {
+	scrape := func(success, ttftBuckets string) string {
+		return `# TYPE vllm:request_success_total counter
+vllm:request_success_total{finished_reason="stop"} ` + success + `
+# TYPE vllm:time_to_first_token_seconds histogram
+` + ttftBuckets
+	}
+	buckets := func(counts ...string) string {
+		return `vllm:time_to_first_token_seconds_bucket{le="0.1"} ` + counts[0] + `
+vllm:time_to_first_token_seconds_bucket{le="1"} ` + counts[1] + `
+vllm:time_to_first_token_seconds_bucket{le="+Inf"} ` + counts[2] + `
+vllm:time_to_first_token_seconds_sum 0
+vllm:time_to_first_token_seconds_count ` + counts[2] + `
+`
+	}
+	start := time.Now()
+	scrapes := []struct {
+		name    string
+		elapsed time.Duration
+		text    string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name:    "first scrape, quantile over all the observations",
+			text:    scrape("100", buckets("100", "100", "100")),
+			want:    map[string]float64{"ttft_p50": 0.05},
+			wantErr: true, // no rate yet
+		},
+		{
+			name:    "rate and quantile since the first scrape",
+			elapsed: 10 * time.Second,
+			text:    scrape("150", buckets("100", "200", "200")),
+			want:    map[string]float64{"success_rate": 5, "ttft_p50": 0.55},
+		},
+		{
+			name:    "window slides past the first scrape",
+			elapsed: 40 * time.Second,
+			text:    scrape("200", buckets("100", "200", "300")),
+			want:    map[string]float64{"success_rate": 50.0 / 30, "ttft_p50": 1},
+		},
+		{
+			name:    "counter and histogram reset",
+			elapsed: 50 * time.Second,
+			text:    scrape("20", buckets("10", "10", "10")),
+			want:    map[string]float64{"success_rate": 0.5, "ttft_p50": 0.05},
+		},
+	}
+
+	rate, err := NewCustomMetricSpec("success_rate", "vllm:request_success_total", "rate")
+	if err != nil {
+		t.Fatalf("NewCustomMetricSpec() unexpected error: %v", err)
+	}
+	quantile, err := NewCustomMetricSpec("ttft_p50", "vllm:time_to_first_token_seconds", "histogram_quantile(0.5)")
+	if err != nil {
+		t.Fatalf("NewCustomMetricSpec() unexpected error: %v", err)
+	}
+	p := &PodMetricsClientImpl{}
+	p.SetCustomMetrics([]*CustomMetricSpec{rate, quantile})
+	pod := types.NamespacedName{Namespace: "default", Name: "pod"}
+
+	updated := &MetricsState{}
+	for _, s := range scrapes {
+		t.Run(s.name, func(t *testing.T) {
+			parser := expfmt.TextParser{}
+			metricFamilies, err := parser.TextToMetricFamilies(strings.NewReader(s.text))
+			if err != nil {
+				t.Fatalf("Failed to parse the metrics: %v", err)
+			}
+			err = p.updateCustomMetrics(metricFamilies, updated, pod, start.Add(s.elapsed))
+			if (err != nil) != s.wantErr {
+				t.Fatalf("updateCustomMetrics() error = %v, wantErr %v", err, s.wantErr)
+			}
+			if diff := cmp.Diff(s.want, updated.Custom, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected custom metrics (-want +got): %s", diff)
+			}
+		})
+	}
+
+	p.DeletePodState(pod)
+	if _, ok := p.scrapeHistory.Load(pod); ok {
+		t.Error("Expected the scrape history of the pod to be deleted")
+	}
+}
+
+func TestNewCustomMetricSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricName  string
+		metric      string
+		aggregation string
+		want        *CustomMetricSpec
+		wantErr     bool
+	}{
+		{
+			name:       "default aggregation",
+			metricName: "running",
+			metric:     "vllm:num_requests_running{model_name=llama}",
+			want: &CustomMetricSpec{
+				Name:        "running",
+				Spec:        MetricSpec{MetricName: "vllm:num_requests_running", Labels: map[string]string{"model_name": "llama"}},
+				Aggregation: LastAggregation,
+			},
+		},
+		{
+			name:        "histogram quantile",
+			metricName:  "ttft_p90",
+			metric:      "vllm:time_to_first_token_seconds",
+			aggregation: "histogram_quantile( 0.9 )",
+			want: &CustomMetricSpec{
+				Name:        "ttft_p90",
+				Spec:        MetricSpec{MetricName: "vllm:time_to_first_token_seconds", Labels: map[string]string{}},
+				Aggregation: HistogramQuantileAggregation,
+				Quantile:    0.9,
+			},
+		},
+		{name: "no name", metric: "vllm:num_requests_running", wantErr: true},
+		{name: "no metric", metricName: "running", wantErr: true},
+		{name: "invalid metric", metricName: "running", metric: "vllm:num_requests_running{", wantErr: true},
+		{name: "unknown aggregation", metricName: "running", metric: "vllm:num_requests_running", aggregation: "median", wantErr: true},
+		{name: "invalid quantile", metricName: "ttft", metric: "vllm:ttft", aggregation: "histogram_quantile(1.5)", wantErr: true},
+		{name: "unclosed quantile", metricName: "ttft", metric: "vllm:ttft", aggregation: "histogram_quantile(0.5", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewCustomMetricSpec(test.metricName, test.metric, test.aggregation)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("NewCustomMetricSpec() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewCustomMetricSpec() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected spec (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// ScrapeFormat is an exposition format of the metrics of the model servers.
+type ScrapeFormat string
+
+const (
+	// ScrapeFormatProtobuf is the delimited protobuf format, the cheapest to parse.
+	ScrapeFormatProtobuf ScrapeFormat = "protobuf"
+	// ScrapeFormatOpenMetrics is the OpenMetrics text format.
+	ScrapeFormatOpenMetrics ScrapeFormat = "openmetrics"
+	// ScrapeFormatText is the Prometheus text format.
+	ScrapeFormatText ScrapeFormat = "text"
+)
+
+// DefaultScrapeFormats are the formats accepted when scraping the model servers, by decreasing preference.
+// OpenMetrics is not accepted by default, since the Python Prometheus client, used by vLLM, answers with OpenMetrics
+// whenever it is accepted.
+var DefaultScrapeFormats = []ScrapeFormat{ScrapeFormatProtobuf, ScrapeFormatText}
+
+var scrapeFormatMediaTypes = map[ScrapeFormat]string{
+	ScrapeFormatProtobuf:    expfmt.ProtoType + ";proto=" + expfmt.ProtoProtocol + ";encoding=delimited",
+	ScrapeFormatOpenMetrics: expfmt.OpenMetricsType + ";version=" + expfmt.OpenMetricsVersion_1_0_0,
+	ScrapeFormatText:        "text/plain;version=" + expfmt.TextVersion,
+}
+
+// ParseScrapeFormats parses a comma-separated list of scrape formats, by decreasing preference.
+func ParseScrapeFormats(s string) ([]ScrapeFormat, error) {
+	var formats []ScrapeFormat
+	for _, name := range strings.Split(s, ",") {
+		format := ScrapeFormat(strings.TrimSpace(name))
+		if _, ok := scrapeFormatMediaTypes[format]; !ok {
+			return nil, fmt.Errorf("unknown scrape format %q, expected one of %s, %s or %s", name,
+				ScrapeFormatProtobuf, ScrapeFormatOpenMetrics, ScrapeFormatText)
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// acceptHeader returns the Accept header negotiating the given formats, by decreasing preference. Any other format
+// is accepted with the lowest preference, and parsed as the Prometheus text format.
+func acceptHeader(formats []ScrapeFormat) string {
+	if len(formats) == 0 {
+		formats = DefaultScrapeFormats
+	}
+	ranges := make([]string, 0, len(formats)+1)
+	for i, format := range formats {
+		ranges = append(ranges, fmt.Sprintf("%s;q=%.1f", scrapeFormatMediaTypes[format], 1-0.1*float64(i)))
+	}
+	return strings.Join(append(ranges, "*/*;q=0.1"), ",")
+}
+
+// decodeMetricFamilies parses the scraped metrics according to the Content-Type of the response. The responses
+// without a known Content-Type are parsed as the Prometheus text format.
+func decodeMetricFamilies(body io.Reader, header http.Header) (map[string]*dto.MetricFamily, error) {
+	if mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type")); err == nil && mediaType == expfmt.OpenMetricsType {
+		return parseOpenMetrics(body)
+	}
+	if expfmt.ResponseFormat(header).FormatType() != expfmt.TypeProtoDelim {
+		parser := expfmt.TextParser{}
+		return parser.TextToMetricFamilies(body)
+	}
+
+	metricFamilies := map[string]*dto.MetricFamily{}
+	decoder := expfmt.NewDecoder(body, expfmt.NewFormat(expfmt.TypeProtoDelim))
+	for {
+		family := &dto.MetricFamily{}
+		if err := decoder.Decode(family); err == io.EOF {
+			return metricFamilies, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode the protobuf metrics: %w", err)
+		}
+		metricFamilies[family.GetName()] = family
+	}
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+// vllmMetricFamilies returns the metric families of a vLLM server serving the given number of models.
+func vllmMetricFamilies(models int) []*dto.MetricFamily {
+	latencyBuckets := []float64{0.001, 0.005, 0.01, 0.02, 0.04, 0.06, 0.08, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10, 20, 40, 80, math.Inf(1)}
+	tokenBuckets := []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000, math.Inf(1)}
+	gauge := func(name string, metrics ...*dto.Metric) *dto.MetricFamily {
+		return &dto.MetricFamily{Name: proto.String(name), Help: proto.String(name), Type: dto.MetricType_GAUGE.Enum(), Metric: metrics}
+	}
+	counter := &dto.MetricFamily{Name: proto.String("vllm:request_success_total"), Help: proto.String("Finished requests."), Type: dto.MetricType_COUNTER.Enum()}
+	families := []*dto.MetricFamily{
+		gauge("vllm:lora_requests_info", &dto.Metric{
+			Label: labelPairs("max_lora", "4", "running_lora_adapters", "lora-1,lora-2", "waiting_lora_adapters", ""),
+			Gauge: &dto.Gauge{Value: proto.Float64(1.7e9)},
+		}),
+		gauge("vllm:cache_config_info", &dto.Metric{
+			Label: labelPairs("block_size", "16", "num_gpu_blocks", "8192"),
+			Gauge: &dto.Gauge{Value: proto.Float64(1)},
+		}),
+		counter,
+	}
+	histograms := map[string][]float64{
+		"vllm:time_to_first_token_seconds":   latencyBuckets,
+		"vllm:time_per_output_token_seconds": latencyBuckets,
+		"vllm:e2e_request_latency_seconds":   latencyBuckets,
+		"vllm:request_prompt_tokens":         tokenBuckets,
+		"vllm:request_generation_tokens":     tokenBuckets,
+	}
+	for _, name := range []string{"vllm:num_requests_running", "vllm:num_requests_waiting", "vllm:gpu_cache_usage_perc"} {
+		family := gauge(name)
+		for model := 0; model < models; model++ {
+			family.Metric = append(family.Metric, &dto.Metric{
+				Label: labelPairs("model_name", fmt.Sprintf("model-%d", model)),
+				Gauge: &dto.Gauge{Value: proto.Float64(float64(model%7 + 1))},
+			})
+		}
+		families = append(families, family)
+	}
+	for name, upperBounds := range histograms {
+		family := &dto.MetricFamily{Name: proto.String(name), Help: proto.String(name), Type: dto.MetricType_HISTOGRAM.Enum()}
+		for model := 0; model < models; model++ {
+			histogram := &dto.Histogram{SampleCount: proto.Uint64(uint64(10 * len(upperBounds))), SampleSum: proto.Float64(123.5)}
+			for i, upperBound := range upperBounds {
+				histogram.Bucket = append(histogram.Bucket, &dto.Bucket{
+					UpperBound: proto.Float64(upperBound), CumulativeCount: proto.Uint64(uint64(10 * (i + 1))),
+				})
+			}
+			family.Metric = append(family.Metric, &dto.Metric{
+				Label: labelPairs("model_name", fmt.Sprintf("model-%d", model)), Histogram: histogram,
+			})
+		}
+		families = append(families, family)
+	}
+	for model := 0; model < models; model++ {
+		for _, reason := range []string{"abort", "length", "stop"} {
+			counter.Metric = append(counter.Metric, &dto.Metric{
+				Label:   labelPairs("finished_reason", reason, "model_name", fmt.Sprintf("model-%d", model)),
+				Counter: &dto.Counter{Value: proto.Float64(float64(1000 * model))},
+			})
+		}
+	}
+	return families
+}
+
+func labelPairs(nameValues ...string) []*dto.LabelPair {
+	labels := make([]*dto.LabelPair, 0, len(nameValues)/2)
+	for i := 0; i < len(nameValues); i += 2 {
+		labels = append(labels, &dto.LabelPair{Name: proto.String(nameValues[i]), Value: proto.String(nameValues[i+1])})
+	}
+	return labels
+}
+
+// encodeMetricFamilies encodes the metric families in the given format and returns the encoded metrics with the
+// headers of the response.
+func encodeMetricFamilies(t testing.TB, families []*dto.MetricFamily, format expfmt.Format) ([]byte, http.Header) {
+	var buf bytes.Buffer
+	encoder := expfmt.NewEncoder(&buf, format)
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			t.Fatalf("Failed to encode %s: %v", family.GetName(), err)
+		}
+	}
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		if err := closer.Close(); err != nil {
+			t.Fatalf("Failed to close the encoder: %v", err)
+		}
+	}
+	return buf.Bytes(), http.Header{"Content-Type": []string{string(format)}}
+}
+
+var scrapeFormats = map[string]expfmt.Format{
+	"text":        expfmt.NewFormat(expfmt.TypeTextPlain),
+	"openmetrics": expfmt.NewFormat(expfmt.TypeOpenMetrics),
+	"protobuf":    expfmt.NewFormat(expfmt.TypeProtoDelim),
+}
+
+func TestDecodeMetricFamilies(t *testing.T) {
+	families := vllmMetricFamilies(3)
+	textBody, textHeader := encodeMetricFamilies(t, families, scrapeFormats["text"])
+	want, err := decodeMetricFamilies(bytes.NewReader(textBody), textHeader)
+	if err != nil {
+		t.Fatalf("Failed to decode the text metrics: %v", err)
+	}
+	if len(want) != len(families) {
+		t.Fatalf("Decoded %d text metric families, want %d", len(want), len(families))
+	}
+
+	for name, format := range scrapeFormats {
+		t.Run(name, func(t *testing.T) {
+			body, header := encodeMetricFamilies(t, families, format)
+			got, err := decodeMetricFamilies(bytes.NewReader(body), header)
+			if err != nil {
+				t.Fatalf("Failed to decode the metrics: %v", err)
+			}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Unexpected metric families (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseOpenMetrics(t *testing.T) {
+	metrics := `# TYPE vllm:request_success counter
+# HELP vllm:request_success Count of \"finished\" requests.
+vllm:request_success_total{model_name="llama",finished_reason="stop"} 7.0 1700000000.5 # {trace_id="abc"} 1.0
+vllm:request_success_created{model_name="llama",finished_reason="stop"} 1.7e9
+# TYPE vllm:time_to_first_token_seconds histogram
+vllm:time_to_first_token_seconds_bucket{le="0.1"} 2.0
+vllm:time_to_first_token_seconds_bucket{le="+Inf"} 3.0
+vllm:time_to_first_token_seconds_count 3.0
+vllm:time_to_first_token_seconds_sum 0.5
+# TYPE vllm:cache_config info
+vllm:cache_config_info{block_size="16"} 1.0
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 0.2
+rpc_duration_seconds_count 10
+rpc_duration_seconds_sum 2.5
+untyped_metric{path="a\"b\\c"} 4
+# EOF
+`
+	want := map[string]*dto.MetricFamily{
+		"vllm:request_success_total": {
+			Name: proto.String("vllm:request_success_total"),
+			Help: proto.String(`Count of "finished" requests.`),
+			Type: dto.MetricType_COUNTER.Enum(),
+			Metric: []*dto.Metric{{
+				Label:       labelPairs("finished_reason", "stop", "model_name", "llama"),
+				Counter:     &dto.Counter{Value: proto.Float64(7)},
+				TimestampMs: proto.Int64(1700000000500),
+			}},
+		},
+		"vllm:time_to_first_token_seconds": {
+			Name: proto.String("vllm:time_to_first_token_seconds"),
+			Type: dto.MetricType_HISTOGRAM.Enum(),
+			Metric: []*dto.Metric{{
+				Label: []*dto.LabelPair{},
+				Histogram: &dto.Histogram{
+					SampleCount: proto.Uint64(3),
+					SampleSum:   proto.Float64(0.5),
+					Bucket: []*dto.Bucket{
+						{UpperBound: proto.Float64(0.1), CumulativeCount: proto.Uint64(2)},
+						{UpperBound: proto.Float64(math.Inf(1)), CumulativeCount: proto.Uint64(3)},
+					},
+				},
+			}},
+		},
+		"vllm:cache_config_info": {
+			Name:   proto.String("vllm:cache_config_info"),
+			Type:   dto.MetricType_GAUGE.Enum(),
+			Metric: []*dto.Metric{{Label: labelPairs("block_size", "16"), Gauge: &dto.Gauge{Value: proto.Float64(1)}}},
+		},
+		"rpc_duration_seconds": {
+			Name: proto.String("rpc_duration_seconds"),
+			Type: dto.MetricType_SUMMARY.Enum(),
+			Metric: []*dto.Metric{{
+				Label: []*dto.LabelPair{},
+				Summary: &dto.Summary{
+					SampleCount: proto.Uint64(10),
+					SampleSum:   proto.Float64(2.5),
+					Quantile:    []*dto.Quantile{{Quantile: proto.Float64(0.5), Value: proto.Float64(0.2)}},
+				},
+			}},
+		},
+		"untyped_metric": {
+			Name:   proto.String("untyped_metric"),
+			Type:   dto.MetricType_UNTYPED.Enum(),
+			Metric: []*dto.Metric{{Label: labelPairs("path", `a"b\c`), Untyped: &dto.Untyped{Value: proto.Float64(4)}}},
+		},
+	}
+
+	got, err := parseOpenMetrics(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("Failed to parse the metrics: %v", err)
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Unexpected metric families (-want +got): %s", diff)
+	}
+
+	if _, err := parseOpenMetrics(strings.NewReader("# TYPE foo gauge\nfoo 1\n")); err == nil {
+		t.Error("Expected an error for metrics truncated before # EOF")
+	}
+	if _, err := parseOpenMetrics(strings.NewReader("foo{bar=\"baz} 1\n# EOF\n")); err == nil {
+		t.Error("Expected an error for an unterminated label value")
+	}
+}
+
+func TestParseScrapeFormats(t *testing.T) {
+	formats, err := ParseScrapeFormats("openmetrics, text")
+	if err != nil {
+		t.Fatalf("ParseScrapeFormats returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]ScrapeFormat{ScrapeFormatOpenMetrics, ScrapeFormatText}, formats); diff != "" {
+		t.Errorf("Unexpected formats (-want +got): %s", diff)
+	}
+	if _, err := ParseScrapeFormats("json"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestFetchMetricsNegotiatesFormat(t *testing.T) {
+	families := vllmMetricFamilies(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := expfmt.Negotiate(r.Header)
+		if strings.Contains(r.Header.Get("Accept"), expfmt.OpenMetricsType) {
+			format = expfmt.NewFormat(expfmt.TypeOpenMetrics)
+		}
+		body, header := encodeMetricFamilies(t, families, format)
+		w.Header().Set("Content-Type", header.Get("Content-Type"))
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	pod := &backend.Pod{Address: "127.0.0.1"}
+	pool := &v1alpha2.InferencePool{Spec: v1alpha2.InferencePoolSpec{TargetPortNumber: serverPort(t, server)}}
+	mapping, err := NewMetricMapping("vllm:num_requests_waiting", "vllm:num_requests_running", "", "", "")
+	if err != nil {
+		t.Fatalf("Failed to create the metric mapping: %v", err)
+	}
+	for _, formats := range [][]ScrapeFormat{nil, {ScrapeFormatOpenMetrics}, {ScrapeFormatText}} {
+		t.Run(fmt.Sprint(formats), func(t *testing.T) {
+			p := &PodMetricsClientImpl{MetricMapping: mapping, Endpoint: MetricsEndpointConfig{Path: "/metrics"}, ScrapeFormats: formats}
+			updated, err := p.FetchMetrics(context.Background(), pod, &MetricsState{}, pool)
+			if err != nil {
+				t.Fatalf("FetchMetrics returned unexpected error: %v", err)
+			}
+			if updated.WaitingQueueSize != 1 || updated.RunningQueueSize != 1 {
+				t.Errorf("Unexpected queue sizes %d and %d", updated.WaitingQueueSize, updated.RunningQueueSize)
+			}
+		})
+	}
+}
+
+// benchmarkDecodeMetricFamilies measures the cost of parsing the metrics of a vLLM server serving 100 models, or
+// LoRA adapters, in the given format.
+func benchmarkDecodeMetricFamilies(b *testing.B, format expfmt.Format) {
+	body, header := encodeMetricFamilies(b, vllmMetricFamilies(100), format)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decodeMetricFamilies(bytes.NewReader(body), header); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeMetricFamiliesText(b *testing.B) {
+	benchmarkDecodeMetricFamilies(b, scrapeFormats["text"])
+}
+
+func BenchmarkDecodeMetricFamiliesOpenMetrics(b *testing.B) {
+	benchmarkDecodeMetricFamilies(b, scrapeFormats["openmetrics"])
+}
+
+func BenchmarkDecodeMetricFamiliesProtobuf(b *testing.B) {
+	benchmarkDecodeMetricFamilies(b, scrapeFormats["protobuf"])
+}
@@ -29,8 +29,8 @@ import (
 )
 
 const (
-	// Note currently the EPP treats stale metrics same as fresh.
-	// TODO: https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/336
+	// metricsValidityPeriod is the age beyond which the metrics are logged as stale. The scheduling excludes or
+	// penalizes the pods with stale metrics through the metrics-staleness filter and metrics-freshness scorer.
 	metricsValidityPeriod = 5 * time.Second
 	debugPrintInterval    = 5 * time.Second
 )
@@ -73,10 +73,10 @@ func StartMetricsLogger(ctx context.Context, datastore Datastore, refreshPrometh
 					return
 				case <-ticker.C:
 					podsWithFreshMetrics := datastore.PodList(func(pm PodMetrics) bool {
-						return time.Since(pm.GetMetrics().UpdateTime) <= metricsValidityPeriod
+						return !pm.GetMetrics().IsStale(metricsValidityPeriod)
 					})
 					podsWithStaleMetrics := datastore.PodList(func(pm PodMetrics) bool {
-						return time.Since(pm.GetMetrics().UpdateTime) > metricsValidityPeriod
+						return pm.GetMetrics().IsStale(metricsValidityPeriod)
 					})
 					s := fmt.Sprintf("Current Pods and metrics gathered. Fresh metrics: %+v, Stale metrics: %+v", podsWithFreshMetrics, podsWithStaleMetrics)
 					logger.V(logutil.VERBOSE).Info(s)
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+const (
+	// DefaultMetricPresetKey is the default label or annotation of the pods naming the metric preset of their model
+	// server.
+	DefaultMetricPresetKey = "inference.networking.x-k8s.io/model-server"
+
+	VLLMMetricPreset   = "vllm"
+	SGLangMetricPreset = "sglang"
+	TGIMetricPreset    = "tgi"
+	TritonMetricPreset = "triton"
+)
+
+// metricPresets are the metric mappings of the model servers. The metrics are read whichever their Prometheus type
+// is, and the metrics a model server doesn't expose are not scraped.
+var metricPresets = map[string]*MetricMapping{
+	VLLMMetricPreset: {
+		TotalQueuedRequests:  mustParseMetricSpec("vllm:num_requests_waiting"),
+		TotalRunningRequests: mustParseMetricSpec("vllm:num_requests_running"),
+		KVCacheUtilization:   mustParseMetricSpec("vllm:gpu_cache_usage_perc"),
+		KVCacheInfo:          mustParseMetricSpec("vllm:cache_config_info"),
+		LoraRequestInfo:      mustParseMetricSpec("vllm:lora_requests_info"),
+	},
+	SGLangMetricPreset: {
+		TotalQueuedRequests:     mustParseMetricSpec("sglang:num_queue_reqs"),
+		TotalRunningRequests:    mustParseMetricSpec("sglang:num_running_reqs"),
+		KVCacheUtilization:      mustParseMetricSpec("sglang:token_usage"),
+		KVCacheMaxTokenCapacity: mustParseMetricSpec("sglang:max_total_num_tokens"),
+	},
+	// TGI doesn't expose the utilization nor the token capacity of its KV-cache: tgi_batch_current_max_tokens is the
+	// number of tokens reserved by the current batch, bounded by the max-batch-total-tokens setting of the launcher
+	// rather than by a metric. The pods without a preset can map it to the utilization with a scale of
+	// 1/max-batch-total-tokens, e.g. "tgi_batch_current_max_tokens*0.0000625" for 16000 tokens.
+	TGIMetricPreset: {
+		TotalQueuedRequests:  mustParseMetricSpec("tgi_queue_size"),
+		TotalRunningRequests: mustParseMetricSpec("tgi_batch_current_size"),
+	},
+	// The Triton metrics are those of the TensorRT-LLM backend.
+	TritonMetricPreset: {
+		TotalQueuedRequests:  mustParseMetricSpec("nv_trt_llm_request_metrics{request_type=waiting}"),
+		TotalRunningRequests: mustParseMetricSpec("nv_trt_llm_request_metrics{request_type=active}"),
+		KVCacheUtilization:   mustParseMetricSpec("nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type=fraction}"),
+		KVCacheBlockSize:     mustParseMetricSpec("nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type=tokens_per}"),
+		KVCacheNumGPUBlocks:  mustParseMetricSpec("nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type=max}"),
+	},
+}
+
+// MetricPresetNames returns the sorted names of the metric presets.
+func MetricPresetNames() []string {
+	return slices.Sorted(maps.Keys(metricPresets))
+}
+
+// MetricPreset returns the metric mapping of the named model server. The mapping is shared and must not be modified.
+func MetricPreset(name string) (*MetricMapping, error) {
+	mapping, ok := metricPresets[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown metric preset '%s', must be one of %v", name, MetricPresetNames())
+	}
+	return mapping, nil
+}
+
+// metricMapping returns the metric mapping of the pod: the preset named by its label or annotation if the metric
+// preset key is set, MetricMapping otherwise. The label takes precedence over the annotation.
+func (p *PodMetricsClientImpl) metricMapping(pod *backend.Pod) (*MetricMapping, error) {
+	if p.MetricPresetKey == "" {
+		return p.MetricMapping, nil
+	}
+	name, ok := pod.Labels[p.MetricPresetKey]
+	if !ok {
+		name, ok = pod.Annotations[p.MetricPresetKey]
+	}
+	if !ok {
+		return p.MetricMapping, nil
+	}
+	mapping, err := MetricPreset(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric preset of pod %s: %w", pod.NamespacedName, err)
+	}
+	return mapping, nil
+}
+
+func mustParseMetricSpec(specStr string) *MetricSpec {
+	spec, err := stringToMetricSpec(specStr)
+	if err != nil {
+		panic(err)
+	}
+	return spec
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/common/expfmt"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+func TestMetricPresets(t *testing.T) {
+	tests := []struct {
+		name    string
+		preset  string
+		metrics string
+		want    *MetricsState
+	}{
+		{
+			name:   "vLLM",
+			preset: VLLMMetricPreset,
+			metrics: `# TYPE vllm:num_requests_waiting gauge
+vllm:num_requests_waiting{model_name="llama"} 3
+# TYPE vllm:num_requests_running gauge
+vllm:num_requests_running{model_name="llama"} 5
+# TYPE vllm:gpu_cache_usage_perc gauge
+vllm:gpu_cache_usage_perc{model_name="llama"} 0.25
+# TYPE vllm:cache_config_info gauge
+vllm:cache_config_info{block_size="16",num_gpu_blocks="2048"} 1
+# TYPE vllm:lora_requests_info gauge
+vllm:lora_requests_info{max_lora="2",running_lora_adapters="sql-lora",waiting_lora_adapters=""} 1.7e+09
+`,
+			want: &MetricsState{
+				ActiveModels:            map[string]int{"sql-lora": 0},
+				WaitingModels:           map[string]int{},
+				MaxActiveModels:         2,
+				RunningQueueSize:        5,
+				WaitingQueueSize:        3,
+				KVCacheUsagePercent:     0.25,
+				KvCacheMaxTokenCapacity: 32768,
+				KvCacheBlockSize:        16,
+				KvCacheNumGPUBlocks:     2048,
+			},
+		},
+		{
+			name:   "SGLang",
+			preset: SGLangMetricPreset,
+			metrics: `# TYPE sglang:num_queue_reqs gauge
+sglang:num_queue_reqs{model_name="llama"} 4
+# TYPE sglang:num_running_reqs gauge
+sglang:num_running_reqs{model_name="llama"} 6
+# TYPE sglang:token_usage gauge
+sglang:token_usage{model_name="llama"} 0.5
+# TYPE sglang:max_total_num_tokens gauge
+sglang:max_total_num_tokens{model_name="llama"} 65536
+`,
+			want: &MetricsState{
+				RunningQueueSize:        6,
+				WaitingQueueSize:        4,
+				KVCacheUsagePercent:     0.5,
+				KvCacheMaxTokenCapacity: 65536,
+			},
+		},
+		{
+			name:   "TGI",
+			preset: TGIMetricPreset,
+			metrics: `# TYPE tgi_queue_size gauge
+tgi_queue_size 2
+# TYPE tgi_batch_current_size gauge
+tgi_batch_current_size 8
+# TYPE tgi_batch_current_max_tokens gauge
+tgi_batch_current_max_tokens 16000
+`,
+			want: &MetricsState{
+				RunningQueueSize: 8,
+				WaitingQueueSize: 2,
+			},
+		},
+		{
+			name:   "Triton",
+			preset: TritonMetricPreset,
+			metrics: `# TYPE nv_trt_llm_request_metrics gauge
+nv_trt_llm_request_metrics{model="tensorrt_llm",request_type="waiting",version="1"} 7
+nv_trt_llm_request_metrics{model="tensorrt_llm",request_type="max",version="1"} 64
+nv_trt_llm_request_metrics{model="tensorrt_llm",request_type="active",version="1"} 9
+# TYPE nv_trt_llm_kv_cache_block_metrics gauge
+nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type="max",model="tensorrt_llm",version="1"} 4096
+nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type="tokens_per",model="tensorrt_llm",version="1"} 32
+nv_trt_llm_kv_cache_block_metrics{kv_cache_block_type="fraction",model="tensorrt_llm",version="1"} 0.75
+`,
+			want: &MetricsState{
+				RunningQueueSize:        9,
+				WaitingQueueSize:        7,
+				KVCacheUsagePercent:     0.75,
+				KvCacheMaxTokenCapacity: 131072,
+				KvCacheBlockSize:        32,
+				KvCacheNumGPUBlocks:     4096,
+			},
+		},
+		{
+			name:   "counters and untyped metrics",
+			preset: TGIMetricPreset,
+			metrics: `# TYPE tgi_queue_size counter
+tgi_queue_size 2
+tgi_batch_current_size 8
+tgi_batch_current_max_tokens 16000
+`,
+			want: &MetricsState{
+				RunningQueueSize: 8,
+				WaitingQueueSize: 2,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapping, err := MetricPreset(test.preset)
+			if err != nil {
+				t.Fatalf("MetricPreset() unexpected error: %v", err)
+			}
+			parser := expfmt.TextParser{}
+			metricFamilies, err := parser.TextToMetricFamilies(strings.NewReader(test.metrics))
+			if err != nil {
+				t.Fatalf("Failed to parse the metrics: %v", err)
+			}
+
+			p := &PodMetricsClientImpl{}
+			got, err := p.promToPodMetrics(metricFamilies, &MetricsState{}, mapping)
+			if err != nil {
+				t.Fatalf("promToPodMetrics() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected metrics (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricMappingOfPod(t *testing.T) {
+	defaultMapping := &MetricMapping{TotalQueuedRequests: &MetricSpec{MetricName: "queued"}}
+	vllm, _ := MetricPreset(VLLMMetricPreset)
+	sglang, _ := MetricPreset(SGLangMetricPreset)
+
+	tests := []struct {
+		name        string
+		presetKey   string
+		labels      map[string]string
+		annotations map[string]string
+		want        *MetricMapping
+		wantErr     bool
+	}{
+		{
+			name:   "no preset key",
+			labels: map[string]string{DefaultMetricPresetKey: SGLangMetricPreset},
+			want:   defaultMapping,
+		},
+		{
+			name:      "no preset",
+			presetKey: DefaultMetricPresetKey,
+			want:      defaultMapping,
+		},
+		{
+			name:      "preset label",
+			presetKey: DefaultMetricPresetKey,
+			labels:    map[string]string{DefaultMetricPresetKey: SGLangMetricPreset},
+			want:      sglang,
+		},
+		{
+			name:        "preset annotation",
+			presetKey:   DefaultMetricPresetKey,
+			annotations: map[string]string{DefaultMetricPresetKey: VLLMMetricPreset},
+			want:        vllm,
+		},
+		{
+			name:        "the label takes precedence over the annotation",
+			presetKey:   DefaultMetricPresetKey,
+			labels:      map[string]string{DefaultMetricPresetKey: SGLangMetricPreset},
+			annotations: map[string]string{DefaultMetricPresetKey: VLLMMetricPreset},
+			want:        sglang,
+		},
+		{
+			name:      "unknown preset",
+			presetKey: DefaultMetricPresetKey,
+			labels:    map[string]string{DefaultMetricPresetKey: "unknown"},
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &PodMetricsClientImpl{MetricMapping: defaultMapping, MetricPresetKey: test.presetKey}
+			pod := &backend.Pod{Labels: test.labels, Annotations: test.annotations}
+
+			got, err := p.metricMapping(pod)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("metricMapping() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("metricMapping() unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("Unexpected metric mapping %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
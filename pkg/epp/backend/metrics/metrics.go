@@ -22,10 +22,13 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	dto "github.com/prometheus/client_model/go"
-	"github.com/prometheus/common/expfmt"
 	"go.uber.org/multierr"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 )
 
@@ -34,23 +37,53 @@ const (
 	LoraInfoRunningAdaptersMetricName = "running_lora_adapters"
 	LoraInfoWaitingAdaptersMetricName = "waiting_lora_adapters"
 	LoraInfoMaxAdaptersMetricName     = "max_lora"
+
+	// KV-cache info metrics based on protocol
+	KVCacheInfoBlockSizeMetricName    = "block_size"
+	KVCacheInfoNumGPUBlocksMetricName = "num_gpu_blocks"
 )
 
 type PodMetricsClientImpl struct {
+	// MetricMapping is the metric mapping of the pods without a metric preset.
 	MetricMapping *MetricMapping
+	// MetricPresetKey is the label or annotation of the pods naming the metric preset of their model server, so that
+	// a pool can mix model servers. If empty, MetricMapping is used for all the pods.
+	MetricPresetKey string
+	// Endpoint configures the metrics endpoint of the model servers, and is overridden by the InferencePool.
+	Endpoint MetricsEndpointConfig
+	// SecretReader reads the Secrets referenced by the configuration of the metrics endpoint.
+	SecretReader SecretReader
+
+	// RateWindow is the window the rates and histogram quantiles of the custom metrics are computed over,
+	// DefaultRateWindow if 0.
+	RateWindow time.Duration
+	// ScrapeFormats are the exposition formats accepted from the model servers, by decreasing preference,
+	// DefaultScrapeFormats if empty.
+	ScrapeFormats []ScrapeFormat
+
+	httpClientOnce  sync.Once
+	plainHTTPClient *http.Client // the HTTP client, reusing the connections to the pods
+	httpsClients    sync.Map     // the HTTPS clients by TLS settings and certificate authorities Secret
+	customMetrics   atomic.Pointer[[]*CustomMetricSpec]
+	scrapeHistory   sync.Map // the recent scrapes of the windowed custom metrics by pod
 }
 
 // FetchMetrics fetches metrics from a given pod, clones the existing metrics object and returns an updated one.
-func (p *PodMetricsClientImpl) FetchMetrics(ctx context.Context, pod *backend.Pod, existing *MetricsState, port int32) (*MetricsState, error) {
-	// Currently the metrics endpoint is hard-coded, which works with vLLM.
-	// TODO(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/16): Consume this from InferencePool config.
-	url := "http://" + pod.Address + ":" + strconv.Itoa(int(port)) + "/metrics"
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+func (p *PodMetricsClientImpl) FetchMetrics(ctx context.Context, pod *backend.Pod, existing *MetricsState, pool *v1alpha2.InferencePool) (*MetricsState, error) {
+	mapping, err := p.metricMapping(pod)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	config := p.Endpoint.merge(pool)
+	req, err := p.newScrapeRequest(ctx, pod, config, pool.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := p.httpClient(ctx, config, pool.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch metrics from %s: %w", pod.NamespacedName, err)
 	}
@@ -62,43 +95,107 @@ func (p *PodMetricsClientImpl) FetchMetrics(ctx context.Context, pod *backend.Po
 		return nil, fmt.Errorf("unexpected status code from %s: %v", pod.NamespacedName, resp.StatusCode)
 	}
 
-	parser := expfmt.TextParser{}
-	metricFamilies, err := parser.TextToMetricFamilies(resp.Body)
+	metricFamilies, err := decodeMetricFamilies(resp.Body, resp.Header)
 	if err != nil {
 		return nil, err
 	}
-	return p.promToPodMetrics(metricFamilies, existing)
+	updated, err := p.promToPodMetrics(metricFamilies, existing, mapping)
+	return updated, multierr.Append(err, p.updateCustomMetrics(metricFamilies, updated, pod.NamespacedName, time.Now()))
 }
 
 // promToPodMetrics updates internal pod metrics with scraped Prometheus metrics.
 func (p *PodMetricsClientImpl) promToPodMetrics(
 	metricFamilies map[string]*dto.MetricFamily,
 	existing *MetricsState,
+	mapping *MetricMapping,
 ) (*MetricsState, error) {
 	var errs error
 	updated := existing.Clone()
 
-	if p.MetricMapping.TotalQueuedRequests != nil {
-		queued, err := p.getMetric(metricFamilies, *p.MetricMapping.TotalQueuedRequests)
+	if mapping.TotalQueuedRequests != nil {
+		queued, err := p.getMetric(metricFamilies, *mapping.TotalQueuedRequests)
+		if err == nil {
+			updated.WaitingQueueSize = int(mapping.TotalQueuedRequests.value(queued))
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if mapping.TotalRunningRequests != nil {
+		running, err := p.getMetric(metricFamilies, *mapping.TotalRunningRequests)
+		if err == nil {
+			updated.RunningQueueSize = int(mapping.TotalRunningRequests.value(running))
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if mapping.KVCacheUtilization != nil {
+		usage, err := p.getMetric(metricFamilies, *mapping.KVCacheUtilization)
+		if err == nil {
+			updated.KVCacheUsagePercent = mapping.KVCacheUtilization.value(usage)
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if mapping.KVCacheMaxTokenCapacity != nil {
+		capacity, err := p.getMetric(metricFamilies, *mapping.KVCacheMaxTokenCapacity)
 		if err == nil {
-			updated.WaitingQueueSize = int(queued.GetGauge().GetValue())
+			updated.KvCacheMaxTokenCapacity = int(mapping.KVCacheMaxTokenCapacity.value(capacity))
 		} else {
 			errs = multierr.Append(errs, err)
 		}
 	}
 
-	if p.MetricMapping.KVCacheUtilization != nil {
-		usage, err := p.getMetric(metricFamilies, *p.MetricMapping.KVCacheUtilization)
+	if mapping.KVCacheInfo != nil {
+		info, err := p.getMetric(metricFamilies, *mapping.KVCacheInfo)
 		if err == nil {
-			updated.KVCacheUsagePercent = usage.GetGauge().GetValue()
+			for _, label := range info.GetLabel() {
+				var value *int
+				switch label.GetName() {
+				case KVCacheInfoBlockSizeMetricName:
+					value = &updated.KvCacheBlockSize
+				case KVCacheInfoNumGPUBlocksMetricName:
+					value = &updated.KvCacheNumGPUBlocks
+				default:
+					continue
+				}
+				if *value, err = strconv.Atoi(label.GetValue()); err != nil {
+					errs = multierr.Append(errs, err)
+				}
+			}
 		} else {
 			errs = multierr.Append(errs, err)
 		}
 	}
 
+	if mapping.KVCacheBlockSize != nil {
+		blockSize, err := p.getMetric(metricFamilies, *mapping.KVCacheBlockSize)
+		if err == nil {
+			updated.KvCacheBlockSize = int(mapping.KVCacheBlockSize.value(blockSize))
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	if mapping.KVCacheNumGPUBlocks != nil {
+		numGPUBlocks, err := p.getMetric(metricFamilies, *mapping.KVCacheNumGPUBlocks)
+		if err == nil {
+			updated.KvCacheNumGPUBlocks = int(mapping.KVCacheNumGPUBlocks.value(numGPUBlocks))
+		} else {
+			errs = multierr.Append(errs, err)
+		}
+	}
+
+	// The token capacity is computed from the KV-cache blocks when the model server doesn't expose it.
+	if mapping.KVCacheMaxTokenCapacity == nil && updated.KvCacheBlockSize > 0 && updated.KvCacheNumGPUBlocks > 0 {
+		updated.KvCacheMaxTokenCapacity = updated.KvCacheBlockSize * updated.KvCacheNumGPUBlocks
+	}
+
 	// Handle LoRA metrics (only if all LoRA MetricSpecs are present)
-	if p.MetricMapping.LoraRequestInfo != nil {
-		loraMetrics, err := p.getLatestLoraMetric(metricFamilies)
+	if mapping.LoraRequestInfo != nil {
+		loraMetrics, err := p.getLatestLoraMetric(metricFamilies, mapping)
 		errs = multierr.Append(errs, err)
 
 		if loraMetrics != nil {
@@ -140,14 +237,14 @@ func (p *PodMetricsClientImpl) promToPodMetrics(
 // reason its specially fetched is because each label key value pair permutation generates new series
 // and only most recent is useful. The value of each series is the creation timestamp so we can
 // retrieve the latest by sorting the value.
-func (p *PodMetricsClientImpl) getLatestLoraMetric(metricFamilies map[string]*dto.MetricFamily) (*dto.Metric, error) {
-	if p.MetricMapping.LoraRequestInfo == nil {
+func (p *PodMetricsClientImpl) getLatestLoraMetric(metricFamilies map[string]*dto.MetricFamily, mapping *MetricMapping) (*dto.Metric, error) {
+	if mapping.LoraRequestInfo == nil {
 		return nil, nil // No LoRA metrics configured
 	}
 
-	loraRequests, ok := metricFamilies[p.MetricMapping.LoraRequestInfo.MetricName]
+	loraRequests, ok := metricFamilies[mapping.LoraRequestInfo.MetricName]
 	if !ok {
-		return nil, fmt.Errorf("metric family %q not found", p.MetricMapping.LoraRequestInfo.MetricName)
+		return nil, fmt.Errorf("metric family %q not found", mapping.LoraRequestInfo.MetricName)
 	}
 
 	var latest *dto.Metric
@@ -198,6 +295,26 @@ func (p *PodMetricsClientImpl) getMetric(metricFamilies map[string]*dto.MetricFa
 	return getLatestMetric(mf, &spec)
 }
 
+// metricValue returns the value of the metric, whichever its type is.
+func metricValue(metric *dto.Metric) float64 {
+	switch {
+	case metric.GetGauge() != nil:
+		return metric.GetGauge().GetValue()
+	case metric.GetCounter() != nil:
+		return metric.GetCounter().GetValue()
+	default:
+		return metric.GetUntyped().GetValue()
+	}
+}
+
+// value returns the value of the metric multiplied by the scale of the spec.
+func (s *MetricSpec) value(metric *dto.Metric) float64 {
+	if s.Scale == 0 {
+		return metricValue(metric)
+	}
+	return metricValue(metric) * s.Scale
+}
+
 // getLabeledMetric gets the latest metric with matching labels.
 func getLatestMetric(mf *dto.MetricFamily, spec *MetricSpec) (*dto.Metric, error) {
 	var latestMetric *dto.Metric
@@ -0,0 +1,294 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+const (
+	// DefaultMetricsPath is the default HTTP path of the metrics endpoint of the model servers.
+	DefaultMetricsPath = "/metrics"
+	// DefaultAuthTokenSecretKey is the key of the bearer token in the Secret referenced by the flags.
+	DefaultAuthTokenSecretKey = "token"
+	// DefaultCASecretKey is the key of the certificate authorities in the Secret referenced by the flags.
+	DefaultCASecretKey = "ca.crt"
+	// DefaultSecretTTL is the default time the values of the Secrets referenced by the metrics endpoint are kept
+	// before the Secrets are read again.
+	DefaultSecretTTL = 30 * time.Second
+)
+
+// MetricsEndpointConfig configures the metrics endpoint of the model servers. It is set by the flags of the endpoint
+// picker, and each of its fields is overridden by the corresponding field of the InferencePool, if set.
+type MetricsEndpointConfig struct {
+	// Path is the HTTP path of the metrics endpoint, "/metrics" if empty.
+	Path string
+	// Port is the port of the metrics endpoint, the TargetPortNumber of the InferencePool if 0.
+	Port int32
+	// Scheme is HTTP or HTTPS, HTTP if empty.
+	Scheme v1alpha2.MetricsScheme
+	// InsecureSkipVerify disables the verification of the certificates of the model servers.
+	InsecureSkipVerify bool
+	// CASecretRef references the PEM encoded certificate authorities the model servers are verified with.
+	CASecretRef *v1alpha2.SecretKeyReference
+	// AuthTokenSecretRef references the bearer token sent to the metrics endpoint.
+	AuthTokenSecretRef *v1alpha2.SecretKeyReference
+}
+
+// Validate returns an error if the configuration is invalid.
+func (c MetricsEndpointConfig) Validate() error {
+	if c.Path != "" && !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("invalid metrics path '%s', must start with '/'", c.Path)
+	}
+	if c.Port < 0 || c.Port > 65535 {
+		return fmt.Errorf("invalid metrics port %d", c.Port)
+	}
+	switch c.Scheme {
+	case "", v1alpha2.MetricsSchemeHTTP, v1alpha2.MetricsSchemeHTTPS:
+	default:
+		return fmt.Errorf("invalid metrics scheme '%s', must be HTTP or HTTPS", c.Scheme)
+	}
+	return nil
+}
+
+// merge returns the configuration overridden by the metrics endpoint of the InferencePool.
+func (c MetricsEndpointConfig) merge(pool *v1alpha2.InferencePool) MetricsEndpointConfig {
+	merged := c
+	if merged.Port == 0 {
+		merged.Port = pool.Spec.TargetPortNumber
+	}
+	if endpoint := pool.Spec.Metrics; endpoint != nil {
+		if endpoint.Path != "" {
+			merged.Path = endpoint.Path
+		}
+		if endpoint.Port != nil {
+			merged.Port = int32(*endpoint.Port)
+		}
+		if endpoint.Scheme != "" {
+			merged.Scheme = endpoint.Scheme
+		}
+		if endpoint.TLS != nil {
+			merged.InsecureSkipVerify = endpoint.TLS.InsecureSkipVerify
+			if endpoint.TLS.CASecretRef != nil {
+				merged.CASecretRef = endpoint.TLS.CASecretRef
+			}
+		}
+		if endpoint.AuthTokenSecretRef != nil {
+			merged.AuthTokenSecretRef = endpoint.AuthTokenSecretRef
+		}
+	}
+	if merged.Path == "" {
+		merged.Path = DefaultMetricsPath
+	}
+	return merged
+}
+
+// url returns the URL of the metrics endpoint of the pod.
+func (c MetricsEndpointConfig) url(pod *backend.Pod) string {
+	scheme := "http"
+	if c.Scheme == v1alpha2.MetricsSchemeHTTPS {
+		scheme = "https"
+	}
+	endpoint := url.URL{Scheme: scheme, Host: net.JoinHostPort(pod.Address, strconv.Itoa(int(c.Port))), Path: c.Path}
+	return endpoint.String()
+}
+
+// SecretReader reads the value of a key of a Secret.
+type SecretReader interface {
+	ReadSecret(ctx context.Context, namespace string, ref v1alpha2.SecretKeyReference) ([]byte, error)
+}
+
+// NewSecretReader returns a SecretReader getting the referenced Secrets with the given reader, which is expected to
+// be uncached, e.g. the API reader of the manager, so that the Secrets are neither listed nor watched. The values of a
+// Secret, or the error reading it, are kept for the given TTL before the Secret is read again.
+func NewSecretReader(reader client.Reader, ttl time.Duration) SecretReader {
+	return &clientSecretReader{reader: reader, ttl: ttl, secrets: map[types.NamespacedName]cachedSecret{}}
+}
+
+type clientSecretReader struct {
+	reader client.Reader
+	ttl    time.Duration
+	reads  singleflight.Group // collapses the concurrent reads of the same Secret
+
+	mu      sync.Mutex
+	secrets map[types.NamespacedName]cachedSecret
+}
+
+// cachedSecret is the data of a Secret, or the error reading it, with the time it was read.
+type cachedSecret struct {
+	data     map[string][]byte
+	err      error
+	readTime time.Time
+}
+
+func (r *clientSecretReader) ReadSecret(ctx context.Context, namespace string, ref v1alpha2.SecretKeyReference) ([]byte, error) {
+	name := types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}
+	data, err := r.secretData(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("the Secret '%s' has no key '%s'", name, ref.Key)
+	}
+	return value, nil
+}
+
+// secretData returns the data of the Secret, from the cache if it was read less than the TTL ago. A failed read is
+// cached as well, so that a missing or forbidden Secret isn't read again on every scrape of every pod.
+func (r *clientSecretReader) secretData(ctx context.Context, name types.NamespacedName) (map[string][]byte, error) {
+	if cached, ok := r.cached(name); ok {
+		return cached.data, cached.err
+	}
+
+	result, _, _ := r.reads.Do(name.String(), func() (any, error) {
+		// The Secret may have been read while waiting for a previous read to complete.
+		if cached, ok := r.cached(name); ok {
+			return cached, nil
+		}
+		cached := cachedSecret{readTime: time.Now()}
+		secret := &corev1.Secret{}
+		if err := r.reader.Get(ctx, name, secret); err != nil {
+			cached.err = fmt.Errorf("failed to get the Secret '%s': %w", name, err)
+			if ctx.Err() != nil {
+				// The scrape was canceled, which says nothing about the Secret.
+				return cached, nil
+			}
+		} else {
+			cached.data = secret.Data
+		}
+		r.mu.Lock()
+		r.secrets[name] = cached
+		r.mu.Unlock()
+		return cached, nil
+	})
+	cached := result.(cachedSecret)
+	return cached.data, cached.err
+}
+
+// cached returns the cached Secret if it was read less than the TTL ago.
+func (r *clientSecretReader) cached(name types.NamespacedName) (cachedSecret, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cached, ok := r.secrets[name]
+	return cached, ok && time.Since(cached.readTime) < r.ttl
+}
+
+// newScrapeRequest returns the request scraping the metrics of the pod, with the bearer token if one is configured.
+func (p *PodMetricsClientImpl) newScrapeRequest(ctx context.Context, pod *backend.Pod, config MetricsEndpointConfig,
+	namespace string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.url(pod), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", acceptHeader(p.ScrapeFormats))
+	if config.AuthTokenSecretRef != nil {
+		token, err := p.readSecret(ctx, namespace, *config.AuthTokenSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the metrics auth token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	return req, nil
+}
+
+// httpsClient is the HTTPS client of a TLS configuration, with the hash of the certificate authorities it verifies
+// the model servers with.
+type httpsClient struct {
+	caHash [sha256.Size]byte
+	client *http.Client
+}
+
+// httpClient returns the HTTP client scraping the metrics endpoint. The clients of the HTTPS endpoints are reused
+// for the same TLS settings, and replaced when the certificate authorities of their Secret change.
+func (p *PodMetricsClientImpl) httpClient(ctx context.Context, config MetricsEndpointConfig, namespace string) (*http.Client, error) {
+	if config.Scheme != v1alpha2.MetricsSchemeHTTPS {
+		p.httpClientOnce.Do(func() {
+			p.plainHTTPClient = &http.Client{Transport: newScrapeTransport()}
+		})
+		return p.plainHTTPClient, nil
+	}
+
+	var caPEM []byte
+	key := fmt.Sprintf("%t", config.InsecureSkipVerify)
+	if config.CASecretRef != nil {
+		var err error
+		if caPEM, err = p.readSecret(ctx, namespace, *config.CASecretRef); err != nil {
+			return nil, fmt.Errorf("failed to read the metrics certificate authorities: %w", err)
+		}
+		key = fmt.Sprintf("%s/%s/%s/%s", key, namespace, config.CASecretRef.Name, config.CASecretRef.Key)
+	}
+	caHash := sha256.Sum256(caPEM)
+	if cached, ok := p.httpsClients.Load(key); ok && cached.(*httpsClient).caHash == caHash {
+		return cached.(*httpsClient).client, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify, // #nosec G402 -- explicitly configured by the operator
+		MinVersion:         tls.VersionTLS12,
+	}
+	if len(caPEM) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("no valid certificate authority in the metrics certificate authorities Secret")
+		}
+	}
+	transport := newScrapeTransport()
+	transport.TLSClientConfig = tlsConfig
+	current := &httpsClient{caHash: caHash, client: &http.Client{Transport: transport}}
+	if previous, loaded := p.httpsClients.Swap(key, current); loaded {
+		previous.(*httpsClient).client.CloseIdleConnections()
+	}
+	return current.client, nil
+}
+
+// newScrapeTransport returns a transport keeping an idle connection to every pod, so that each scrape reuses the
+// connection of the previous one. The default transport keeps at most 100 idle connections in total, which closes
+// and reopens connections on every scrape in large pools.
+func newScrapeTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 0 // no limit
+	transport.MaxIdleConnsPerHost = 1
+	return transport
+}
+
+func (p *PodMetricsClientImpl) readSecret(ctx context.Context, namespace string, ref v1alpha2.SecretKeyReference) ([]byte, error) {
+	if p.SecretReader == nil {
+		return nil, errors.New("no Secret reader is configured")
+	}
+	return p.SecretReader.ReadSecret(ctx, namespace, ref)
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+const (
+	testMetricsPath  = "/custom/metrics"
+	testMetricsToken = "secret-token"
+)
+
+// newTestMetricsServer returns a server exposing the waiting queue size on testMetricsPath, which requires the test
+// token if requireToken is set.
+func newTestMetricsServer(t *testing.T, useTLS bool, requireToken bool) *httptest.Server {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testMetricsPath {
+			http.NotFound(w, r)
+			return
+		}
+		if requireToken && r.Header.Get("Authorization") != "Bearer "+testMetricsToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = fmt.Fprint(w, "# TYPE vllm:num_requests_waiting gauge\nvllm:num_requests_waiting 3\n")
+	})
+	var server *httptest.Server
+	if useTLS {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serverPort(t *testing.T, server *httptest.Server) int32 {
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse the server URL: %v", err)
+	}
+	_, port, _ := net.SplitHostPort(serverURL.Host)
+	portNumber, _ := strconv.Atoi(port)
+	return int32(portNumber)
+}
+
+func TestFetchMetricsEndpoint(t *testing.T) {
+	httpServer := newTestMetricsServer(t, false, false)
+	httpsServer := newTestMetricsServer(t, true, false)
+	authServer := newTestMetricsServer(t, false, true)
+	httpPort := serverPort(t, httpServer)
+	httpsPort := serverPort(t, httpsServer)
+	authPort := serverPort(t, authServer)
+	httpsPortNumber := v1alpha2.PortNumber(httpsPort)
+	authPortNumber := v1alpha2.PortNumber(authPort)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: httpsServer.Certificate().Raw})
+
+	secrets := NewSecretReader(fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics-auth"},
+			Data:       map[string][]byte{"token": []byte(testMetricsToken + "\n")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics-ca"},
+			Data:       map[string][]byte{"ca.crt": caPEM},
+		},
+	).Build(), DefaultSecretTTL)
+
+	tests := []struct {
+		name     string
+		endpoint MetricsEndpointConfig
+		pool     *v1alpha2.InferencePool
+		wantErr  bool
+	}{
+		{
+			name:     "path and port from the flags",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath, Port: httpPort},
+			pool:     testPool(nil),
+		},
+		{
+			name:     "path and port from the pool",
+			endpoint: MetricsEndpointConfig{Path: "/metrics", Port: 1},
+			pool: testPool(&v1alpha2.MetricsEndpoint{
+				Path: testMetricsPath,
+				Port: ptr.To(v1alpha2.PortNumber(httpPort)),
+			}),
+		},
+		{
+			name:     "default path",
+			endpoint: MetricsEndpointConfig{Port: httpPort},
+			pool:     testPool(nil),
+			wantErr:  true,
+		},
+		{
+			name:     "HTTPS without the certificate authority",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath},
+			pool:     testPool(&v1alpha2.MetricsEndpoint{Port: &httpsPortNumber, Scheme: v1alpha2.MetricsSchemeHTTPS}),
+			wantErr:  true,
+		},
+		{
+			name:     "HTTPS skipping the verification",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath, Scheme: v1alpha2.MetricsSchemeHTTPS, InsecureSkipVerify: true},
+			pool:     testPool(&v1alpha2.MetricsEndpoint{Port: &httpsPortNumber}),
+		},
+		{
+			name:     "HTTPS with the certificate authority Secret",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath},
+			pool: testPool(&v1alpha2.MetricsEndpoint{
+				Port:   &httpsPortNumber,
+				Scheme: v1alpha2.MetricsSchemeHTTPS,
+				TLS: &v1alpha2.MetricsTLSConfig{
+					CASecretRef: &v1alpha2.SecretKeyReference{Name: "metrics-ca", Key: "ca.crt"},
+				},
+			}),
+		},
+		{
+			name:     "HTTP to an HTTPS server",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath, Port: httpsPort},
+			pool:     testPool(nil),
+			wantErr:  true,
+		},
+		{
+			name:     "auth token from the flags",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath, AuthTokenSecretRef: &v1alpha2.SecretKeyReference{Name: "metrics-auth", Key: "token"}},
+			pool:     testPool(&v1alpha2.MetricsEndpoint{Port: &authPortNumber}),
+		},
+		{
+			name:     "auth token from the pool",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath},
+			pool: testPool(&v1alpha2.MetricsEndpoint{
+				Port:               &authPortNumber,
+				AuthTokenSecretRef: &v1alpha2.SecretKeyReference{Name: "metrics-auth", Key: "token"},
+			}),
+		},
+		{
+			name:     "missing auth token",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath},
+			pool:     testPool(&v1alpha2.MetricsEndpoint{Port: &authPortNumber}),
+			wantErr:  true,
+		},
+		{
+			name:     "missing auth token Secret",
+			endpoint: MetricsEndpointConfig{Path: testMetricsPath},
+			pool: testPool(&v1alpha2.MetricsEndpoint{
+				Port:               &authPortNumber,
+				AuthTokenSecretRef: &v1alpha2.SecretKeyReference{Name: "unknown", Key: "token"},
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mapping, err := NewMetricMapping("vllm:num_requests_waiting", "", "", "", "")
+			if err != nil {
+				t.Fatalf("Failed to create the metric mapping: %v", err)
+			}
+			client := &PodMetricsClientImpl{MetricMapping: mapping, Endpoint: test.endpoint, SecretReader: secrets}
+			pod := &backend.Pod{Address: "127.0.0.1"}
+
+			got, err := client.FetchMetrics(context.Background(), pod, &MetricsState{}, test.pool)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("FetchMetrics() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FetchMetrics() unexpected error: %v", err)
+			}
+			if got.WaitingQueueSize != 3 {
+				t.Errorf("Unexpected waiting queue size %d, want 3", got.WaitingQueueSize)
+			}
+		})
+	}
+}
+
+func TestSecretReaderCache(t *testing.T) {
+	gets := 0
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics-auth"},
+		Data:       map[string][]byte{"token": []byte("first")},
+	}
+	reader := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(secret).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			gets++
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	ctx := context.Background()
+	ref := v1alpha2.SecretKeyReference{Name: "metrics-auth", Key: "token"}
+
+	readToken := func(secrets SecretReader, want string) {
+		t.Helper()
+		got, err := secrets.ReadSecret(ctx, "default", ref)
+		if err != nil {
+			t.Fatalf("ReadSecret() unexpected error: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("Unexpected token %q, want %q", got, want)
+		}
+	}
+
+	// The Secret is read once within the TTL, even if it changes.
+	cached := NewSecretReader(reader, time.Hour)
+	readToken(cached, "first")
+	secret.Data["token"] = []byte("second")
+	if err := reader.Update(ctx, secret); err != nil {
+		t.Fatalf("Failed to update the Secret: %v", err)
+	}
+	readToken(cached, "first")
+	if gets != 1 {
+		t.Errorf("Expected the Secret to be read once, got %d reads", gets)
+	}
+
+	// The Secret is read again once the TTL has expired.
+	expired := NewSecretReader(reader, 0)
+	readToken(expired, "second")
+	readToken(expired, "second")
+	if gets != 3 {
+		t.Errorf("Expected the Secret to be read on each call, got %d reads", gets)
+	}
+
+	if _, err := cached.ReadSecret(ctx, "default", v1alpha2.SecretKeyReference{Name: "metrics-auth", Key: "missing"}); err == nil {
+		t.Error("ReadSecret() expected an error for a missing key, got nil")
+	}
+
+	// A failed read is cached as well.
+	gets = 0
+	for range 2 {
+		if _, err := cached.ReadSecret(ctx, "default", v1alpha2.SecretKeyReference{Name: "missing", Key: "token"}); err == nil {
+			t.Error("ReadSecret() expected an error for a missing Secret, got nil")
+		}
+	}
+	if gets != 1 {
+		t.Errorf("Expected the missing Secret to be read once, got %d reads", gets)
+	}
+}
+
+func TestSecretReaderConcurrentReads(t *testing.T) {
+	var gets atomic.Int32
+	release := make(chan struct{})
+	reader := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics-auth"},
+		Data:       map[string][]byte{"token": []byte(testMetricsToken)},
+	}).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			gets.Add(1)
+			<-release
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	secrets := NewSecretReader(reader, time.Hour)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := secrets.ReadSecret(context.Background(), "default", v1alpha2.SecretKeyReference{Name: "metrics-auth", Key: "token"}); err != nil {
+				t.Errorf("ReadSecret() unexpected error: %v", err)
+			}
+		}()
+	}
+	// Let the readers wait for the first read before completing it.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if got := gets.Load(); got != 1 {
+		t.Errorf("Expected the concurrent reads to read the Secret once, got %d reads", got)
+	}
+}
+
+func TestHTTPSClientCARotation(t *testing.T) {
+	server := newTestMetricsServer(t, true, false)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "metrics-ca"},
+		Data:       map[string][]byte{"ca.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})},
+	}
+	reader := fake.NewClientBuilder().WithScheme(testScheme(t)).WithObjects(secret).Build()
+	client := &PodMetricsClientImpl{SecretReader: NewSecretReader(reader, 0)}
+	config := MetricsEndpointConfig{
+		Scheme:      v1alpha2.MetricsSchemeHTTPS,
+		CASecretRef: &v1alpha2.SecretKeyReference{Name: "metrics-ca", Key: "ca.crt"},
+	}
+	ctx := context.Background()
+
+	first, err := client.httpClient(ctx, config, "default")
+	if err != nil {
+		t.Fatalf("httpClient() unexpected error: %v", err)
+	}
+	if again, _ := client.httpClient(ctx, config, "default"); again != first {
+		t.Error("Expected the client to be reused while the certificate authorities are unchanged")
+	}
+
+	// The test servers share their certificate, so the rotated bundle repeats it.
+	secret.Data["ca.crt"] = append(secret.Data["ca.crt"], secret.Data["ca.crt"]...)
+	if err := reader.Update(ctx, secret); err != nil {
+		t.Fatalf("Failed to update the Secret: %v", err)
+	}
+	rotated, err := client.httpClient(ctx, config, "default")
+	if err != nil {
+		t.Fatalf("httpClient() unexpected error: %v", err)
+	}
+	if rotated == first {
+		t.Error("Expected the client to be replaced when the certificate authorities change")
+	}
+	clients := 0
+	client.httpsClients.Range(func(_, _ any) bool {
+		clients++
+		return true
+	})
+	if clients != 1 {
+		t.Errorf("Expected one HTTPS client for the Secret, got %d", clients)
+	}
+}
+
+func TestMetricsEndpointConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  MetricsEndpointConfig
+		wantErr bool
+	}{
+		{name: "defaults", config: MetricsEndpointConfig{}},
+		{name: "valid", config: MetricsEndpointConfig{Path: "/stats", Port: 8443, Scheme: v1alpha2.MetricsSchemeHTTPS}},
+		{name: "relative path", config: MetricsEndpointConfig{Path: "metrics"}, wantErr: true},
+		{name: "invalid port", config: MetricsEndpointConfig{Port: 70000}, wantErr: true},
+		{name: "invalid scheme", config: MetricsEndpointConfig{Scheme: "FTP"}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.config.Validate(); (err != nil) != test.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func testPool(endpoint *v1alpha2.MetricsEndpoint) *v1alpha2.InferencePool {
+	return &v1alpha2.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pool"},
+		Spec:       v1alpha2.InferencePoolSpec{TargetPortNumber: 1, Metrics: endpoint},
+	}
+}
+
+func testScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed to create the scheme: %v", err)
+	}
+	return scheme
+}
@@ -18,6 +18,8 @@ package metrics
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 )
 
@@ -25,12 +27,23 @@ import (
 type MetricSpec struct {
 	MetricName string
 	Labels     map[string]string // Label name -> Label value
+	// Scale is the factor the value of the metric is multiplied by, to convert it to the unit of the field it's
+	// mapped to, e.g. 0.01 for a percentage mapped to a fraction. 0 stands for 1.
+	Scale float64
 }
 
 // MetricMapping holds named MetricSpecs.
 type MetricMapping struct {
-	TotalQueuedRequests *MetricSpec
-	KVCacheUtilization  *MetricSpec
+	TotalQueuedRequests     *MetricSpec
+	TotalRunningRequests    *MetricSpec
+	KVCacheUtilization      *MetricSpec
+	KVCacheMaxTokenCapacity *MetricSpec
+	// KVCacheInfo is an info metric with the block size and the number of GPU blocks as labels, in vLLM label format.
+	KVCacheInfo *MetricSpec
+	// KVCacheBlockSize and KVCacheNumGPUBlocks are the metrics of the block size and the number of GPU blocks, for the
+	// model servers exposing them as values rather than labels.
+	KVCacheBlockSize    *MetricSpec
+	KVCacheNumGPUBlocks *MetricSpec
 	LoraRequestInfo     *MetricSpec
 }
 
@@ -40,11 +53,23 @@ type MetricMapping struct {
 //	"metric_name"
 //	"metric_name{label1=value1}"
 //	"metric_name{label1=value1,label2=value2}"
+//	"metric_name{label1=value1}*0.01"
 func stringToMetricSpec(specStr string) (*MetricSpec, error) {
 	if specStr == "" {
 		return nil, nil // Allow empty strings to represent nil MetricSpecs
 	}
 	specStr = strings.TrimSpace(specStr)
+
+	// Check for a scale factor after the metric name and labels
+	scale := 0.0
+	if idx := strings.LastIndex(specStr, "*"); idx != -1 && idx > strings.LastIndex(specStr, "}") {
+		var err error
+		scale, err = strconv.ParseFloat(strings.TrimSpace(specStr[idx+1:]), 64)
+		if err != nil || !(scale > 0) || math.IsInf(scale, 1) {
+			return nil, fmt.Errorf("invalid scale factor in metric spec: %q, must be a positive number", specStr)
+		}
+		specStr = strings.TrimSpace(specStr[:idx])
+	}
 	metricName := specStr
 	labels := make(map[string]string)
 
@@ -89,27 +114,38 @@ func stringToMetricSpec(specStr string) (*MetricSpec, error) {
 	return &MetricSpec{
 		MetricName: metricName,
 		Labels:     labels,
+		Scale:      scale,
 	}, nil
 }
 
 // NewMetricMapping creates a MetricMapping from string values.
-func NewMetricMapping(queuedStr, kvUsageStr, loraReqInfoStr string) (*MetricMapping, error) {
+func NewMetricMapping(queuedStr, runningStr, kvUsageStr, kvCacheInfoStr, loraReqInfoStr string) (*MetricMapping, error) {
 	queuedSpec, err := stringToMetricSpec(queuedStr)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing WaitingRequests: %w", err)
 	}
+	runningSpec, err := stringToMetricSpec(runningStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing RunningRequests: %w", err)
+	}
 	kvUsageSpec, err := stringToMetricSpec(kvUsageStr)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing KVCacheUsage: %w", err)
 	}
+	kvCacheInfoSpec, err := stringToMetricSpec(kvCacheInfoStr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KVCacheInfo: %w", err)
+	}
 	loraReqInfoSpec, err := stringToMetricSpec(loraReqInfoStr)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing loraReqInfoStr: %w", err)
 	}
 	mapping := &MetricMapping{
-		TotalQueuedRequests: queuedSpec,
-		KVCacheUtilization:  kvUsageSpec,
-		LoraRequestInfo:     loraReqInfoSpec,
+		TotalQueuedRequests:  queuedSpec,
+		TotalRunningRequests: runningSpec,
+		KVCacheUtilization:   kvUsageSpec,
+		KVCacheInfo:          kvCacheInfoSpec,
+		LoraRequestInfo:      loraReqInfoSpec,
 	}
 
 	return mapping, nil
@@ -114,6 +114,40 @@ func TestStringToMetricSpec(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name:  "scale",
+			input: "my_metric*0.01",
+			want: &MetricSpec{
+				MetricName: "my_metric",
+				Labels:     map[string]string{},
+				Scale:      0.01,
+			},
+			wantErr: false,
+		},
+		{
+			name:  "labels and scale",
+			input: "my_metric{label1=value1} * 2",
+			want: &MetricSpec{
+				MetricName: "my_metric",
+				Labels: map[string]string{
+					"label1": "value1",
+				},
+				Scale: 2,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid scale",
+			input:   "my_metric*percent",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "negative scale",
+			input:   "my_metric{label1=value1}*-1",
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name:    "characters after closing brace",
 			input:   "my_metric{label=val}extra",
@@ -162,6 +196,9 @@ func TestStringToMetricSpec(t *testing.T) {
 				if !reflect.DeepEqual(got.Labels, tt.want.Labels) {
 					t.Errorf("stringToMetricSpec() got Labels = %v, want %v", got.Labels, tt.want.Labels)
 				}
+				if got.Scale != tt.want.Scale {
+					t.Errorf("stringToMetricSpec() got Scale = %v, want %v", got.Scale, tt.want.Scale)
+				}
 			} else if tt.want != got { // handles if one is nil and the other isn't
 				t.Errorf("stringToMetricSpec() = %v, want %v", got, tt.want)
 			}
@@ -40,11 +40,28 @@ type MetricsState struct {
 	WaitingQueueSize        int
 	KVCacheUsagePercent     float64
 	KvCacheMaxTokenCapacity int
+	// KvCacheBlockSize is the number of tokens of a KV-cache block.
+	KvCacheBlockSize int
+	// KvCacheNumGPUBlocks is the number of KV-cache blocks on GPU.
+	KvCacheNumGPUBlocks int
+	// Custom holds the custom metrics declared in the configuration, by name.
+	Custom map[string]float64
 
 	// UpdateTime record the last time when the metrics were updated.
 	UpdateTime time.Time
 }
 
+// IsUnknown returns whether the metrics were never updated, e.g. because the pod was never scraped successfully. The
+// values of unknown metrics are zero, which doesn't mean that the pod is idle.
+func (s *MetricsState) IsUnknown() bool {
+	return s == nil || s.UpdateTime.IsZero()
+}
+
+// IsStale returns whether the metrics were last updated more than threshold ago. Unknown metrics are stale.
+func (s *MetricsState) IsStale(threshold time.Duration) bool {
+	return s.IsUnknown() || time.Since(s.UpdateTime) > threshold
+}
+
 // String returns a string with all MetricState information
 func (s *MetricsState) String() string {
 	if s == nil {
@@ -67,6 +84,13 @@ func (s *MetricsState) Clone() *MetricsState {
 	for key, value := range s.WaitingModels {
 		waitingModels[key] = value
 	}
+	var custom map[string]float64
+	if s.Custom != nil {
+		custom = make(map[string]float64, len(s.Custom))
+		for key, value := range s.Custom {
+			custom[key] = value
+		}
+	}
 	return &MetricsState{
 		ActiveModels:            activeModels,
 		WaitingModels:           waitingModels,
@@ -75,6 +99,9 @@ func (s *MetricsState) Clone() *MetricsState {
 		WaitingQueueSize:        s.WaitingQueueSize,
 		KVCacheUsagePercent:     s.KVCacheUsagePercent,
 		KvCacheMaxTokenCapacity: s.KvCacheMaxTokenCapacity,
+		KvCacheBlockSize:        s.KvCacheBlockSize,
+		KvCacheNumGPUBlocks:     s.KvCacheNumGPUBlocks,
+		Custom:                  custom,
 		UpdateTime:              s.UpdateTime,
 	}
 }
@@ -30,6 +30,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"k8s.io/apimachinery/pkg/types"
 
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -313,7 +314,7 @@ func TestGetLatestLoraMetric(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			p := &PodMetricsClientImpl{MetricMapping: tc.mapping}
-			loraMetric, err := p.getLatestLoraMetric(tc.metricFamilies)
+			loraMetric, err := p.getLatestLoraMetric(tc.metricFamilies, p.MetricMapping)
 
 			if tc.expectedErr != nil {
 				if err == nil || err.Error() != tc.expectedErr.Error() {
@@ -410,6 +411,23 @@ func TestPromToPodMetrics(t *testing.T) {
 				MaxActiveModels:     3,
 			},
 		},
+		{
+			name: "scaled metrics",
+			metricFamilies: map[string]*dto.MetricFamily{
+				"kv_cache_usage_percent": makeMetricFamily("kv_cache_usage_percent",
+					makeMetric(nil, 40.0, 1000),
+				),
+			},
+			mapping: &MetricMapping{
+				KVCacheUtilization: &MetricSpec{MetricName: "kv_cache_usage_percent", Scale: 0.01},
+			},
+			existingMetrics: &MetricsState{ActiveModels: map[string]int{}, WaitingModels: map[string]int{}},
+			expectedMetrics: &MetricsState{
+				ActiveModels:        map[string]int{},
+				WaitingModels:       map[string]int{},
+				KVCacheUsagePercent: 0.4,
+			},
+		},
 		{
 			name:           "missing metrics",
 			metricFamilies: map[string]*dto.MetricFamily{}, // No metrics
@@ -471,7 +489,7 @@ func TestPromToPodMetrics(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			p := &PodMetricsClientImpl{MetricMapping: tc.mapping}
-			updated, err := p.promToPodMetrics(tc.metricFamilies, tc.existingMetrics)
+			updated, err := p.promToPodMetrics(tc.metricFamilies, tc.existingMetrics, p.MetricMapping)
 			if tc.expectedErr != nil {
 				assert.Error(t, err)
 				assert.EqualError(t, err, tc.expectedErr.Error())
@@ -497,7 +515,8 @@ func TestFetchMetrics(t *testing.T) {
 	existing := &MetricsState{}
 	p := &PodMetricsClientImpl{} // No MetricMapping needed for this basic test
 
-	_, err := p.FetchMetrics(ctx, pod, existing, 9999) // Use a port that's unlikely to be in use.
+	pool := &v1alpha2.InferencePool{Spec: v1alpha2.InferencePoolSpec{TargetPortNumber: 9999}} // Use a port that's unlikely to be in use.
+	_, err := p.FetchMetrics(ctx, pod, existing, pool)
 	if err == nil {
 		t.Errorf("FetchMetrics() expected error, got nil")
 	}
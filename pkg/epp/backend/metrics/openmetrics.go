@@ -0,0 +1,310 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+)
+
+// openMetricsSuffixes are the suffixes of the samples of the OpenMetrics families by type.
+var openMetricsSuffixes = map[string][]string{
+	"counter":        {"_total", "_created"},
+	"histogram":      {"_bucket", "_count", "_sum", "_created"},
+	"gaugehistogram": {"_bucket", "_gcount", "_gsum"},
+	"summary":        {"_count", "_sum", "_created"},
+	"info":           {"_info"},
+}
+
+// openMetricsParser parses the OpenMetrics text format into the metric families the Prometheus text format is parsed
+// into, so that the metrics are named alike in both formats: the counter families are named after their _total
+// samples, the info families after their _info samples, and the _created samples are dropped.
+type openMetricsParser struct {
+	types    map[string]string // the types of the OpenMetrics families by name
+	help     map[string]string // the help of the OpenMetrics families by name
+	families map[string]*dto.MetricFamily
+	metrics  map[string]*dto.Metric // the metrics of the histograms and summaries by family and labels
+}
+
+// parseOpenMetrics parses metrics in the OpenMetrics text format.
+func parseOpenMetrics(r io.Reader) (map[string]*dto.MetricFamily, error) {
+	p := &openMetricsParser{
+		types:    map[string]string{},
+		help:     map[string]string{},
+		families: map[string]*dto.MetricFamily{},
+		metrics:  map[string]*dto.Metric{},
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if line == "# EOF" {
+			return p.families, nil
+		}
+		var err error
+		if strings.HasPrefix(line, "#") {
+			err = p.parseMetadata(line)
+		} else if line != "" {
+			err = p.parseSample(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the OpenMetrics line %d: %w", lineNumber, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("the OpenMetrics metrics are missing the # EOF line")
+}
+
+// parseMetadata parses the TYPE and HELP lines. The other comments, such as UNIT, are ignored.
+func (p *openMetricsParser) parseMetadata(line string) error {
+	fields := strings.SplitN(line, " ", 4)
+	if len(fields) < 4 {
+		return nil
+	}
+	switch fields[1] {
+	case "TYPE":
+		p.types[fields[2]] = fields[3]
+	case "HELP":
+		p.help[fields[2]] = unescapeOpenMetrics(fields[3])
+	}
+	return nil
+}
+
+// parseSample parses a sample line, i.e. the metric name, the optional labels, the value, the optional timestamp and
+// the optional exemplar, which is ignored.
+func (p *openMetricsParser) parseSample(line string) error {
+	nameEnd := strings.IndexAny(line, "{ ")
+	if nameEnd <= 0 {
+		return fmt.Errorf("invalid sample %q", line)
+	}
+	name := line[:nameEnd]
+	rest := line[nameEnd:]
+	var labels []*dto.LabelPair
+	if rest[0] == '{' {
+		var err error
+		if labels, rest, err = parseOpenMetricsLabels(rest[1:]); err != nil {
+			return err
+		}
+	}
+	if exemplar := strings.Index(rest, " # "); exemplar >= 0 {
+		rest = rest[:exemplar]
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("invalid value of the sample %q", line)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return fmt.Errorf("invalid value of the sample %q: %w", line, err)
+	}
+	var timestampMs *int64
+	if len(fields) == 2 {
+		timestamp, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return fmt.Errorf("invalid timestamp of the sample %q: %w", line, err)
+		}
+		timestampMs = proto.Int64(int64(timestamp * 1000))
+	}
+
+	familyName, familyType, suffix := p.family(name)
+	switch familyType {
+	case "counter":
+		if suffix == "_total" {
+			p.addMetric(name, dto.MetricType_COUNTER, &dto.Metric{
+				Label: labels, Counter: &dto.Counter{Value: proto.Float64(value)}, TimestampMs: timestampMs,
+			}, familyName)
+		}
+	case "gauge", "stateset", "info":
+		p.addMetric(name, dto.MetricType_GAUGE, &dto.Metric{
+			Label: labels, Gauge: &dto.Gauge{Value: proto.Float64(value)}, TimestampMs: timestampMs,
+		}, familyName)
+	case "histogram", "gaugehistogram":
+		return p.addHistogramSample(familyName, familyType, suffix, labels, value, timestampMs)
+	case "summary":
+		return p.addSummarySample(familyName, suffix, labels, value, timestampMs)
+	default:
+		p.addMetric(name, dto.MetricType_UNTYPED, &dto.Metric{
+			Label: labels, Untyped: &dto.Untyped{Value: proto.Float64(value)}, TimestampMs: timestampMs,
+		}, familyName)
+	}
+	return nil
+}
+
+// family returns the name and type of the OpenMetrics family of the sample with the given name, and the suffix of
+// the sample.
+func (p *openMetricsParser) family(name string) (string, string, string) {
+	if familyType, ok := p.types[name]; ok {
+		return name, familyType, ""
+	}
+	for familyType, suffixes := range openMetricsSuffixes {
+		for _, suffix := range suffixes {
+			familyName, found := strings.CutSuffix(name, suffix)
+			if found && p.types[familyName] == familyType {
+				return familyName, familyType, suffix
+			}
+		}
+	}
+	return name, "unknown", ""
+}
+
+// addMetric adds a metric to the family with the given name, creating the family if needed.
+func (p *openMetricsParser) addMetric(name string, metricType dto.MetricType, metric *dto.Metric, familyName string) {
+	family, ok := p.families[name]
+	if !ok {
+		family = &dto.MetricFamily{Name: proto.String(name), Type: metricType.Enum()}
+		if help, ok := p.help[familyName]; ok {
+			family.Help = proto.String(help)
+		}
+		p.families[name] = family
+	}
+	family.Metric = append(family.Metric, metric)
+}
+
+// groupMetric returns the metric of the histogram or summary with the given labels, excluding the bucket or quantile
+// label, creating it if needed.
+func (p *openMetricsParser) groupMetric(familyName string, metricType dto.MetricType, labels []*dto.LabelPair,
+	excluded string, timestampMs *int64) (*dto.Metric, string) {
+	var excludedValue string
+	groupLabels := make([]*dto.LabelPair, 0, len(labels))
+	var key strings.Builder
+	key.WriteString(familyName)
+	for _, label := range labels {
+		if label.GetName() == excluded {
+			excludedValue = label.GetValue()
+			continue
+		}
+		groupLabels = append(groupLabels, label)
+		key.WriteString("\xff" + label.GetName() + "\xff" + label.GetValue())
+	}
+	metric, ok := p.metrics[key.String()]
+	if !ok {
+		metric = &dto.Metric{Label: groupLabels, TimestampMs: timestampMs}
+		p.metrics[key.String()] = metric
+		p.addMetric(familyName, metricType, metric, familyName)
+	}
+	return metric, excludedValue
+}
+
+func (p *openMetricsParser) addHistogramSample(familyName, familyType, suffix string, labels []*dto.LabelPair,
+	value float64, timestampMs *int64) error {
+	metricType := dto.MetricType_HISTOGRAM
+	if familyType == "gaugehistogram" {
+		metricType = dto.MetricType_GAUGE_HISTOGRAM
+	}
+	metric, le := p.groupMetric(familyName, metricType, labels, "le", timestampMs)
+	if metric.Histogram == nil {
+		metric.Histogram = &dto.Histogram{}
+	}
+	switch suffix {
+	case "_bucket":
+		upperBound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			return fmt.Errorf("invalid bucket %q of %s: %w", le, familyName, err)
+		}
+		metric.Histogram.Bucket = append(metric.Histogram.Bucket, &dto.Bucket{
+			UpperBound: proto.Float64(upperBound), CumulativeCount: proto.Uint64(uint64(value)),
+		})
+	case "_count", "_gcount":
+		metric.Histogram.SampleCount = proto.Uint64(uint64(value))
+	case "_sum", "_gsum":
+		metric.Histogram.SampleSum = proto.Float64(value)
+	}
+	return nil
+}
+
+func (p *openMetricsParser) addSummarySample(familyName, suffix string, labels []*dto.LabelPair, value float64,
+	timestampMs *int64) error {
+	metric, quantile := p.groupMetric(familyName, dto.MetricType_SUMMARY, labels, "quantile", timestampMs)
+	if metric.Summary == nil {
+		metric.Summary = &dto.Summary{}
+	}
+	switch suffix {
+	case "":
+		q, err := strconv.ParseFloat(quantile, 64)
+		if err != nil {
+			return fmt.Errorf("invalid quantile %q of %s: %w", quantile, familyName, err)
+		}
+		metric.Summary.Quantile = append(metric.Summary.Quantile, &dto.Quantile{
+			Quantile: proto.Float64(q), Value: proto.Float64(value),
+		})
+	case "_count":
+		metric.Summary.SampleCount = proto.Uint64(uint64(value))
+	case "_sum":
+		metric.Summary.SampleSum = proto.Float64(value)
+	}
+	return nil
+}
+
+// parseOpenMetricsLabels parses the labels following the opening brace, sorted by name, and returns the rest of the
+// line after the closing brace.
+func parseOpenMetricsLabels(s string) ([]*dto.LabelPair, string, error) {
+	var labels []*dto.LabelPair
+	for {
+		s = strings.TrimLeft(s, ", ")
+		if strings.HasPrefix(s, "}") {
+			sort.Slice(labels, func(i, j int) bool { return labels[i].GetName() < labels[j].GetName() })
+			return labels, s[1:], nil
+		}
+		nameEnd := strings.Index(s, "=\"")
+		if nameEnd <= 0 {
+			return nil, "", fmt.Errorf("invalid labels %q", s)
+		}
+		name := s[:nameEnd]
+		s = s[nameEnd+2:]
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(s); i++ {
+			if s[i] == '"' {
+				s = s[i+1:]
+				closed = true
+				break
+			}
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				if s[i] == 'n' {
+					value.WriteByte('\n')
+					continue
+				}
+			}
+			value.WriteByte(s[i])
+		}
+		if !closed {
+			return nil, "", fmt.Errorf("unterminated value of the label %q", name)
+		}
+		labels = append(labels, &dto.LabelPair{Name: proto.String(name), Value: proto.String(value.String())})
+	}
+}
+
+// unescapeOpenMetrics unescapes the backslashes, double quotes and line feeds of the help.
+func unescapeOpenMetrics(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	return strings.NewReplacer(`\\`, `\`, `\"`, `"`, `\n`, "\n").Replace(s)
+}
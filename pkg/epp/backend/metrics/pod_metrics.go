@@ -27,22 +27,22 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
-const (
-	fetchMetricsTimeout = 5 * time.Second
-)
-
 type podMetrics struct {
-	pod      atomic.Pointer[backend.Pod]
-	metrics  atomic.Pointer[MetricsState]
-	pmc      PodMetricsClient
-	ds       Datastore
-	interval time.Duration
-
-	startOnce sync.Once // ensures the refresh loop goroutine is started only once
+	pod       atomic.Pointer[backend.Pod]
+	metrics   atomic.Pointer[MetricsState]
+	pmc       PodMetricsClient
+	ds        Datastore
+	scheduler *scrapeScheduler
+	// pushTimeout is the time the scraping is skipped for after the pod pushed its metrics.
+	pushTimeout time.Duration
+	lastPush    atomic.Int64 // the time of the last push, in Unix nanoseconds
+
+	startOnce sync.Once // ensures the refresh is scheduled only once
 	stopOnce  sync.Once // ensures the done channel is closed only once
 	done      chan struct{}
 
@@ -50,7 +50,13 @@ type podMetrics struct {
 }
 
 type PodMetricsClient interface {
-	FetchMetrics(ctx context.Context, pod *backend.Pod, existing *MetricsState, port int32) (*MetricsState, error)
+	FetchMetrics(ctx context.Context, pod *backend.Pod, existing *MetricsState, pool *v1alpha2.InferencePool) (*MetricsState, error)
+}
+
+// podStateDeleter is implemented by the PodMetricsClients keeping state per pod, which is deleted when the refresh
+// of the pod stops.
+type podStateDeleter interface {
+	DeletePodState(pod types.NamespacedName)
 }
 
 func (pm *podMetrics) String() string {
@@ -74,67 +80,93 @@ func toInternalPod(pod *corev1.Pod) *backend.Pod {
 	for key, value := range pod.GetLabels() {
 		labels[key] = value
 	}
+	var annotations map[string]string
+	if len(pod.GetAnnotations()) > 0 {
+		annotations = make(map[string]string, len(pod.GetAnnotations()))
+		for key, value := range pod.GetAnnotations() {
+			annotations[key] = value
+		}
+	}
 	return &backend.Pod{
 		NamespacedName: types.NamespacedName{
 			Name:      pod.Name,
 			Namespace: pod.Namespace,
 		},
-		Address: pod.Status.PodIP,
-		Labels:  labels,
+		Address:     pod.Status.PodIP,
+		Labels:      labels,
+		Annotations: annotations,
 	}
 }
 
-// start starts a goroutine exactly once to periodically update metrics. The goroutine will be
-// stopped either when stop() is called, or the given ctx is cancelled.
+// startRefreshLoop schedules the periodic refresh of the metrics exactly once. The refresh is stopped either when
+// StopRefreshLoop() is called, or the given ctx is cancelled.
 func (pm *podMetrics) startRefreshLoop(ctx context.Context) {
 	pm.startOnce.Do(func() {
-		go func() {
-			pm.logger.V(logutil.DEFAULT).Info("Starting refresher", "pod", pm.GetPod())
-			ticker := time.NewTicker(pm.interval)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-pm.done:
-					return
-				case <-ctx.Done():
-					return
-				case <-ticker.C: // refresh metrics periodically
-					if err := pm.refreshMetrics(); err != nil {
-						pm.logger.V(logutil.TRACE).Error(err, "Failed to refresh metrics", "pod", pm.GetPod())
-					}
-				}
-			}
-		}()
+		pm.logger.V(logutil.DEFAULT).Info("Starting refresher", "pod", pm.GetPod())
+		pm.scheduler.add(ctx, pm)
 	})
 }
 
-func (pm *podMetrics) refreshMetrics() error {
+// refreshMetrics scrapes the metrics of the pod. It returns an error if the metrics could not be fetched at all.
+func (pm *podMetrics) refreshMetrics(timeout time.Duration) error {
 	pool, err := pm.ds.PoolGet()
 	if err != nil {
 		// No inference pool or not initialize.
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), fetchMetricsTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	updated, err := pm.pmc.FetchMetrics(ctx, pm.GetPod(), pm.GetMetrics(), pool.Spec.TargetPortNumber)
-	if err != nil {
-		pm.logger.V(logutil.TRACE).Info("Failed to refreshed metrics:", "err", err)
-	}
+	updated, err := pm.pmc.FetchMetrics(ctx, pm.GetPod(), pm.GetMetrics(), pool)
 	// Optimistically update metrics even if there was an error.
 	// The FetchMetrics can return an error for the following reasons:
-	// 1. As refresher is running in the background, it's possible that the pod is deleted but
-	// the refresh goroutine doesn't read the done channel yet. In this case, the updated
-	// metrics object will be nil. And the refresher will soon be stopped.
+	// 1. The metrics endpoint of the pod can't be scraped, e.g. because the pod is deleted but its
+	// refresh is not stopped yet, or the model server is not ready. In this case, the updated
+	// metrics object will be nil, and the next scrapes of the pod are backed off.
 	// 2. The FetchMetrics call can partially fail. For example, due to one metric missing. In
 	// this case, the updated metrics object will have partial updates. A partial update is
 	// considered better than no updates.
-	if updated != nil {
+	if updated == nil {
+		return err
+	}
+	if err != nil {
+		pm.logger.V(logutil.TRACE).Info("Failed to refreshed metrics:", "err", err)
+	}
+	updated.UpdateTime = time.Now()
+	pm.logger.V(logutil.TRACE).Info("Refreshed metrics", "updated", updated)
+	pm.metrics.Store(updated)
+	return nil
+}
+
+// PushMetrics updates the metrics with those pushed by the pod. The scraping of the pod is skipped until it hasn't
+// pushed for the push timeout.
+func (pm *podMetrics) PushMetrics(update func(existing *MetricsState) *MetricsState) {
+	updated := pm.swapMetrics(update)
+	pm.lastPush.Store(updated.UpdateTime.UnixNano())
+	pm.logger.V(logutil.TRACE).Info("Pushed metrics", "updated", updated)
+}
+
+// UpdateMetrics updates the metrics with those reported inline by the pod. The pod keeps being scraped.
+func (pm *podMetrics) UpdateMetrics(update func(existing *MetricsState) *MetricsState) {
+	updated := pm.swapMetrics(update)
+	pm.logger.V(logutil.TRACE).Info("Updated metrics", "updated", updated)
+}
+
+// swapMetrics stores the metrics returned by the update function, and returns them. The update is retried if the
+// metrics changed concurrently, so that concurrent updates don't lose each other's values.
+func (pm *podMetrics) swapMetrics(update func(existing *MetricsState) *MetricsState) *MetricsState {
+	for {
+		existing := pm.metrics.Load()
+		updated := update(existing)
 		updated.UpdateTime = time.Now()
-		pm.logger.V(logutil.TRACE).Info("Refreshed metrics", "updated", updated)
-		pm.metrics.Store(updated)
+		if pm.metrics.CompareAndSwap(existing, updated) {
+			return updated
+		}
 	}
+}
 
-	return nil
+func (pm *podMetrics) pushedRecently() bool {
+	lastPush := pm.lastPush.Load()
+	return lastPush != 0 && time.Since(time.Unix(0, lastPush)) < pm.pushTimeout
 }
 
 func (pm *podMetrics) StopRefreshLoop() {
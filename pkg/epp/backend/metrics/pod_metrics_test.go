@@ -17,6 +17,7 @@ package metrics
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -84,6 +85,81 @@ func TestMetricsRefresh(t *testing.T) {
 	assert.EventuallyWithT(t, condition, time.Second, time.Millisecond)
 }
 
+func TestPushedMetricsSkipScraping(t *testing.T) {
+	ctx := context.Background()
+	namespacedName := types.NamespacedName{Name: pod1.Name, Namespace: pod1.Namespace}
+	pmc := &FakePodMetricsClient{}
+	pmc.SetRes(map[types.NamespacedName]*MetricsState{namespacedName: initial})
+	pmf := NewPodMetricsFactory(pmc, time.Millisecond)
+	pmf.PushTimeout = time.Hour
+
+	pm := pmf.NewPodMetrics(ctx, pod1, &fakeDataStore{})
+	defer pm.StopRefreshLoop()
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		assert.True(collect, cmp.Equal(pm.GetMetrics(), initial, cmpopts.IgnoreFields(MetricsState{}, "UpdateTime")))
+	}, time.Second, time.Millisecond)
+
+	// The pushed metrics are not overwritten by the scraped ones while the pod keeps pushing.
+	pm.PushMetrics(func(*MetricsState) *MetricsState { return updated.Clone() })
+	if pm.GetMetrics().UpdateTime.IsZero() {
+		t.Error("Expected the update time of the pushed metrics to be set")
+	}
+	time.Sleep(20 * pmf.refreshMetricsInterval)
+	if diff := cmp.Diff(updated, pm.GetMetrics(), cmpopts.IgnoreFields(MetricsState{}, "UpdateTime")); diff != "" {
+		t.Errorf("Unexpected metrics (-want +got): %s", diff)
+	}
+
+	// The scraping resumes once the pod stopped pushing for the push timeout.
+	pm.(*podMetrics).lastPush.Store(time.Now().Add(-pmf.PushTimeout).UnixNano())
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		assert.True(collect, cmp.Equal(pm.GetMetrics(), initial, cmpopts.IgnoreFields(MetricsState{}, "UpdateTime")))
+	}, time.Second, time.Millisecond)
+}
+
+func TestConcurrentPushedMetrics(t *testing.T) {
+	pmf := NewPodMetricsFactory(&FakePodMetricsClient{}, time.Hour)
+	pm := pmf.NewPodMetrics(context.Background(), pod1, &fakeDataStore{})
+	defer pm.StopRefreshLoop()
+
+	// Each push and inline update increments the waiting queue size, none of them is lost.
+	const updates = 100
+	increment := func(existing *MetricsState) *MetricsState {
+		updated := existing.Clone()
+		updated.WaitingQueueSize++
+		return updated
+	}
+	var wg sync.WaitGroup
+	for range updates {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			pm.PushMetrics(increment)
+		}()
+		go func() {
+			defer wg.Done()
+			pm.UpdateMetrics(increment)
+		}()
+	}
+	wg.Wait()
+	if got := pm.GetMetrics().WaitingQueueSize; got != 2*updates {
+		t.Errorf("Unexpected waiting queue size %d, want %d", got, 2*updates)
+	}
+}
+
+func TestUpdatedMetricsKeepScraping(t *testing.T) {
+	pmf := NewPodMetricsFactory(&FakePodMetricsClient{}, time.Hour)
+	pm := pmf.NewPodMetrics(context.Background(), pod1, &fakeDataStore{})
+	defer pm.StopRefreshLoop()
+
+	pm.UpdateMetrics(func(*MetricsState) *MetricsState { return updated.Clone() })
+	if pm.GetMetrics().UpdateTime.IsZero() {
+		t.Error("Expected the update time of the updated metrics to be set")
+	}
+	if pm.(*podMetrics).pushedRecently() {
+		t.Error("Expected the inline updates not to skip the scraping")
+	}
+}
+
 type fakeDataStore struct{}
 
 func (f *fakeDataStore) PoolGet() (*v1alpha2.InferencePool, error) {
@@ -0,0 +1,245 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"container/heap"
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// DefaultScrapeConcurrency is the default number of pods scraped concurrently.
+	DefaultScrapeConcurrency = 100
+	// DefaultScrapeTimeout is the default timeout of a scrape.
+	DefaultScrapeTimeout = 5 * time.Second
+	// DefaultScrapeJitter is the default fraction of the refresh interval the scrapes are randomly shifted by, so that
+	// the pods are not all scraped at once.
+	DefaultScrapeJitter = 0.1
+	// DefaultMaxScrapeBackoff is the default maximum interval between the scrapes of a failing pod.
+	DefaultMaxScrapeBackoff = 10 * time.Second
+)
+
+// scrapeTarget is a pod scheduled to be scraped.
+type scrapeTarget struct {
+	ctx      context.Context
+	pm       *podMetrics
+	next     time.Time
+	failures int
+	index    int
+}
+
+// scrapeQueue is a min-heap of the scrape targets by the time of their next scrape.
+type scrapeQueue []*scrapeTarget
+
+func (q scrapeQueue) Len() int           { return len(q) }
+func (q scrapeQueue) Less(i, j int) bool { return q[i].next.Before(q[j].next) }
+func (q scrapeQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *scrapeQueue) Push(x any) {
+	target := x.(*scrapeTarget)
+	target.index = len(*q)
+	*q = append(*q, target)
+}
+func (q *scrapeQueue) Pop() any {
+	old := *q
+	target := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return target
+}
+
+// scrapeScheduler scrapes the pods of a PodMetricsFactory with a bounded pool of workers, instead of a goroutine per
+// pod. The scrapes are jittered, and the interval between the scrapes of a failing pod backs off exponentially.
+type scrapeScheduler struct {
+	interval    time.Duration
+	timeout     time.Duration
+	jitter      float64
+	maxBackoff  time.Duration
+	concurrency int
+
+	startOnce sync.Once
+	mu        sync.Mutex
+	queue     scrapeQueue
+	wakeup    chan struct{}
+}
+
+func newScrapeScheduler(f *PodMetricsFactory) *scrapeScheduler {
+	s := &scrapeScheduler{
+		interval:    f.refreshMetricsInterval,
+		timeout:     f.ScrapeTimeout,
+		jitter:      f.ScrapeJitter,
+		maxBackoff:  f.MaxScrapeBackoff,
+		concurrency: f.ScrapeConcurrency,
+		wakeup:      make(chan struct{}, 1),
+	}
+	if s.timeout <= 0 {
+		s.timeout = DefaultScrapeTimeout
+	}
+	if s.concurrency <= 0 {
+		s.concurrency = DefaultScrapeConcurrency
+	}
+	if s.maxBackoff < s.interval {
+		s.maxBackoff = s.interval
+	}
+	return s
+}
+
+// add schedules the scrapes of the pod until it is stopped or the context is cancelled. The first scrape is randomly
+// spread over the refresh interval. The workers are started with the context of the first pod.
+func (s *scrapeScheduler) add(ctx context.Context, pm *podMetrics) {
+	s.startOnce.Do(func() {
+		work := make(chan *scrapeTarget)
+		for range s.concurrency {
+			go s.worker(ctx, work)
+		}
+		go s.dispatch(ctx, work)
+	})
+	var delay time.Duration
+	if s.interval > 0 {
+		delay = rand.N(s.interval)
+	}
+	s.schedule(&scrapeTarget{ctx: ctx, pm: pm, next: time.Now().Add(delay)})
+}
+
+func (s *scrapeScheduler) schedule(target *scrapeTarget) {
+	s.mu.Lock()
+	heap.Push(&s.queue, target)
+	s.mu.Unlock()
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch hands the targets due for a scrape to the workers, in the order of their next scrape. It blocks when all
+// the workers are busy, which delays the next scrapes rather than piling them up.
+func (s *scrapeScheduler) dispatch(ctx context.Context, work chan<- *scrapeTarget) {
+	timer := time.NewTimer(s.interval)
+	defer timer.Stop()
+	for {
+		var due *scrapeTarget
+		wait := time.Duration(-1)
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			if wait = time.Until(s.queue[0].next); wait <= 0 {
+				due = heap.Pop(&s.queue).(*scrapeTarget)
+			}
+		}
+		s.mu.Unlock()
+
+		if due != nil {
+			if s.stopped(due) {
+				continue
+			}
+			select {
+			case work <- due:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		var timerC <-chan time.Time
+		if wait > 0 {
+			timer.Reset(wait)
+			timerC = timer.C
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wakeup:
+		case <-timerC:
+		}
+	}
+}
+
+func (s *scrapeScheduler) worker(ctx context.Context, work <-chan *scrapeTarget) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case target := <-work:
+			s.scrape(target)
+		}
+	}
+}
+
+// scrape scrapes the pod, unless it pushed its metrics recently, and schedules its next scrape.
+func (s *scrapeScheduler) scrape(target *scrapeTarget) {
+	pm := target.pm
+	interval := s.interval
+	if !pm.pushedRecently() {
+		start := time.Now()
+		err := pm.refreshMetrics(s.timeout)
+		metrics.RecordModelServerScrape(err == nil, time.Since(start))
+		if err != nil {
+			target.failures++
+			interval = s.backoff(target.failures)
+			pm.logger.V(logutil.TRACE).Error(err, "Failed to refresh metrics", "pod", pm.GetPod(),
+				"failures", target.failures, "nextScrape", interval)
+		} else {
+			target.failures = 0
+		}
+	}
+	if s.stopped(target) {
+		return
+	}
+	target.next = time.Now().Add(s.jittered(interval))
+	s.schedule(target)
+}
+
+// stopped returns whether the scrapes of the target are stopped, in which case the state kept by the client for the
+// pod is deleted. It is called only while the target is not scheduled, so that no scrape in flight recreates the
+// state.
+func (s *scrapeScheduler) stopped(target *scrapeTarget) bool {
+	select {
+	case <-target.pm.done:
+	case <-target.ctx.Done():
+	default:
+		return false
+	}
+	if deleter, ok := target.pm.pmc.(podStateDeleter); ok {
+		deleter.DeletePodState(target.pm.GetPod().NamespacedName)
+	}
+	return true
+}
+
+// backoff returns the interval before the next scrape of a pod that failed the given number of times in a row.
+func (s *scrapeScheduler) backoff(failures int) time.Duration {
+	interval := s.interval
+	for i := 1; i < failures && interval < s.maxBackoff; i++ {
+		interval *= 2
+	}
+	return min(interval, s.maxBackoff)
+}
+
+// jittered randomly shifts the interval by up to the jitter fraction of it, in either direction.
+func (s *scrapeScheduler) jittered(interval time.Duration) time.Duration {
+	if s.jitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration((rand.Float64()*2-1)*s.jitter*float64(interval))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+)
+
+// countingPodMetricsClient counts the fetches per pod and the maximum number of concurrent fetches. The fetches of the
+// failing pods return an error.
+type countingPodMetricsClient struct {
+	delay   time.Duration
+	failing map[string]bool
+
+	mu            sync.Mutex
+	fetches       map[string]int
+	inFlight      int
+	maxInFlight   int
+	deletedStates atomic.Int32
+}
+
+func (c *countingPodMetricsClient) FetchMetrics(_ context.Context, pod *backend.Pod, existing *MetricsState,
+	_ *v1alpha2.InferencePool) (*MetricsState, error) {
+	c.mu.Lock()
+	c.fetches[pod.NamespacedName.Name]++
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+
+	time.Sleep(c.delay)
+
+	c.mu.Lock()
+	c.inFlight--
+	c.mu.Unlock()
+	if c.failing[pod.NamespacedName.Name] {
+		return nil, errors.New("connection refused")
+	}
+	return existing.Clone(), nil
+}
+
+func (c *countingPodMetricsClient) DeletePodState(types.NamespacedName) {
+	c.deletedStates.Add(1)
+}
+
+func (c *countingPodMetricsClient) fetchCount(pod string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetches[pod]
+}
+
+func newPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+}
+
+func TestScrapeSchedulerConcurrency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pmc := &countingPodMetricsClient{delay: 5 * time.Millisecond, fetches: map[string]int{}}
+	pmf := NewPodMetricsFactory(pmc, time.Millisecond)
+	pmf.ScrapeConcurrency = 3
+
+	const numPods = 20
+	pods := make([]PodMetrics, 0, numPods)
+	for i := range numPods {
+		pods = append(pods, pmf.NewPodMetrics(ctx, newPod(fmt.Sprintf("pod%d", i)), &fakeDataStore{}))
+	}
+
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		for i := range numPods {
+			assert.GreaterOrEqual(collect, pmc.fetchCount(fmt.Sprintf("pod%d", i)), 2)
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	pmc.mu.Lock()
+	maxInFlight := pmc.maxInFlight
+	pmc.mu.Unlock()
+	if maxInFlight > pmf.ScrapeConcurrency {
+		t.Errorf("Got %d concurrent scrapes, want at most %d", maxInFlight, pmf.ScrapeConcurrency)
+	}
+	for _, pm := range pods {
+		if pm.GetMetrics().UpdateTime.IsZero() {
+			t.Errorf("Expected the metrics of %s to be updated", pm.GetPod().NamespacedName)
+		}
+	}
+
+	// The state of the stopped pods is deleted once their scrape in flight, if any, is done.
+	for _, pm := range pods {
+		pm.StopRefreshLoop()
+	}
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		assert.Equal(collect, int32(numPods), pmc.deletedStates.Load())
+	}, time.Second, time.Millisecond)
+}
+
+func TestScrapeSchedulerBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pmc := &countingPodMetricsClient{fetches: map[string]int{}, failing: map[string]bool{"failing": true}}
+	pmf := NewPodMetricsFactory(pmc, time.Millisecond)
+	pmf.MaxScrapeBackoff = 100 * time.Millisecond
+
+	healthy := pmf.NewPodMetrics(ctx, newPod("healthy"), &fakeDataStore{})
+	defer healthy.StopRefreshLoop()
+	failing := pmf.NewPodMetrics(ctx, newPod("failing"), &fakeDataStore{})
+	defer failing.StopRefreshLoop()
+
+	// The failing pod is scraped after 1, 2, 4, 8, 16 and 32ms, the healthy one every 1ms meanwhile. The counts are
+	// compared to each other rather than to the elapsed time, which depends on the load of the machine.
+	const failingScrapes = 6
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		assert.GreaterOrEqual(collect, pmc.fetchCount("failing"), failingScrapes)
+	}, 5*time.Second, time.Millisecond)
+	failingCount, healthyCount := pmc.fetchCount("failing"), pmc.fetchCount("healthy")
+	if healthyCount < 3*failingCount {
+		t.Errorf("Got %d scrapes of the healthy pod for %d scrapes of the failing pod, want at least %d", healthyCount,
+			failingCount, 3*failingCount)
+	}
+}
+
+func TestScrapeBackoff(t *testing.T) {
+	s := &scrapeScheduler{interval: 50 * time.Millisecond, maxBackoff: time.Second}
+	want := []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond,
+		400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := s.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
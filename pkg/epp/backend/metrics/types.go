@@ -27,28 +27,57 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 )
 
+// DefaultPushTimeout is the default time the scraping of a pod is skipped for after it pushed its metrics.
+const DefaultPushTimeout = time.Second
+
 func NewPodMetricsFactory(pmc PodMetricsClient, refreshMetricsInterval time.Duration) *PodMetricsFactory {
 	return &PodMetricsFactory{
 		pmc:                    pmc,
 		refreshMetricsInterval: refreshMetricsInterval,
+		PushTimeout:            DefaultPushTimeout,
+		ScrapeConcurrency:      DefaultScrapeConcurrency,
+		ScrapeTimeout:          DefaultScrapeTimeout,
+		ScrapeJitter:           DefaultScrapeJitter,
+		MaxScrapeBackoff:       DefaultMaxScrapeBackoff,
 	}
 }
 
 type PodMetricsFactory struct {
 	pmc                    PodMetricsClient
 	refreshMetricsInterval time.Duration
+
+	// PushTimeout is the time the scraping of a pod is skipped for after it pushed its metrics. Once a pod stops
+	// pushing, it is scraped again.
+	PushTimeout time.Duration
+	// ScrapeConcurrency is the maximum number of pods scraped concurrently.
+	ScrapeConcurrency int
+	// ScrapeTimeout is the timeout of a scrape.
+	ScrapeTimeout time.Duration
+	// ScrapeJitter is the fraction of the refresh interval the scrapes are randomly shifted by.
+	ScrapeJitter float64
+	// MaxScrapeBackoff is the maximum interval between the scrapes of a pod that fails to be scraped. The interval
+	// doubles with every failure, starting from the refresh interval.
+	MaxScrapeBackoff time.Duration
+
+	// The scheduler is created on the first pod, once the fields are set.
+	schedulerOnce sync.Once
+	scheduler     *scrapeScheduler
 }
 
 func (f *PodMetricsFactory) NewPodMetrics(parentCtx context.Context, in *corev1.Pod, ds Datastore) PodMetrics {
+	f.schedulerOnce.Do(func() {
+		f.scheduler = newScrapeScheduler(f)
+	})
 	pod := toInternalPod(in)
 	pm := &podMetrics{
-		pmc:       f.pmc,
-		ds:        ds,
-		interval:  f.refreshMetricsInterval,
-		startOnce: sync.Once{},
-		stopOnce:  sync.Once{},
-		done:      make(chan struct{}),
-		logger:    log.FromContext(parentCtx).WithValues("pod", pod.NamespacedName),
+		pmc:         f.pmc,
+		ds:          ds,
+		scheduler:   f.scheduler,
+		pushTimeout: f.PushTimeout,
+		startOnce:   sync.Once{},
+		stopOnce:    sync.Once{},
+		done:        make(chan struct{}),
+		logger:      log.FromContext(parentCtx).WithValues("pod", pod.NamespacedName),
 	}
 	pm.pod.Store(pod)
 	pm.metrics.Store(newMetricsState())
@@ -61,6 +90,13 @@ type PodMetrics interface {
 	GetPod() *backend.Pod
 	GetMetrics() *MetricsState
 	UpdatePod(*corev1.Pod)
+	// PushMetrics atomically updates the metrics with those pushed by the pod, in lieu of scraping them. The update
+	// function returns the updated metrics from the existing ones, and may be called more than once.
+	PushMetrics(update func(existing *MetricsState) *MetricsState)
+	// UpdateMetrics atomically updates the metrics with those reported inline by the pod, e.g. in the headers of its
+	// responses. Unlike the pushed metrics, they don't skip the scraping of the pod. The update function returns the
+	// updated metrics from the existing ones, and may be called more than once.
+	UpdateMetrics(update func(existing *MetricsState) *MetricsState)
 	StopRefreshLoop()
 	String() string
 }
@@ -26,6 +26,7 @@ type Pod struct {
 	NamespacedName types.NamespacedName
 	Address        string
 	Labels         map[string]string
+	Annotations    map[string]string
 }
 
 func (p *Pod) String() string {
@@ -43,12 +44,20 @@ func (p *Pod) Clone() *Pod {
 	for key, value := range p.Labels {
 		clonedLabels[key] = value
 	}
+	var clonedAnnotations map[string]string
+	if p.Annotations != nil {
+		clonedAnnotations = make(map[string]string, len(p.Annotations))
+		for key, value := range p.Annotations {
+			clonedAnnotations[key] = value
+		}
+	}
 	return &Pod{
 		NamespacedName: types.NamespacedName{
 			Name:      p.NamespacedName.Name,
 			Namespace: p.NamespacedName.Namespace,
 		},
-		Address: p.Address,
-		Labels:  clonedLabels,
+		Address:     p.Address,
+		Labels:      clonedLabels,
+		Annotations: clonedAnnotations,
 	}
 }
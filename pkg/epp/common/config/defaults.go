@@ -18,6 +18,11 @@ limitations under the License.
 // different EPP components.
 package config
 
+import (
+	"fmt"
+	"time"
+)
+
 const (
 	// DefaultKVCacheThreshold is the default KV cache utilization (0.0 to 1.0)
 	// threshold.
@@ -25,4 +30,21 @@ const (
 	// DefaultQueueThresholdCritical is the default backend waiting queue size
 	// threshold.
 	DefaultQueueThresholdCritical = 5
+	// DefaultMetricsStalenessThreshold is the default age beyond which the
+	// metrics of a pod are considered stale. Given the pod metrics refresh
+	// interval is 50ms, a threshold slightly above that should be fine.
+	DefaultMetricsStalenessThreshold = 200 * time.Millisecond
 )
+
+// ParseMetricsStalenessThreshold parses a metrics staleness threshold given as a duration, e.g. 200ms. An empty
+// threshold stands for DefaultMetricsStalenessThreshold.
+func ParseMetricsStalenessThreshold(threshold string) (time.Duration, error) {
+	if threshold == "" {
+		return DefaultMetricsStalenessThreshold, nil
+	}
+	duration, err := time.ParseDuration(threshold)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid threshold %q, expected a positive duration", threshold)
+	}
+	return duration, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMetricsStalenessThreshold(t *testing.T) {
+	tests := []struct {
+		threshold string
+		want      time.Duration
+		wantErr   bool
+	}{
+		{threshold: "", want: DefaultMetricsStalenessThreshold},
+		{threshold: "1s", want: time.Second},
+		{threshold: "0s", wantErr: true},
+		{threshold: "-1s", wantErr: true},
+		{threshold: "1", wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := ParseMetricsStalenessThreshold(test.threshold)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("ParseMetricsStalenessThreshold(%q) did not return an expected error", test.threshold)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("ParseMetricsStalenessThreshold(%q) = %v, %v, want %v", test.threshold, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"fmt"
+	"sync"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+)
+
+// Applier applies new versions of the configuration to the Director, without restarting the EPP. The plugins that
+// are unchanged from the current version are carried over with their state. An invalid version is rejected and the
+// current configuration is kept.
+type Applier struct {
+	// Director is the Director the new scheduler and plugins are applied to.
+	Director *requestcontrol.Director
+	// RequestControlConfig is the requestcontrol configuration the plugins of the configuration are added to. It is
+	// not modified.
+	RequestControlConfig *requestcontrol.Config
+	// NewHandle returns the handle the plugins of a new version of the configuration are instantiated in, given the
+	// names of its custom metrics.
+	NewHandle func(customMetrics []string) plugins.Handle
+	// DecisionTrace enables the decision trace of the new schedulers.
+	DecisionTrace bool
+	// MetricsClient is the client the custom metrics are applied to, if set.
+	MetricsClient *backendmetrics.PodMetricsClientImpl
+	// SaturationDetector is the Detector the saturationDetector section is applied to, if set.
+	SaturationDetector *saturationdetector.Detector
+	// SaturationDetectorConfig is the Detector configuration the saturationDetector section overrides, e.g. the one
+	// loaded from the environment variables. It is not modified.
+	SaturationDetectorConfig *saturationdetector.Config
+
+	mu     sync.Mutex
+	config *configapi.EndpointPickerConfig
+	handle plugins.Handle
+}
+
+// SetCurrent sets the configuration currently in use, with the handle of its plugins. It must be called before the
+// first version is applied.
+func (a *Applier) SetCurrent(theConfig *configapi.EndpointPickerConfig, handle plugins.Handle) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = theConfig
+	a.handle = handle
+}
+
+// Current returns the configuration currently in use, or nil if it was not set.
+func (a *Applier) Current() *configapi.EndpointPickerConfig {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.config
+}
+
+// Apply validates the given configuration and applies it to the Director. It returns the names of the plugins that
+// were carried over from the current configuration.
+func (a *Applier) Apply(theConfig *configapi.EndpointPickerConfig) ([]string, error) {
+	scheme.Default(theConfig)
+	if err := validateConfiguration(theConfig); err != nil {
+		return nil, fmt.Errorf("the configuration is invalid. error: %s", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	handle := a.NewHandle(CustomMetricNames(theConfig.CustomMetrics))
+	var previousPlugins []configapi.PluginSpec
+	if a.config != nil {
+		previousPlugins = a.config.Plugins
+	}
+	reused, err := ReloadPluginReferences(theConfig.Plugins, handle, previousPlugins, a.handle)
+	if err != nil {
+		return nil, err
+	}
+	schedulerConfig, err := LoadSchedulerConfig(theConfig.SchedulingProfiles, handle)
+	if err != nil {
+		return nil, err
+	}
+	customMetrics, err := LoadCustomMetrics(theConfig.CustomMetrics)
+	if err != nil {
+		return nil, err
+	}
+	requestControlConfig := a.RequestControlConfig.Clone()
+	requestControlConfig.AddPlugins(handle.Plugins().GetAllPlugins()...)
+
+	a.Director.Update(scheduling.NewSchedulerWithConfig(schedulerConfig).WithDecisionTrace(a.DecisionTrace), requestControlConfig)
+	if a.MetricsClient != nil {
+		a.MetricsClient.SetCustomMetrics(customMetrics)
+	}
+	if a.SaturationDetector != nil {
+		a.SaturationDetector.UpdateConfig(saturationdetector.ApplyAPIConfig(a.SaturationDetectorConfig, theConfig.SaturationDetector))
+	}
+	a.config = theConfig
+	a.handle = handle
+	return reused, nil
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package loader
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
+	"slices"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -27,6 +32,7 @@ import (
 
 	"sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
 	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
@@ -73,30 +79,179 @@ func LoadPluginReferences(thePlugins []configapi.PluginSpec, handle plugins.Hand
 		}
 		handle.Plugins().AddPlugin(pluginConfig.Name, thePlugin)
 	}
+	return resolvePluginReferences(handle)
+}
+
+// ReloadPluginReferences instantiates the plugins of a new version of the configuration. The plugins whose name, type
+// and parameters didn't change from the previous version are reused from the previous handle instead of being
+// instantiated again, so they keep their state (e.g. the prefix cache index), unless they depend on custom metrics
+// that are no longer declared. It returns the names of the reused plugins.
+func ReloadPluginReferences(thePlugins []configapi.PluginSpec, handle plugins.Handle, previousPlugins []configapi.PluginSpec,
+	previousHandle plugins.Handle) ([]string, error) {
+	reused := []string{}
+	for _, pluginConfig := range thePlugins {
+		var thePlugin plugins.Plugin
+		if previousHandle != nil && slices.ContainsFunc(previousPlugins, func(previous configapi.PluginSpec) bool {
+			return samePluginSpec(previous, pluginConfig)
+		}) {
+			thePlugin = previousHandle.Plugins().Plugin(pluginConfig.Name)
+			// The referenced plugins may have changed, and the plugins of the previous version are still in use.
+			if _, ok := thePlugin.(plugins.ReferencingPlugin); ok {
+				thePlugin = nil
+			}
+			// The custom metrics may have changed, the factory validates the plugin against the new ones.
+			if customMetricsPlugin, ok := thePlugin.(plugins.CustomMetricsPlugin); ok &&
+				!isSubset(customMetricsPlugin.CustomMetrics(), handle.CustomMetrics()) {
+				thePlugin = nil
+			}
+		}
+		if thePlugin != nil {
+			reused = append(reused, pluginConfig.Name)
+		} else {
+			var err error
+			if thePlugin, err = instantiatePlugin(pluginConfig, handle); err != nil {
+				return nil, err
+			}
+		}
+		handle.Plugins().AddPlugin(pluginConfig.Name, thePlugin)
+	}
+	if err := resolvePluginReferences(handle); err != nil {
+		return nil, err
+	}
+	return reused, nil
+}
+
+// isSubset returns whether all the names are in the given set of names.
+func isSubset(names []string, set []string) bool {
+	for _, name := range names {
+		if !slices.Contains(set, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolvePluginReferences resolves the references of the plugins that reference other plugins, once all the plugins
+// are instantiated. It returns an error if the references form a cycle.
+func resolvePluginReferences(handle plugins.Handle) error {
+	references := map[string][]string{}
+	for name, thePlugin := range handle.Plugins().GetAllPluginsWithNames() {
+		if referencingPlugin, ok := thePlugin.(plugins.ReferencingPlugin); ok {
+			references[name] = referencingPlugin.References()
+		}
+	}
+	if cycle := findReferenceCycle(references); cycle != nil {
+		return fmt.Errorf("the plugin references form a cycle: %s", strings.Join(cycle, " -> "))
+	}
+	for _, name := range slices.Sorted(maps.Keys(references)) {
+		if err := handle.Plugins().Plugin(name).(plugins.ReferencingPlugin).ResolveReferences(handle); err != nil {
+			return fmt.Errorf("failed to resolve the references of the plugin %s. Error: %s", name, err)
+		}
+	}
 	return nil
 }
 
+// findReferenceCycle returns the first cycle found in the reference graph, as the path of plugin names from the
+// first plugin of the cycle back to itself, or nil if there is no cycle.
+func findReferenceCycle(references map[string][]string) []string {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := map[string]int{}
+	path := []string{}
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+			return append(slices.Clone(path[start:]), name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, reference := range references[name] {
+			if cycle := visit(reference); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(references)) {
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// samePluginSpec returns true if the two plugin specs have the same name, type and parameters, regardless of the
+// formatting of the parameters.
+func samePluginSpec(a, b configapi.PluginSpec) bool {
+	if a.Name != b.Name || a.Type != b.Type {
+		return false
+	}
+	var aParameters, bParameters any
+	if len(a.Parameters) > 0 {
+		if err := json.Unmarshal(a.Parameters, &aParameters); err != nil {
+			return false
+		}
+	}
+	if len(b.Parameters) > 0 {
+		if err := json.Unmarshal(b.Parameters, &bParameters); err != nil {
+			return false
+		}
+	}
+	return reflect.DeepEqual(aParameters, bParameters)
+}
+
 func LoadSchedulerConfig(configProfiles []v1alpha1.SchedulingProfile, handle plugins.Handle) (*scheduling.SchedulerConfig, error) {
 
 	var profiles = map[string]*framework.SchedulerProfile{}
 
 	for _, configProfile := range configProfiles {
 		profile := framework.SchedulerProfile{}
+		hasPicker := false
+
+		if configProfile.ScoreCombination != nil {
+			normalization := configProfile.ScoreCombination.Normalization
+			strategy := configProfile.ScoreCombination.Strategy
+			if err := framework.ValidateScoreCombination(normalization, strategy); err != nil {
+				return nil, fmt.Errorf("invalid score combination of SchedulingProfile '%s' - %w", configProfile.Name, err)
+			}
+			profile.WithScoreCombination(normalization, strategy)
+		}
 
 		for _, plugin := range configProfile.Plugins {
 			var err error
 			thePlugin := handle.Plugins().Plugin(plugin.PluginRef)
+			if !isProfilePlugin(thePlugin) {
+				return nil, fmt.Errorf("plugin '%s' of SchedulingProfile '%s' is not a filter, scorer or picker", plugin.PluginRef, configProfile.Name)
+			}
+			if _, ok := thePlugin.(framework.Picker); ok {
+				hasPicker = true
+			}
 			if theScorer, ok := thePlugin.(framework.Scorer); ok {
 				if plugin.Weight == nil {
 					return nil, fmt.Errorf("scorer '%s' is missing a weight", plugin.PluginRef)
 				}
-				thePlugin = framework.NewWeightedScorer(theScorer, *plugin.Weight)
+				weight := plugin.Weight.AsApproximateFloat64()
+				if weight < 0 {
+					return nil, fmt.Errorf("scorer '%s' has a negative weight %s", plugin.PluginRef, plugin.Weight)
+				}
+				thePlugin = framework.NewWeightedScorer(theScorer, weight)
 			}
 			err = profile.AddPlugins(thePlugin)
 			if err != nil {
 				return nil, err
 			}
 		}
+		if !hasPicker {
+			return nil, fmt.Errorf("SchedulingProfile '%s' is missing a picker", configProfile.Name)
+		}
 		profiles[configProfile.Name] = &profile
 	}
 
@@ -119,12 +274,54 @@ func LoadSchedulerConfig(configProfiles []v1alpha1.SchedulingProfile, handle plu
 	return scheduling.NewSchedulerConfig(profileHandler, profiles), nil
 }
 
+// LoadCustomMetrics returns the specs of the custom metrics scraped from the model servers.
+func LoadCustomMetrics(customMetrics []configapi.CustomMetric) ([]*backendmetrics.CustomMetricSpec, error) {
+	specs := make([]*backendmetrics.CustomMetricSpec, 0, len(customMetrics))
+	names := make(map[string]struct{}, len(customMetrics))
+	for _, customMetric := range customMetrics {
+		if _, ok := names[customMetric.Name]; ok {
+			return nil, fmt.Errorf("the name %s has been specified for more than one custom metric", customMetric.Name)
+		}
+		names[customMetric.Name] = struct{}{}
+
+		spec, err := backendmetrics.NewCustomMetricSpec(customMetric.Name, customMetric.Metric, customMetric.Aggregation)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// CustomMetricNames returns the names of the custom metrics, which the plugins can reference.
+func CustomMetricNames(customMetrics []configapi.CustomMetric) []string {
+	names := make([]string, 0, len(customMetrics))
+	for _, customMetric := range customMetrics {
+		names = append(names, customMetric.Name)
+	}
+	return names
+}
+
+// isProfilePlugin returns true if the plugin implements one of the extension points of a SchedulerProfile.
+func isProfilePlugin(thePlugin plugins.Plugin) bool {
+	switch thePlugin.(type) {
+	case framework.Filter, framework.Scorer, framework.Picker, framework.PostCycle:
+		return true
+	}
+	return false
+}
+
 func instantiatePlugin(pluginSpec configapi.PluginSpec, handle plugins.Handle) (plugins.Plugin, error) {
-	factory, ok := plugins.Registry[pluginSpec.Type]
+	registration, ok := plugins.Registry[pluginSpec.Type]
 	if !ok {
 		return nil, fmt.Errorf("failed to instantiate the plugin. plugin type %s not found", pluginSpec.Type)
 	}
-	thePlugin, err := factory(pluginSpec.Name, pluginSpec.Parameters, handle)
+	if registration.Parameters != nil {
+		if err := registration.Parameters.Validate(pluginSpec.Parameters); err != nil {
+			return nil, fmt.Errorf("failed to instantiate the plugin %s of type %s. Error: %s", pluginSpec.Name, pluginSpec.Type, err)
+		}
+	}
+	thePlugin, err := registration.Factory(pluginSpec.Name, pluginSpec.Parameters, handle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to instantiate the plugin type %s. Error: %s", pluginSpec.Type, err)
 	}
@@ -185,5 +382,25 @@ func validateConfiguration(theConfig *configapi.EndpointPickerConfig) error {
 			}
 		}
 	}
+	return validateSaturationDetector(theConfig.SaturationDetector)
+}
+
+func validateSaturationDetector(sdConfig *configapi.SaturationDetectorConfig) error {
+	if sdConfig == nil {
+		return nil
+	}
+	if sdConfig.QueueDepthThreshold != nil && *sdConfig.QueueDepthThreshold <= 0 {
+		return fmt.Errorf("the queueDepthThreshold of the saturationDetector must be positive, got %d", *sdConfig.QueueDepthThreshold)
+	}
+	if sdConfig.KVCacheUtilThreshold != nil {
+		if threshold := sdConfig.KVCacheUtilThreshold.AsApproximateFloat64(); threshold <= 0 || threshold >= 1 {
+			return fmt.Errorf("the kvCacheUtilThreshold of the saturationDetector must be between 0 and 1 exclusive, got %s",
+				sdConfig.KVCacheUtilThreshold)
+		}
+	}
+	if sdConfig.MetricsStalenessThreshold != nil && sdConfig.MetricsStalenessThreshold.Duration <= 0 {
+		return fmt.Errorf("the metricsStalenessThreshold of the saturationDetector must be positive, got %s",
+			sdConfig.MetricsStalenessThreshold.Duration)
+	}
 	return nil
 }
@@ -19,9 +19,11 @@ package loader
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
@@ -31,6 +33,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
 )
@@ -43,7 +46,7 @@ const (
 )
 
 func TestLoadConfiguration(t *testing.T) {
-	test2Weight := 50
+	test2Weight := resource.MustParse("50")
 
 	registerTestPlugins()
 
@@ -171,6 +174,24 @@ func TestLoadConfiguration(t *testing.T) {
 			configFile: "",
 			wantErr:    true,
 		},
+		{
+			name:       "errorSaturationDetectorQueueDepth",
+			configText: successConfigText + "saturationDetector:\n  queueDepthThreshold: 0\n",
+			configFile: "",
+			wantErr:    true,
+		},
+		{
+			name:       "errorSaturationDetectorKVCacheUtil",
+			configText: successConfigText + "saturationDetector:\n  kvCacheUtilThreshold: 1.5\n",
+			configFile: "",
+			wantErr:    true,
+		},
+		{
+			name:       "errorSaturationDetectorStaleness",
+			configText: successConfigText + "saturationDetector:\n  metricsStalenessThreshold: -1s\n",
+			configFile: "",
+			wantErr:    true,
+		},
 		{
 			name:       "successFromFile",
 			configText: "",
@@ -233,6 +254,133 @@ func TestLoadPluginReferences(t *testing.T) {
 	}
 }
 
+func TestDecisionTreeReferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		configText string
+		wantErr    string
+	}{
+		{
+			name:       "forward references",
+			configText: decisionTreeConfigText,
+		},
+		{
+			name:       "cycle",
+			configText: strings.Replace(decisionTreeConfigText, "nextOnSuccessOrFailure: test1", "nextOnSuccessOrFailure: root", 1),
+			wantErr:    "the plugin references form a cycle: leaf -> root -> leaf",
+		},
+		{
+			name:       "self reference",
+			configText: strings.Replace(decisionTreeConfigText, "nextOnFailure: test1", "nextOnFailure: root", 1),
+			wantErr:    "the plugin references form a cycle: root -> root",
+		},
+		{
+			name:       "missing reference",
+			configText: strings.Replace(decisionTreeConfigText, "current: test1", "current: missing", 1),
+			wantErr:    "the plugin 'missing' referenced by the decision tree does not exist",
+		},
+		{
+			name:       "not a filter",
+			configText: strings.Replace(decisionTreeConfigText, "current: test1", "current: testPicker", 1),
+			wantErr:    "the plugin 'testPicker' referenced by the decision tree is not a filter",
+		},
+	}
+
+	registerNeededPlgugins()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theConfig, err := LoadConfig([]byte(test.configText), "")
+			if err != nil {
+				t.Fatalf("LoadConfig returned unexpected error: %v", err)
+			}
+			handle := utils.NewTestHandle()
+			err = LoadPluginReferences(theConfig.Plugins, handle)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("LoadPluginReferences returned error %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadPluginReferences returned unexpected error: %v", err)
+			}
+			root, ok := handle.Plugins().Plugin("root").(*filter.DecisionTreeFilter)
+			if !ok {
+				t.Fatalf("The root plugin is not a decision tree: %#v", handle.Plugins().Plugin("root"))
+			}
+			if root.Current != handle.Plugins().Plugin("test1") || root.NextOnSuccess != handle.Plugins().Plugin("leaf") {
+				t.Errorf("The references of the root decision tree are not resolved: %#v", root)
+			}
+			if _, err := LoadSchedulerConfig(theConfig.SchedulingProfiles, handle); err != nil {
+				t.Errorf("LoadSchedulerConfig returned unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestReloadPluginReferences(t *testing.T) {
+	previousConfig, err := LoadConfig([]byte(successConfigText), "")
+	if err != nil {
+		t.Fatalf("LoadConfig returned unexpected error: %v", err)
+	}
+	previousHandle := utils.NewTestHandle()
+	if err := LoadPluginReferences(previousConfig.Plugins, previousHandle); err != nil {
+		t.Fatalf("LoadPluginReferences returned unexpected error: %v", err)
+	}
+
+	// The parameters of test1 changed, the parameters of test-two are only formatted differently.
+	theConfig, err := LoadConfig([]byte(strings.NewReplacer("threshold: 10", "threshold: 20",
+		"parameters:\n    hashBlockSize: 32", "parameters: {\"hashBlockSize\": 32}").Replace(successConfigText)), "")
+	if err != nil {
+		t.Fatalf("LoadConfig returned unexpected error: %v", err)
+	}
+	handle := utils.NewTestHandle()
+	reused, err := ReloadPluginReferences(theConfig.Plugins, handle, previousConfig.Plugins, previousHandle)
+	if err != nil {
+		t.Fatalf("ReloadPluginReferences returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"profileHandler", "test-two", "testPicker"}, reused); diff != "" {
+		t.Errorf("Unexpected reused plugins (-want +got): %s", diff)
+	}
+	for _, name := range reused {
+		if handle.Plugins().Plugin(name) != previousHandle.Plugins().Plugin(name) {
+			t.Errorf("Expected plugin %s to be reused", name)
+		}
+	}
+	if t1, ok := handle.Plugins().Plugin("test1").(*test1); !ok || t1 == previousHandle.Plugins().Plugin("test1") || t1.Threshold != 20 {
+		t.Errorf("Expected a new instance of test1 with a threshold of 20, got %#v", handle.Plugins().Plugin("test1"))
+	}
+}
+
+func TestReloadCustomMetricPlugin(t *testing.T) {
+	plugins.RegisterWithSchema(scorer.CustomMetricScorerType, scorer.CustomMetricScorerFactory, scorer.CustomMetricScorerParameterSchema)
+	thePlugins := []configapi.PluginSpec{{
+		Name:       "custom",
+		Type:       scorer.CustomMetricScorerType,
+		Parameters: json.RawMessage(`{"metric": "ttft_p90"}`),
+	}}
+	previousHandle := utils.NewTestHandle("ttft_p90", "throughput")
+	if err := LoadPluginReferences(thePlugins, previousHandle); err != nil {
+		t.Fatalf("LoadPluginReferences returned unexpected error: %v", err)
+	}
+
+	// The plugin is reused as long as its metric is declared.
+	reused, err := ReloadPluginReferences(thePlugins, utils.NewTestHandle("ttft_p90"), thePlugins, previousHandle)
+	if err != nil {
+		t.Fatalf("ReloadPluginReferences returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"custom"}, reused); diff != "" {
+		t.Errorf("Unexpected reused plugins (-want +got): %s", diff)
+	}
+
+	// The new version no longer declares the metric.
+	if _, err := ReloadPluginReferences(thePlugins, utils.NewTestHandle("throughput"), thePlugins, previousHandle); err == nil {
+		t.Error("ReloadPluginReferences expected an error for an undeclared custom metric, got nil")
+	}
+}
+
 func TestInstantiatePlugin(t *testing.T) {
 	plugSpec := configapi.PluginSpec{Type: "plover"}
 	_, err := instantiatePlugin(plugSpec, utils.NewTestHandle())
@@ -282,6 +430,37 @@ func TestLoadSchedulerConfig(t *testing.T) {
 			configText: errorNoProfileHandlersText,
 			wantErr:    true,
 		},
+		{
+			name:       "errorUnknownParameter",
+			configText: strings.Replace(successSchedulerConfigText, "hashBlockSize: 32", "blockSize: 32", 1),
+			wantErr:    true,
+		},
+		{
+			name:       "errorNoPicker",
+			configText: strings.Replace(successSchedulerConfigText, "  - pluginRef: maxScore\n", "", 1),
+			wantErr:    true,
+		},
+		{
+			name: "scoreCombination",
+			configText: strings.Replace(successSchedulerConfigText, "weight: 50", "weight: 0.5", 1) +
+				"  scoreCombination:\n    normalization: MinMax\n    strategy: Product\n",
+			wantErr: false,
+		},
+		{
+			name:       "errorNegativeWeight",
+			configText: strings.Replace(successSchedulerConfigText, "weight: 50", "weight: -1", 1),
+			wantErr:    true,
+		},
+		{
+			name:       "errorUnknownScoreCombination",
+			configText: successSchedulerConfigText + "  scoreCombination:\n    strategy: Max\n",
+			wantErr:    true,
+		},
+		{
+			name:       "errorProfileHandlerInProfile",
+			configText: successSchedulerConfigText + "  - pluginRef: profileHandler\n",
+			wantErr:    true,
+		},
 	}
 
 	registerNeededPlgugins()
@@ -314,12 +493,88 @@ func TestLoadSchedulerConfig(t *testing.T) {
 	}
 }
 
+func TestLoadCustomMetrics(t *testing.T) {
+	tests := []struct {
+		name          string
+		customMetrics string
+		wantNames     []string
+		wantErr       bool
+	}{
+		{
+			name:      "no custom metrics",
+			wantNames: []string{},
+		},
+		{
+			name: "custom metrics",
+			customMetrics: `customMetrics:
+- name: ttft_p90
+  metric: vllm:time_to_first_token_seconds
+  aggregation: histogram_quantile(0.9)
+- name: running
+  metric: vllm:num_requests_running{model_name=llama}
+`,
+			wantNames: []string{"ttft_p90", "running"},
+		},
+		{
+			name: "errorDuplicateName",
+			customMetrics: `customMetrics:
+- name: running
+  metric: vllm:num_requests_running
+- name: running
+  metric: vllm:num_requests_waiting
+`,
+			wantErr: true,
+		},
+		{
+			name: "errorUnknownAggregation",
+			customMetrics: `customMetrics:
+- name: running
+  metric: vllm:num_requests_running
+  aggregation: median
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theConfig, err := LoadConfig([]byte(successSchedulerConfigText+test.customMetrics), "")
+			if err != nil {
+				t.Fatalf("LoadConfig returned unexpected error: %v", err)
+			}
+			specs, err := LoadCustomMetrics(theConfig.CustomMetrics)
+			if test.wantErr {
+				if err == nil {
+					t.Error("LoadCustomMetrics did not return an expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadCustomMetrics returned unexpected error: %v", err)
+			}
+			names := []string{}
+			for _, spec := range specs {
+				names = append(names, spec.Name)
+			}
+			if diff := cmp.Diff(test.wantNames, names); diff != "" {
+				t.Errorf("Unexpected custom metrics (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
 func registerNeededPlgugins() {
-	plugins.Register(filter.LowQueueFilterType, filter.LowQueueFilterFactory)
-	plugins.Register(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory)
+	plugins.RegisterWithSchema(filter.DecisionTreeFilterType, filter.DecisionTreeFilterFactory, filter.DecisionTreeFilterParameterSchema)
+	plugins.RegisterWithSchema(filter.LoraAffinityFilterType, filter.LoraAffinityFilterFactory, filter.LoraAffinityFilterParameterSchema)
+	plugins.RegisterWithSchema(filter.LeastQueueFilterType, filter.LeastQueueFilterFactory, plugins.NoParameters)
+	plugins.RegisterWithSchema(filter.LeastKVCacheFilterType, filter.LeastKVCacheFilterFactory, plugins.NoParameters)
+	plugins.RegisterWithSchema(filter.LowQueueFilterType, filter.LowQueueFilterFactory, filter.LowQueueFilterParameterSchema)
+	plugins.RegisterWithSchema(prefix.PrefixCachePluginType, prefix.PrefixCachePluginFactory, prefix.PrefixCachePluginParameterSchema)
 	plugins.Register(picker.MaxScorePickerType, picker.MaxScorePickerFactory)
 	plugins.Register(picker.RandomPickerType, picker.RandomPickerFactory)
 	plugins.Register(profile.SingleProfileHandlerType, profile.SingleProfileHandlerFactory)
+	plugins.RegisterWithSchema(scorer.QueueScorerType, scorer.QueueScorerFactory, plugins.NoParameters)
+	plugins.RegisterWithSchema(scorer.KvCacheScorerType, scorer.KvCacheScorerFactory, plugins.NoParameters)
 }
 
 // The following multi-line string constants, cause false positive lint errors (dupword)
@@ -548,6 +803,39 @@ schedulingProfiles:
   - pluginRef: test2
 `
 
+// decision trees referencing plugins declared after them
+//
+//nolint:dupword
+const decisionTreeConfigText = `
+apiVersion: inference.networking.x-k8s.io/v1alpha1
+kind: EndpointPickerConfig
+plugins:
+- name: root
+  type: decision-tree
+  parameters:
+    current: test1
+    nextOnSuccess: leaf
+    nextOnFailure: test1
+- name: leaf
+  type: decision-tree
+  parameters:
+    current: test1
+    nextOnSuccessOrFailure: test1
+- name: test1
+  type: test-one
+  parameters:
+    threshold: 10
+- name: profileHandler
+  type: test-profile-handler
+- name: testPicker
+  type: test-picker
+schedulingProfiles:
+- name: default
+  plugins:
+  - pluginRef: root
+  - pluginRef: testPicker
+`
+
 // compile-time type validation
 var _ framework.Filter = &test1{}
 
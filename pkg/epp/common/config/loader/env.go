@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/config"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/filter"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
+	envutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/env"
+)
+
+// The deprecated environment variables of the scheduler, replaced by the configuration presets.
+const (
+	EnvSchedulerV2            = "EXPERIMENTAL_USE_SCHEDULER_V2"
+	EnvPrefixCacheScheduling  = "ENABLE_PREFIX_CACHE_SCHEDULING"
+	EnvQueueScoreWeight       = "QUEUE_SCORE_WEIGHT"
+	EnvKVCacheScoreWeight     = "KV_CACHE_SCORE_WEIGHT"
+	EnvPrefixCacheScoreWeight = "PREFIX_CACHE_SCORE_WEIGHT"
+	EnvPrefixCacheHashBlock   = "PREFIX_CACHE_HASH_BLOCK_SIZE"
+	EnvPrefixCacheMaxBlocks   = "PREFIX_CACHE_MAX_PREFIX_BLOCKS"
+	EnvPrefixCacheLRUCapacity = "PREFIX_CACHE_LRU_CAPACITY_PER_SERVER"
+	EnvQueueingThresholdLoRA  = "QUEUING_THRESHOLD_LORA"
+	EnvLoraAffinityThreshold  = "LORA_AFFINITY_THRESHOLD"
+	deprecatedEnvReplacement  = "the configPreset flag or an EndpointPickerConfig"
+)
+
+// LoadConfigFromEnv maps the deprecated scheduler environment variables onto the equivalent configuration preset,
+// for backwards compatibility. If EXPERIMENTAL_USE_SCHEDULER_V2 isn't enabled, the LoRA thresholds are mapped onto
+// the default preset, and nil is returned when none of them is set, in which case the default scheduler is used.
+// A deprecation warning is logged for each of the variables that is set, along with the equivalent configuration.
+func LoadConfigFromEnv(logger logr.Logger) (*configapi.EndpointPickerConfig, error) {
+	envutil.WarnDeprecated(logger, deprecatedEnvReplacement, EnvSchedulerV2, EnvPrefixCacheScheduling,
+		EnvQueueScoreWeight, EnvKVCacheScoreWeight, EnvPrefixCacheScoreWeight,
+		EnvPrefixCacheHashBlock, EnvPrefixCacheMaxBlocks, EnvPrefixCacheLRUCapacity,
+		EnvQueueingThresholdLoRA, EnvLoraAffinityThreshold)
+	if !envutil.GetEnvBool(EnvSchedulerV2, false, logger) {
+		return loadDefaultPresetFromEnv(logger)
+	}
+
+	presetName := LoadAwarePreset
+	prefixCacheScheduling := envutil.GetEnvBool(EnvPrefixCacheScheduling, false, logger)
+	if prefixCacheScheduling {
+		presetName = PrefixCacheAwarePreset
+	}
+	theConfig, err := LoadPreset(presetName)
+	if err != nil {
+		return nil, err
+	}
+
+	weights := map[string]int{
+		scorer.QueueScorerType:   envutil.GetEnvInt(EnvQueueScoreWeight, scorer.DefaultQueueScorerWeight, logger),
+		scorer.KvCacheScorerType: envutil.GetEnvInt(EnvKVCacheScoreWeight, scorer.DefaultKVCacheScorerWeight, logger),
+	}
+	if prefixCacheScheduling {
+		weights[prefix.PrefixCachePluginType] = envutil.GetEnvInt(EnvPrefixCacheScoreWeight, prefix.DefaultScorerWeight, logger)
+
+		parameters, err := json.Marshal(prefix.Config{
+			HashBlockSize:          envutil.GetEnvInt(EnvPrefixCacheHashBlock, prefix.DefaultHashBlockSize, logger),
+			MaxPrefixBlocksToMatch: envutil.GetEnvInt(EnvPrefixCacheMaxBlocks, prefix.DefaultMaxPrefixBlocks, logger),
+			LRUCapacityPerServer:   envutil.GetEnvInt(EnvPrefixCacheLRUCapacity, prefix.DefaultLRUCapacityPerServer, logger),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal the prefix cache parameters - %w", err)
+		}
+		for idx := range theConfig.Plugins {
+			if theConfig.Plugins[idx].Name == prefix.PrefixCachePluginType {
+				theConfig.Plugins[idx].Parameters = parameters
+			}
+		}
+	}
+	for _, profile := range theConfig.SchedulingProfiles {
+		for idx, pluginRef := range profile.Plugins {
+			if weight, ok := weights[pluginRef.PluginRef]; ok {
+				profile.Plugins[idx].Weight = resource.NewQuantity(int64(weight), resource.DecimalSI)
+			}
+		}
+	}
+
+	logger.Info("The scheduler is configured by deprecated environment variables, use the equivalent configuration instead",
+		"preset", presetName, "config", theConfig)
+	return theConfig, nil
+}
+
+// loadDefaultPresetFromEnv maps the deprecated LoRA threshold environment variables onto the parameters of the
+// low-queue and lora-affinity filters of the default preset. It returns nil if none of them is set.
+func loadDefaultPresetFromEnv(logger logr.Logger) (*configapi.EndpointPickerConfig, error) {
+	parameters := map[string]any{}
+	if _, ok := os.LookupEnv(EnvQueueingThresholdLoRA); ok {
+		parameters[filter.LowQueueFilterType] = map[string]int{
+			"threshold": envutil.GetEnvInt(EnvQueueingThresholdLoRA, config.DefaultQueueingThresholdLoRA, logger),
+		}
+	}
+	if _, ok := os.LookupEnv(EnvLoraAffinityThreshold); ok {
+		parameters[filter.LoraAffinityFilterType] = map[string]float64{
+			"threshold": envutil.GetEnvFloat(EnvLoraAffinityThreshold, config.DefaultLoraAffinityThreshold, logger),
+		}
+	}
+	if len(parameters) == 0 {
+		return nil, nil
+	}
+
+	theConfig, err := LoadPreset(DefaultPreset)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range theConfig.Plugins {
+		if pluginParameters, ok := parameters[theConfig.Plugins[idx].Name]; ok {
+			if theConfig.Plugins[idx].Parameters, err = json.Marshal(pluginParameters); err != nil {
+				return nil, fmt.Errorf("failed to marshal the %s parameters - %w", theConfig.Plugins[idx].Name, err)
+			}
+		}
+	}
+
+	logger.Info("The scheduler is configured by deprecated environment variables, use the equivalent configuration instead",
+		"preset", DefaultPreset, "config", theConfig)
+	return theConfig, nil
+}
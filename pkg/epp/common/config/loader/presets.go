@@ -0,0 +1,75 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+)
+
+const (
+	// DefaultPreset is the algorithm of the default scheduler, expressed as a decision tree of filters.
+	DefaultPreset = "default"
+	// LoadAwarePreset balances the load across the pods, based on their queue size and KV-cache utilization.
+	LoadAwarePreset = "load-aware"
+	// PrefixCacheAwarePreset adds the prefix cache scorer to the LoadAwarePreset.
+	PrefixCacheAwarePreset = "prefix-cache-aware"
+
+	presetsDir = "presets"
+)
+
+// presets are the built-in EndpointPickerConfig documents, named after their file name without extension.
+//
+//go:embed presets/*.yaml
+var presets embed.FS
+
+// PresetNames returns the sorted names of the built-in configuration presets.
+func PresetNames() []string {
+	entries, err := presets.ReadDir(presetsDir)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// PresetText returns the text of the built-in configuration preset with the given name.
+func PresetText(name string) ([]byte, error) {
+	if !slices.Contains(PresetNames(), name) {
+		return nil, fmt.Errorf("unknown configuration preset %q, the presets are %s", name, strings.Join(PresetNames(), ", "))
+	}
+	return presets.ReadFile(path.Join(presetsDir, name+".yaml"))
+}
+
+// LoadPreset loads the built-in configuration preset with the given name. The returned configuration can be modified
+// by the caller.
+func LoadPreset(name string) (*configapi.EndpointPickerConfig, error) {
+	text, err := PresetText(name)
+	if err != nil {
+		return nil, err
+	}
+	return LoadConfig(text, "")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
+)
+
+func TestLoadPreset(t *testing.T) {
+	registerNeededPlgugins()
+
+	if diff := cmp.Diff([]string{DefaultPreset, LoadAwarePreset, PrefixCacheAwarePreset}, PresetNames()); diff != "" {
+		t.Errorf("Unexpected preset names (-want +got): %s", diff)
+	}
+	for _, name := range PresetNames() {
+		theConfig, err := LoadPreset(name)
+		if err != nil {
+			t.Fatalf("LoadPreset(%s) returned unexpected error: %v", name, err)
+		}
+		handle := utils.NewTestHandle()
+		if err := LoadPluginReferences(theConfig.Plugins, handle); err != nil {
+			t.Fatalf("LoadPluginReferences(%s) returned unexpected error: %v", name, err)
+		}
+		if _, err := LoadSchedulerConfig(theConfig.SchedulingProfiles, handle); err != nil {
+			t.Errorf("LoadSchedulerConfig(%s) returned unexpected error: %v", name, err)
+		}
+	}
+
+	if _, err := LoadPreset("unknown"); err == nil {
+		t.Error("LoadPreset did not return an expected error for an unknown preset")
+	}
+}
+
+func TestLoadConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantPlugins map[string]string
+		wantWeights map[string]float64
+	}{
+		{
+			name: "scheduler v2 disabled",
+			env:  map[string]string{EnvQueueScoreWeight: "2"},
+		},
+		{
+			name: "LoRA thresholds",
+			env:  map[string]string{EnvQueueingThresholdLoRA: "64", EnvLoraAffinityThreshold: "0.5"},
+			wantPlugins: map[string]string{
+				"single-profile":                    "",
+				"low-queue":                         `{"threshold":64}`,
+				"lora-affinity":                     `{"threshold":0.5}`,
+				"least-queue":                       "",
+				"least-KV-cache":                    "",
+				"low-latency":                       `{"current":"low-queue","nextOnFailure":"least-queue-then-lora-affinity","nextOnSuccess":"lora-affinity-then-least-queue"}`,
+				"lora-affinity-then-least-queue":    `{"current":"lora-affinity","nextOnSuccessOrFailure":"least-queue-then-least-kv-cache"}`,
+				"least-queue-then-least-kv-cache":   `{"current":"least-queue","nextOnSuccessOrFailure":"least-KV-cache"}`,
+				"least-queue-then-lora-affinity":    `{"current":"least-queue","nextOnSuccessOrFailure":"lora-affinity-then-least-kv-cache"}`,
+				"lora-affinity-then-least-kv-cache": `{"current":"lora-affinity","nextOnSuccessOrFailure":"least-KV-cache"}`,
+				"random":                            "",
+			},
+			wantWeights: map[string]float64{},
+		},
+		{
+			name:        "load aware",
+			env:         map[string]string{EnvSchedulerV2: "true", EnvQueueScoreWeight: "2"},
+			wantPlugins: map[string]string{"single-profile": "", "queue": "", "kv-cache": "", "max-score": ""},
+			wantWeights: map[string]float64{"queue": 2, "kv-cache": 1},
+		},
+		{
+			name: "prefix cache aware",
+			env: map[string]string{
+				EnvSchedulerV2:            "true",
+				EnvPrefixCacheScheduling:  "true",
+				EnvKVCacheScoreWeight:     "3",
+				EnvPrefixCacheScoreWeight: "4",
+				EnvPrefixCacheMaxBlocks:   "1024",
+			},
+			wantPlugins: map[string]string{
+				"single-profile": "",
+				"queue":          "",
+				"kv-cache":       "",
+				"max-score":      "",
+				"prefix-cache": mustMarshal(t, prefix.Config{
+					HashBlockSize:          prefix.DefaultHashBlockSize,
+					MaxPrefixBlocksToMatch: 1024,
+					LRUCapacityPerServer:   prefix.DefaultLRUCapacityPerServer,
+				}),
+			},
+			wantWeights: map[string]float64{"queue": 1, "kv-cache": 3, "prefix-cache": 4},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for key, value := range test.env {
+				t.Setenv(key, value)
+			}
+			theConfig, err := LoadConfigFromEnv(logr.Discard())
+			if err != nil {
+				t.Fatalf("LoadConfigFromEnv returned unexpected error: %v", err)
+			}
+			if test.wantPlugins == nil {
+				if theConfig != nil {
+					t.Errorf("LoadConfigFromEnv returned an unexpected configuration: %v", theConfig)
+				}
+				return
+			}
+
+			gotPlugins := map[string]string{}
+			for _, plugin := range theConfig.Plugins {
+				gotPlugins[plugin.Name] = string(plugin.Parameters)
+			}
+			if diff := cmp.Diff(test.wantPlugins, gotPlugins); diff != "" {
+				t.Errorf("Unexpected plugins (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(test.wantWeights, weights(theConfig)); diff != "" {
+				t.Errorf("Unexpected weights (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func weights(theConfig *configapi.EndpointPickerConfig) map[string]float64 {
+	result := map[string]float64{}
+	for _, profile := range theConfig.SchedulingProfiles {
+		for _, plugin := range profile.Plugins {
+			if plugin.Weight != nil {
+				result[plugin.PluginRef] = plugin.Weight.AsApproximateFloat64()
+			}
+		}
+	}
+	return result
+}
+
+func mustMarshal(t *testing.T, v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %v: %v", v, err)
+	}
+	return string(data)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"sigs.k8s.io/gateway-api-inference-extension/internal/runnable"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// Reloader watches the configuration file and applies its new versions with an Applier, without restarting the EPP.
+// The file is polled rather than watched for file system events, so the atomic symlink swaps of a mounted ConfigMap
+// are picked up as well.
+type Reloader struct {
+	// FileName is the path of the configuration file.
+	FileName string
+	// Interval is the interval between two checks of the configuration file.
+	Interval time.Duration
+	// Applier applies the new versions of the configuration.
+	Applier *Applier
+
+	contents []byte
+}
+
+// SetCurrent sets the contents of the configuration file currently in use. It must be called before the Reloader is
+// started.
+func (r *Reloader) SetCurrent(contents []byte) {
+	r.contents = contents
+}
+
+// AsRunnable returns a Runnable that polls the configuration file until the context is cancelled.
+// The runnable implements LeaderElectionRunnable with leader election disabled.
+func (r *Reloader) AsRunnable() manager.Runnable {
+	return runnable.NoLeaderElection(manager.RunnableFunc(func(ctx context.Context) error {
+		ticker := time.NewTicker(r.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if _, err := r.Reload(ctx); err != nil {
+					log.FromContext(ctx).Error(err, "Failed to reload the configuration, keeping the current one", "file", r.FileName)
+				}
+			}
+		}
+	}))
+}
+
+// Reload reads the configuration file and, if it changed, applies the new version. It returns true if a new version
+// was applied.
+func (r *Reloader) Reload(ctx context.Context) (bool, error) {
+	logger := log.FromContext(ctx)
+	contents, err := os.ReadFile(r.FileName)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	if bytes.Equal(contents, r.contents) {
+		return false, nil
+	}
+	// The version is only tried once, a fix of an invalid version is a new version.
+	r.contents = contents
+
+	reused, err := r.apply(contents)
+	metrics.RecordConfigReload(err == nil)
+	if err != nil {
+		return false, err
+	}
+	logger.Info("Configuration reloaded", "file", r.FileName, "reusedPlugins", reused)
+	logger.V(logutil.DEBUG).Info("New configuration", "config", r.Applier.Current())
+	return true, nil
+}
+
+func (r *Reloader) apply(contents []byte) ([]string, error) {
+	if len(contents) == 0 {
+		return nil, fmt.Errorf("the configuration file %s is empty", r.FileName)
+	}
+	theConfig, err := LoadConfig(contents, r.FileName)
+	if err != nil {
+		return nil, err
+	}
+	return r.Applier.Apply(theConfig)
+}
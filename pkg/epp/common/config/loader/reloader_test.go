@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/saturationdetector"
+	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
+)
+
+func TestReloader(t *testing.T) {
+	registerTestPlugins()
+	ctx := context.Background()
+	fileName := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(contents string) {
+		if err := os.WriteFile(fileName, []byte(contents), 0o644); err != nil {
+			t.Fatalf("Failed to write the configuration file: %v", err)
+		}
+	}
+
+	writeConfig(successConfigText)
+	theConfig, err := LoadConfig(nil, fileName)
+	if err != nil {
+		t.Fatalf("LoadConfig returned unexpected error: %v", err)
+	}
+	handle := utils.NewTestHandle()
+	if err := LoadPluginReferences(theConfig.Plugins, handle); err != nil {
+		t.Fatalf("LoadPluginReferences returned unexpected error: %v", err)
+	}
+	applier := &Applier{
+		Director:             requestcontrol.NewDirectorWithConfig(nil, nil, nil, requestcontrol.NewConfig()),
+		RequestControlConfig: requestcontrol.NewConfig(),
+		NewHandle:            func(customMetrics []string) plugins.Handle { return utils.NewTestHandle(customMetrics...) },
+	}
+	applier.SetCurrent(theConfig, handle)
+	reloader := &Reloader{
+		FileName: fileName,
+		Applier:  applier,
+	}
+	reloader.SetCurrent([]byte(successConfigText))
+
+	// Unchanged configuration.
+	if reloaded, err := reloader.Reload(ctx); reloaded || err != nil {
+		t.Fatalf("Expected no reload of an unchanged configuration, got %v and error %v", reloaded, err)
+	}
+
+	// Invalid configuration, the current one is kept.
+	writeConfig(errorNoProfilesText)
+	if reloaded, err := reloader.Reload(ctx); reloaded || err == nil {
+		t.Fatalf("Expected an invalid configuration to be rejected, got %v and error %v", reloaded, err)
+	}
+	if applier.config != theConfig || applier.handle != handle {
+		t.Errorf("Expected the current configuration to be kept after a failed reload")
+	}
+
+	// Valid new configuration, the unchanged plugins are carried over.
+	writeConfig(strings.Replace(successConfigText, "threshold: 10", "threshold: 20", 1))
+	if reloaded, err := reloader.Reload(ctx); !reloaded || err != nil {
+		t.Fatalf("Expected the configuration to be reloaded, got %v and error %v", reloaded, err)
+	}
+	if applier.handle.Plugins().Plugin("testPicker") != handle.Plugins().Plugin("testPicker") {
+		t.Errorf("Expected the unchanged plugin testPicker to be carried over")
+	}
+	if t1, ok := applier.handle.Plugins().Plugin("test1").(*test1); !ok || t1.Threshold != 20 {
+		t.Errorf("Expected test1 to be reinstantiated with a threshold of 20, got %#v", applier.handle.Plugins().Plugin("test1"))
+	}
+}
+
+func TestApplier(t *testing.T) {
+	registerTestPlugins()
+	applier := &Applier{
+		Director:             requestcontrol.NewDirectorWithConfig(nil, nil, nil, requestcontrol.NewConfig()),
+		RequestControlConfig: requestcontrol.NewConfig(),
+		NewHandle:            func(customMetrics []string) plugins.Handle { return utils.NewTestHandle(customMetrics...) },
+	}
+
+	// A configuration object that was not loaded from a file, e.g. an EndpointPickerConfig resource, is defaulted.
+	theConfig := &configapi.EndpointPickerConfig{
+		Plugins: []configapi.PluginSpec{{Type: testProfileHandlerType}, {Type: testPickerType}},
+		SchedulingProfiles: []configapi.SchedulingProfile{
+			{Name: "default", Plugins: []configapi.SchedulingPlugin{{PluginRef: testPickerType}}},
+		},
+	}
+	if _, err := applier.Apply(theConfig); err != nil {
+		t.Fatalf("Apply returned unexpected error: %v", err)
+	}
+	if applier.Current() != theConfig {
+		t.Errorf("Expected the applied configuration to be the current one")
+	}
+
+	// An invalid configuration is rejected and the current one is kept.
+	invalidConfig := &configapi.EndpointPickerConfig{Plugins: []configapi.PluginSpec{{Type: "unknown"}}}
+	if _, err := applier.Apply(invalidConfig); err == nil {
+		t.Fatalf("Expected an invalid configuration to be rejected")
+	}
+	if applier.Current() != theConfig {
+		t.Errorf("Expected the current configuration to be kept after a failed apply")
+	}
+}
+
+func TestApplierSaturationDetector(t *testing.T) {
+	registerTestPlugins()
+	ctx := context.Background()
+	// A pod whose waiting queue is above the threshold of the environment, but below the one of the configuration.
+	pods := &fakeSaturationDatastore{pods: []backendmetrics.PodMetrics{&backendmetrics.FakePodMetrics{
+		Metrics: &backendmetrics.MetricsState{WaitingQueueSize: 10, UpdateTime: time.Now()},
+	}}}
+	envConfig := &saturationdetector.Config{QueueDepthThreshold: 5, KVCacheUtilThreshold: 0.8, MetricsStalenessThreshold: time.Hour}
+	detector := saturationdetector.NewDetector(envConfig, pods, logr.Discard())
+	applier := &Applier{
+		Director:                 requestcontrol.NewDirectorWithConfig(nil, nil, nil, requestcontrol.NewConfig()),
+		RequestControlConfig:     requestcontrol.NewConfig(),
+		NewHandle:                func(customMetrics []string) plugins.Handle { return utils.NewTestHandle(customMetrics...) },
+		SaturationDetector:       detector,
+		SaturationDetectorConfig: envConfig,
+	}
+	theConfig := &configapi.EndpointPickerConfig{
+		Plugins: []configapi.PluginSpec{{Type: testProfileHandlerType}, {Type: testPickerType}},
+		SchedulingProfiles: []configapi.SchedulingProfile{
+			{Name: "default", Plugins: []configapi.SchedulingPlugin{{PluginRef: testPickerType}}},
+		},
+		SaturationDetector: &configapi.SaturationDetectorConfig{QueueDepthThreshold: ptr.To(20)},
+	}
+	if _, err := applier.Apply(theConfig); err != nil {
+		t.Fatalf("Apply returned unexpected error: %v", err)
+	}
+	if detector.IsSaturated(ctx) {
+		t.Error("Expected the queue depth threshold of the configuration to be applied")
+	}
+
+	// Removing the section restores the thresholds of the environment.
+	withoutSection := theConfig.DeepCopy()
+	withoutSection.SaturationDetector = nil
+	if _, err := applier.Apply(withoutSection); err != nil {
+		t.Fatalf("Apply returned unexpected error: %v", err)
+	}
+	if !detector.IsSaturated(ctx) {
+		t.Error("Expected the queue depth threshold of the environment to be restored")
+	}
+}
+
+type fakeSaturationDatastore struct {
+	pods []backendmetrics.PodMetrics
+}
+
+func (ds *fakeSaturationDatastore) PodGetAll() []backendmetrics.PodMetrics {
+	return ds.pods
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+// ConfigApplier applies a new version of the configuration to the running EPP.
+type ConfigApplier interface {
+	// Apply validates and applies the configuration. It returns the names of the plugins that were carried over from
+	// the current configuration.
+	Apply(theConfig *configapi.EndpointPickerConfig) ([]string, error)
+}
+
+// EndpointPickerConfigReconciler applies the EndpointPickerConfig referenced by the InferencePool, and reports in the
+// status of the EndpointPickerConfig whether it was accepted. The EndpointPickerConfigs that are not referenced by the
+// InferencePool are ignored.
+type EndpointPickerConfigReconciler struct {
+	client.Client
+	Record             record.EventRecorder
+	Applier            ConfigApplier
+	PoolNamespacedName types.NamespacedName
+
+	// applied identifies the last version that was applied, whether it was accepted or not, and condition is the
+	// Accepted condition reporting its outcome.
+	applied   appliedConfig
+	condition metav1.Condition
+}
+
+type appliedConfig struct {
+	uid        types.UID
+	generation int64
+}
+
+func (c *EndpointPickerConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).V(logutil.DEFAULT).WithValues("endpointPickerConfig", req.NamespacedName)
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	pool := &v1alpha2.InferencePool{}
+	if err := c.Get(ctx, c.PoolNamespacedName, pool); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to get InferencePool")
+		return ctrl.Result{}, err
+	}
+	if !c.isReferenced(pool, req.NamespacedName) {
+		logger.V(logutil.DEBUG).Info("EndpointPickerConfig is not referenced by the InferencePool, ignoring")
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Reconciling EndpointPickerConfig")
+
+	theConfig := &configapi.EndpointPickerConfig{}
+	if err := c.Get(ctx, req.NamespacedName, theConfig); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("EndpointPickerConfig not found. Keeping the current configuration")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Unable to get EndpointPickerConfig")
+		return ctrl.Result{}, err
+	} else if !theConfig.DeletionTimestamp.IsZero() {
+		logger.Info("EndpointPickerConfig is marked for deletion. Keeping the current configuration")
+		return ctrl.Result{}, nil
+	}
+
+	version := appliedConfig{uid: theConfig.UID, generation: theConfig.Generation}
+	if version != c.applied {
+		// The version is only tried once, a fix of an invalid version is a new generation.
+		c.applied = version
+		c.condition = c.apply(ctx, theConfig)
+	}
+
+	// The status is written separately from the apply, so that a failed write is retried without applying again.
+	if !meta.SetStatusCondition(&theConfig.Status.Conditions, c.condition) {
+		return ctrl.Result{}, nil
+	}
+	if err := c.Status().Update(ctx, theConfig); err != nil {
+		logger.Error(err, "Unable to update the EndpointPickerConfig status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// apply applies the configuration and returns the Accepted condition reporting the outcome.
+func (c *EndpointPickerConfigReconciler) apply(ctx context.Context, theConfig *configapi.EndpointPickerConfig) metav1.Condition {
+	logger := log.FromContext(ctx)
+	condition := metav1.Condition{
+		Type:               string(configapi.EndpointPickerConfigConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		Reason:             string(configapi.EndpointPickerConfigReasonAccepted),
+		Message:            "The configuration was applied",
+		ObservedGeneration: theConfig.Generation,
+	}
+	reused, err := c.Applier.Apply(theConfig.DeepCopy())
+	metrics.RecordConfigReload(err == nil)
+	if err != nil {
+		logger.Error(err, "Failed to apply the EndpointPickerConfig, keeping the current configuration")
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(configapi.EndpointPickerConfigReasonInvalidPlugin)
+		condition.Message = err.Error()
+		c.Record.Eventf(theConfig, corev1.EventTypeWarning, condition.Reason, "Rejected generation %d: %v", theConfig.Generation, err)
+	} else {
+		logger.Info("EndpointPickerConfig applied", "reusedPlugins", reused)
+		c.Record.Eventf(theConfig, corev1.EventTypeNormal, string(configapi.EndpointPickerConfigReasonAccepted), "Applied generation %d", theConfig.Generation)
+	}
+	return condition
+}
+
+func (c *EndpointPickerConfigReconciler) isReferenced(pool *v1alpha2.InferencePool, name types.NamespacedName) bool {
+	ref := pool.Spec.EndpointPickerConfigRef
+	return ref != nil && pool.Namespace == name.Namespace && string(ref.Name) == name.Name
+}
+
+func (c *EndpointPickerConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&configapi.EndpointPickerConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Changing the reference of the InferencePool applies the newly referenced EndpointPickerConfig.
+		Watches(&v1alpha2.InferencePool{}, handler.EnqueueRequestsFromMapFunc(c.referencedConfig),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(c)
+}
+
+func (c *EndpointPickerConfigReconciler) referencedConfig(_ context.Context, obj client.Object) []reconcile.Request {
+	pool, ok := obj.(*v1alpha2.InferencePool)
+	if !ok || pool.Spec.EndpointPickerConfigRef == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: pool.Namespace,
+		Name:      string(pool.Spec.EndpointPickerConfigRef.Name),
+	}}}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	utiltest "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/testing"
+)
+
+type fakeConfigApplier struct {
+	applied []*configapi.EndpointPickerConfig
+	err     error
+}
+
+func (f *fakeConfigApplier) Apply(theConfig *configapi.EndpointPickerConfig) ([]string, error) {
+	f.applied = append(f.applied, theConfig)
+	return nil, f.err
+}
+
+func makeEndpointPickerConfig(name string, generation int64) *configapi.EndpointPickerConfig {
+	return &configapi.EndpointPickerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  "pool1-ns",
+			UID:        types.UID(name),
+			Generation: generation,
+		},
+		Plugins: []configapi.PluginSpec{{Name: "picker", Type: "max-score"}},
+		SchedulingProfiles: []configapi.SchedulingProfile{
+			{Name: "default", Plugins: []configapi.SchedulingPlugin{{PluginRef: "picker"}}},
+		},
+	}
+}
+
+func TestEndpointPickerConfigReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1alpha2.Install(scheme)
+	_ = configapi.Install(scheme)
+
+	pool := utiltest.MakeInferencePool("pool1").Namespace("pool1-ns").EndpointPickerConfigRef("config1").ObjRef()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, makeEndpointPickerConfig("config1", 1), makeEndpointPickerConfig("config2", 1)).
+		WithStatusSubresource(&configapi.EndpointPickerConfig{}).
+		Build()
+
+	ctx := context.Background()
+	applier := &fakeConfigApplier{}
+	recorder := record.NewFakeRecorder(10)
+	reconciler := &EndpointPickerConfigReconciler{
+		Client:             fakeClient,
+		Record:             recorder,
+		Applier:            applier,
+		PoolNamespacedName: types.NamespacedName{Name: "pool1", Namespace: "pool1-ns"},
+	}
+	reconcile := func(name string) {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "pool1-ns"}}
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Unexpected EndpointPickerConfig reconcile error: %v", err)
+		}
+	}
+	getCondition := func(name string) *metav1.Condition {
+		theConfig := &configapi.EndpointPickerConfig{}
+		if err := fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "pool1-ns"}, theConfig); err != nil {
+			t.Fatalf("Unexpected EndpointPickerConfig get error: %v", err)
+		}
+		return meta.FindStatusCondition(theConfig.Status.Conditions, string(configapi.EndpointPickerConfigConditionAccepted))
+	}
+
+	// Step 1: the referenced configuration is applied and accepted.
+	reconcile("config1")
+	if len(applier.applied) != 1 || applier.applied[0].Name != "config1" {
+		t.Fatalf("Expected config1 to be applied, got %v", applier.applied)
+	}
+	if cond := getCondition("config1"); cond == nil || cond.Status != metav1.ConditionTrue ||
+		cond.Reason != string(configapi.EndpointPickerConfigReasonAccepted) || cond.ObservedGeneration != 1 {
+		t.Errorf("Unexpected Accepted condition of config1: %+v", cond)
+	}
+	if event := <-recorder.Events; event != "Normal Accepted Applied generation 1" {
+		t.Errorf("Unexpected event %q", event)
+	}
+
+	// Step 2: a configuration that is not referenced by the pool is ignored.
+	reconcile("config2")
+	if len(applier.applied) != 1 {
+		t.Errorf("Expected config2 not to be applied, got %v", applier.applied)
+	}
+	if cond := getCondition("config2"); cond != nil {
+		t.Errorf("Expected no condition on config2, got %+v", cond)
+	}
+
+	// Step 3: the same generation is not applied again.
+	reconcile("config1")
+	if len(applier.applied) != 1 {
+		t.Errorf("Expected config1 not to be applied again, got %v", applier.applied)
+	}
+
+	// Step 4: an invalid new generation is rejected with the validation error.
+	theConfig := &configapi.EndpointPickerConfig{}
+	if err := fakeClient.Get(ctx, types.NamespacedName{Name: "config1", Namespace: "pool1-ns"}, theConfig); err != nil {
+		t.Fatalf("Unexpected EndpointPickerConfig get error: %v", err)
+	}
+	theConfig.Generation = 2
+	theConfig.Plugins[0].Type = "unknown"
+	if err := fakeClient.Update(ctx, theConfig); err != nil {
+		t.Fatalf("Unexpected EndpointPickerConfig update error: %v", err)
+	}
+	applier.err = errors.New("plugin type unknown is not found")
+	reconcile("config1")
+	if len(applier.applied) != 2 {
+		t.Errorf("Expected the new generation of config1 to be applied, got %v", applier.applied)
+	}
+	if cond := getCondition("config1"); cond == nil || cond.Status != metav1.ConditionFalse ||
+		cond.Reason != string(configapi.EndpointPickerConfigReasonInvalidPlugin) ||
+		cond.Message != applier.err.Error() || cond.ObservedGeneration != 2 {
+		t.Errorf("Unexpected Accepted condition of config1: %+v", cond)
+	}
+	if event := <-recorder.Events; event != "Warning InvalidPlugin Rejected generation 2: plugin type unknown is not found" {
+		t.Errorf("Unexpected event %q", event)
+	}
+
+	// Step 5: changing the reference of the pool applies the newly referenced configuration.
+	pool.Spec.EndpointPickerConfigRef.Name = "config2"
+	if err := fakeClient.Update(ctx, pool); err != nil {
+		t.Fatalf("Unexpected pool update error: %v", err)
+	}
+	applier.err = nil
+	if reqs := reconciler.referencedConfig(ctx, pool); len(reqs) != 1 || reqs[0].Name != "config2" {
+		t.Fatalf("Expected the pool to map to config2, got %v", reqs)
+	}
+	reconcile("config2")
+	if len(applier.applied) != 3 || applier.applied[2].Name != "config2" {
+		t.Errorf("Expected config2 to be applied, got %v", applier.applied)
+	}
+	if cond := getCondition("config2"); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("Unexpected Accepted condition of config2: %+v", cond)
+	}
+}
+
+func TestEndpointPickerConfigReconcilerRetriesStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = v1alpha2.Install(scheme)
+	_ = configapi.Install(scheme)
+
+	failStatusUpdate := true
+	pool := utiltest.MakeInferencePool("pool1").Namespace("pool1-ns").EndpointPickerConfigRef("config1").ObjRef()
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, makeEndpointPickerConfig("config1", 1)).
+		WithStatusSubresource(&configapi.EndpointPickerConfig{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if failStatusUpdate {
+					return errors.New("conflict")
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	ctx := context.Background()
+	applier := &fakeConfigApplier{}
+	reconciler := &EndpointPickerConfigReconciler{
+		Client:             fakeClient,
+		Record:             record.NewFakeRecorder(10),
+		Applier:            applier,
+		PoolNamespacedName: types.NamespacedName{Name: "pool1", Namespace: "pool1-ns"},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "config1", Namespace: "pool1-ns"}}
+	if _, err := reconciler.Reconcile(ctx, req); err == nil {
+		t.Fatal("Expected the failed status update to be returned")
+	}
+
+	// The retry writes the status without applying the configuration again.
+	failStatusUpdate = false
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Unexpected EndpointPickerConfig reconcile error: %v", err)
+	}
+	if len(applier.applied) != 1 {
+		t.Errorf("Expected config1 to be applied once, got %v", applier.applied)
+	}
+	theConfig := &configapi.EndpointPickerConfig{}
+	if err := fakeClient.Get(ctx, req.NamespacedName, theConfig); err != nil {
+		t.Fatalf("Unexpected EndpointPickerConfig get error: %v", err)
+	}
+	if cond := meta.FindStatusCondition(theConfig.Status.Conditions, string(configapi.EndpointPickerConfigConditionAccepted)); cond == nil ||
+		cond.Status != metav1.ConditionTrue || cond.ObservedGeneration != 1 {
+		t.Errorf("Unexpected Accepted condition of config1: %+v", cond)
+	}
+}
@@ -65,6 +65,8 @@ type Datastore interface {
 	PodGetAll() []backendmetrics.PodMetrics
 	// PodList lists pods matching the given predicate.
 	PodList(predicate func(backendmetrics.PodMetrics) bool) []backendmetrics.PodMetrics
+	// PodGet returns the pod with the given name, or nil if it is not in the datastore.
+	PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics
 	PodUpdateOrAddIfNotExist(pod *corev1.Pod) bool
 	PodDelete(namespacedName types.NamespacedName)
 
@@ -262,6 +264,13 @@ func (ds *datastore) PodList(predicate func(backendmetrics.PodMetrics) bool) []b
 	return res
 }
 
+func (ds *datastore) PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics {
+	if pm, ok := ds.pods.Load(namespacedName); ok {
+		return pm.(backendmetrics.PodMetrics)
+	}
+	return nil
+}
+
 func (ds *datastore) PodUpdateOrAddIfNotExist(pod *corev1.Pod) bool {
 	namespacedName := types.NamespacedName{
 		Name:      pod.Name,
@@ -0,0 +1,379 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
+)
+
+const (
+	// streamingTailLimit bounds the number of trailing bytes of a streamed response that are kept in memory
+	// in order to parse the usage reported in the last SSE events.
+	streamingTailLimit = 64 * 1024
+)
+
+// NewHTTPProxyServer creates a new HTTPProxyServer that runs the given director and proxies the requests itself.
+// If transport is nil, http.DefaultTransport is used. If backendTLS is true, the requests are proxied to the model
+// servers over HTTPS. The requests whose body is larger than maxRequestBodyBytes are rejected.
+func NewHTTPProxyServer(datastore Datastore, director Director, transport http.RoundTripper, backendTLS bool,
+	maxRequestBodyBytes int64) *HTTPProxyServer {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	backendScheme := "http"
+	if backendTLS {
+		backendScheme = "https"
+	}
+	return &HTTPProxyServer{
+		datastore:     datastore,
+		director:      director,
+		transport:           transport,
+		backendScheme:       backendScheme,
+		maxRequestBodyBytes: maxRequestBodyBytes,
+	}
+}
+
+// HTTPProxyServer is an OpenAI-compatible HTTP reverse proxy. It runs the same request handling flow as the
+// StreamingServer (admission, scheduling, request-control plugins and metrics), but instead of instructing a
+// gateway where to route the request, it forwards the request to the selected endpoint by itself, or to the fallback
+// endpoints ranked by the picker if the selected endpoint can't be connected to.
+// It is intended for local development and small deployments that do not run a gateway.
+type HTTPProxyServer struct {
+	datastore           Datastore
+	director            Director
+	transport           http.RoundTripper
+	backendScheme       string
+	maxRequestBodyBytes int64
+}
+
+// ServeHTTP handles a single HTTP request end to end.
+func (s *HTTPProxyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := log.FromContext(ctx)
+
+	reqCtx := &RequestContext{
+		RequestReceivedTimestamp: time.Now(),
+		Request: &Request{
+			Headers: make(map[string]string, len(r.Header)),
+			Body:    make(map[string]interface{}),
+		},
+		Response: &Response{
+			Headers: make(map[string]string),
+		},
+	}
+	// Header keys are lower cased to match the representation used by Envoy.
+	for key, values := range r.Header {
+		if len(values) > 0 {
+			reqCtx.Request.Headers[strings.ToLower(key)] = values[0]
+		}
+	}
+	if requestId := reqCtx.Request.Headers[requtil.RequestIdHeaderKey]; len(requestId) > 0 {
+		logger = logger.WithValues(requtil.RequestIdHeaderKey, requestId)
+		ctx = log.IntoContext(ctx, logger)
+	}
+	// Continue the trace of the caller if the request carries a W3C trace context.
+	ctx, reqCtx.requestSpan = tracing.Tracer().Start(tracing.Extract(ctx, reqCtx.Request.Headers), "Request", trace.WithSpanKind(trace.SpanKindServer))
+
+	var err error
+	defer func() {
+		if reqCtx.ResponseStatusCode != "" {
+			metrics.RecordRequestErrCounter(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseStatusCode)
+		} else if err != nil {
+			metrics.RecordRequestErrCounter(reqCtx.Model, reqCtx.ResolvedTargetModel, errutil.CanonicalCode(err))
+		}
+		if reqCtx.RequestRunning {
+			metrics.DecRunningRequests(reqCtx.Model)
+		}
+		reqCtx.endSpans(err)
+	}()
+
+	var requestBodyBytes []byte
+	requestBodyBytes, err = io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxRequestBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = errutil.Error{Code: errutil.BadRequest, Msg: "request body larger than " + strconv.FormatInt(maxBytesErr.Limit, 10) + " bytes"}
+			logger.V(logutil.DEFAULT).Error(err, "Failed to process request", "status", http.StatusRequestEntityTooLarge)
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		err = errutil.Error{Code: errutil.BadRequest, Msg: "failed to read request body: " + err.Error()}
+		writeHTTPError(ctx, w, err)
+		return
+	}
+
+	if len(requestBodyBytes) == 0 {
+		// Same as in the ext-proc path, a request without a body is assumed to be a GET and is routed to a random pod.
+		if err = s.setRandomTargetEndpoint(reqCtx); err != nil {
+			writeHTTPError(ctx, w, err)
+			return
+		}
+	} else {
+		if err = json.Unmarshal(requestBodyBytes, &reqCtx.Request.Body); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Error unmarshaling request body")
+			err = errutil.Error{Code: errutil.BadRequest, Msg: "Error unmarshaling request body: " + string(requestBodyBytes)}
+			writeHTTPError(ctx, w, err)
+			return
+		}
+
+		reqCtx, err = s.director.HandleRequest(ctx, reqCtx)
+		if err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Error handling request")
+			writeHTTPError(ctx, w, err)
+			return
+		}
+
+		// The director may have mutated the body (e.g. the target model after traffic split).
+		requestBodyBytes, err = json.Marshal(reqCtx.Request.Body)
+		if err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Error marshalling request body")
+			err = errutil.Error{Code: errutil.Internal, Msg: "failed to marshal request body: " + err.Error()}
+			writeHTTPError(ctx, w, err)
+			return
+		}
+		reqCtx.RequestSize = len(requestBodyBytes)
+
+		metrics.RecordRequestCounter(reqCtx.Model, reqCtx.ResolvedTargetModel)
+		metrics.RecordRequestSizes(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestSize)
+		metrics.IncRunningRequests(reqCtx.Model)
+		reqCtx.RequestRunning = true
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Transport: &fallbackTransport{transport: s.transport, reqCtx: reqCtx},
+		// Flush immediately so that SSE events are passed through as soon as the model server emits them.
+		FlushInterval: -1,
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			pr.Out.URL.Scheme = s.backendScheme
+			pr.Out.URL.Host = reqCtx.TargetEndpoint
+			pr.Out.Host = reqCtx.TargetEndpoint
+			pr.Out.Body = io.NopCloser(bytes.NewReader(requestBodyBytes))
+			pr.Out.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(requestBodyBytes)), nil
+			}
+			pr.Out.ContentLength = int64(len(requestBodyBytes))
+			pr.Out.Header.Set("Content-Length", strconv.Itoa(len(requestBodyBytes)))
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			return s.handleResponse(ctx, reqCtx, resp)
+		},
+		ErrorHandler: func(rw http.ResponseWriter, _ *http.Request, proxyErr error) {
+			logger.V(logutil.DEFAULT).Error(proxyErr, "Failed to proxy request", "endpoint", reqCtx.TargetEndpoint)
+			reqCtx.ResponseStatusCode = errutil.ModelServerError
+			rw.WriteHeader(http.StatusBadGateway)
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// fallbackTransport proxies the request to its target endpoint, and to its fallback endpoints in turn as long as the
+// connection to the endpoint can't be established. The request isn't retried once it was sent to a model server.
+type fallbackTransport struct {
+	transport http.RoundTripper
+	reqCtx    *RequestContext
+}
+
+func (t *fallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	for _, endpoint := range t.reqCtx.FallbackEndpoints {
+		var opErr *net.OpError
+		if err == nil || !errors.As(err, &opErr) || opErr.Op != "dial" {
+			break
+		}
+		log.FromContext(req.Context()).V(logutil.DEFAULT).Info("Failed to connect to the endpoint, trying the next fallback endpoint",
+			"endpoint", t.reqCtx.TargetEndpoint, "fallbackEndpoint", endpoint, "err", err)
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return nil, bodyErr
+		}
+		req = req.Clone(req.Context())
+		req.URL.Host = endpoint
+		req.Host = endpoint
+		req.Body = body
+		t.reqCtx.TargetEndpoint = endpoint
+		resp, err = t.transport.RoundTrip(req)
+	}
+	return resp, err
+}
+
+// setRandomTargetEndpoint sets a random pod from the datastore as the target endpoint of the request.
+func (s *HTTPProxyServer) setRandomTargetEndpoint(reqCtx *RequestContext) error {
+	pod := s.director.GetRandomPod()
+	if pod == nil {
+		return errutil.Error{Code: errutil.Internal, Msg: "no pods available in datastore"}
+	}
+	pool, err := s.datastore.PoolGet()
+	if err != nil {
+		return err
+	}
+	reqCtx.TargetPod = pod
+	reqCtx.TargetEndpoint = pod.Address + ":" + strconv.Itoa(int(pool.Spec.TargetPortNumber))
+	return nil
+}
+
+// handleResponse runs the response handling flow on the upstream response. For non-streaming responses the body is
+// buffered in order to extract the usage, streaming responses are passed through and inspected on the fly.
+func (s *HTTPProxyServer) handleResponse(ctx context.Context, reqCtx *RequestContext, resp *http.Response) error {
+	logger := log.FromContext(ctx)
+	reqCtx.RequestState = ResponseRecieved
+
+	for key, values := range resp.Header {
+		if len(values) > 0 {
+			reqCtx.Response.Headers[strings.ToLower(key)] = values[0]
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		reqCtx.ResponseStatusCode = errutil.ModelServerError
+	}
+	if strings.Contains(resp.Header.Get("Content-Type"), "text/event-stream") {
+		reqCtx.modelServerStreaming = true
+	}
+
+	var responseCtx context.Context
+	responseCtx, reqCtx.responseSpan = tracing.Tracer().Start(ctx, "Response")
+	if reqCtx.SchedulingRequest != nil {
+		var err error
+		if reqCtx, err = s.director.HandleResponse(responseCtx, reqCtx); err != nil {
+			logger.V(logutil.DEFAULT).Error(err, "Failed to process response headers")
+		}
+		// Apply the headers set while handling the response, as the ext-proc server does.
+		for key, value := range reqCtx.Response.Headers {
+			if resp.Header.Get(key) != value {
+				resp.Header.Set(key, value)
+			}
+		}
+	}
+
+	if reqCtx.modelServerStreaming {
+		resp.Body = &streamedResponseBody{ctx: ctx, reqCtx: reqCtx, body: resp.Body}
+		return nil
+	}
+
+	responseBytes, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBytes))
+
+	reqCtx.ResponseSize = len(responseBytes)
+	reqCtx.ResponseComplete = true
+	reqCtx.ResponseCompleteTimestamp = time.Now()
+	response := ResponseBody{}
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		// Don't fail on a response error, just let the message passthrough, as in the ext-proc path.
+		logger.V(logutil.DEFAULT).Error(err, "Error unmarshaling response body", "body", string(responseBytes))
+	} else {
+		reqCtx.Usage = response.Usage
+	}
+
+	if reqCtx.SchedulingRequest != nil {
+		metrics.RecordRequestLatencies(ctx, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestReceivedTimestamp, reqCtx.ResponseCompleteTimestamp)
+		metrics.RecordResponseSizes(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseSize)
+		metrics.RecordInputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Usage.PromptTokens)
+		metrics.RecordOutputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Usage.CompletionTokens)
+	}
+	reqCtx.endResponseSpan()
+	return nil
+}
+
+// streamedResponseBody passes a streamed response through while keeping the tail of the stream, so the usage can be
+// parsed from the last events once the stream is completed.
+type streamedResponseBody struct {
+	ctx      context.Context
+	reqCtx   *RequestContext
+	body     io.ReadCloser
+	tail     []byte
+	finished bool
+}
+
+func (b *streamedResponseBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if n > 0 {
+		b.reqCtx.ResponseSize += n
+		b.tail = append(b.tail, p[:n]...)
+		if len(b.tail) > streamingTailLimit {
+			b.tail = b.tail[len(b.tail)-streamingTailLimit:]
+		}
+	}
+	if errors.Is(err, io.EOF) {
+		b.finish()
+	}
+	return n, err
+}
+
+// Close completes the response, if the stream was closed before it was read to the end, and closes the stream.
+func (b *streamedResponseBody) Close() error {
+	b.finish()
+	return b.body.Close()
+}
+
+// finish records the completion of the response. It is idempotent.
+func (b *streamedResponseBody) finish() {
+	if b.finished {
+		return
+	}
+	b.finished = true
+	reqCtx := b.reqCtx
+	reqCtx.ResponseComplete = true
+	reqCtx.ResponseCompleteTimestamp = time.Now()
+	if strings.Contains(string(b.tail), streamingEndMsg) {
+		reqCtx.Usage = parseRespForUsage(b.ctx, string(b.tail)).Usage
+	}
+	if reqCtx.SchedulingRequest != nil {
+		metrics.RecordRequestLatencies(b.ctx, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestReceivedTimestamp, reqCtx.ResponseCompleteTimestamp)
+		metrics.RecordResponseSizes(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseSize)
+		metrics.RecordInputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Usage.PromptTokens)
+		metrics.RecordOutputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Usage.CompletionTokens)
+	}
+	reqCtx.endResponseSpan()
+}
+
+// writeHTTPError writes the given error as an immediate HTTP response, using the same status codes as BuildErrResponse.
+func writeHTTPError(ctx context.Context, w http.ResponseWriter, err error) {
+	var code int
+	switch errutil.CanonicalCode(err) {
+	case errutil.InferencePoolResourceExhausted:
+		code = http.StatusTooManyRequests
+	case errutil.BadRequest:
+		code = http.StatusBadRequest
+	case errutil.BadConfiguration:
+		code = http.StatusNotFound
+	default:
+		code = http.StatusInternalServerError
+	}
+	log.FromContext(ctx).V(logutil.DEFAULT).Error(err, "Failed to process request", "status", code)
+	http.Error(w, err.Error(), code)
+}
@@ -0,0 +1,311 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
+)
+
+// testMaxRequestBodyBytes is the maximum size of the body of the requests of the test proxies.
+const testMaxRequestBodyBytes = 1024
+
+type fakeDirector struct {
+	endpoint          string
+	fallbackEndpoints []string
+	err               error
+	responseSeen      *RequestContext
+}
+
+func (d *fakeDirector) HandleRequest(_ context.Context, reqCtx *RequestContext) (*RequestContext, error) {
+	if d.err != nil {
+		return reqCtx, d.err
+	}
+	reqCtx.Model = reqCtx.Request.Body["model"].(string)
+	reqCtx.ResolvedTargetModel = "resolved-" + reqCtx.Model
+	reqCtx.Request.Body["model"] = reqCtx.ResolvedTargetModel
+	reqCtx.SchedulingRequest = &schedulingtypes.LLMRequest{TargetModel: reqCtx.ResolvedTargetModel}
+	reqCtx.TargetEndpoint = d.endpoint
+	reqCtx.FallbackEndpoints = d.fallbackEndpoints
+	return reqCtx, nil
+}
+
+func (d *fakeDirector) HandleResponse(_ context.Context, reqCtx *RequestContext) (*RequestContext, error) {
+	d.responseSeen = reqCtx
+	return reqCtx, nil
+}
+
+func (d *fakeDirector) GetRandomPod() *backend.Pod {
+	host, _, _ := strings.Cut(d.endpoint, ":")
+	return &backend.Pod{Address: host}
+}
+
+type fakeProxyDatastore struct {
+	port int32
+}
+
+func (ds *fakeProxyDatastore) PoolGet() (*v1alpha2.InferencePool, error) {
+	return &v1alpha2.InferencePool{Spec: v1alpha2.InferencePoolSpec{TargetPortNumber: ds.port}}, nil
+}
+
+func TestHTTPProxyServer(t *testing.T) {
+	tests := []struct {
+		name             string
+		requestBody      string
+		directorErr      error
+		upstreamHeaders  map[string]string
+		upstreamBody     string
+		wantStatus       int
+		wantUpstreamBody map[string]any
+		wantResponseBody string
+		wantUsage        Usage
+	}{
+		{
+			name:             "non-streaming request is proxied with the mutated body",
+			requestBody:      `{"model":"food-review","prompt":"hello"}`,
+			upstreamHeaders:  map[string]string{"Content-Type": "application/json"},
+			upstreamBody:     body,
+			wantStatus:       http.StatusOK,
+			wantUpstreamBody: map[string]any{"model": "resolved-food-review", "prompt": "hello"},
+			wantResponseBody: body,
+			wantUsage:        Usage{PromptTokens: 11, TotalTokens: 111, CompletionTokens: 100},
+		},
+		{
+			name:             "streaming response is passed through",
+			requestBody:      `{"model":"food-review","prompt":"hello","stream":true}`,
+			upstreamHeaders:  map[string]string{"Content-Type": "text/event-stream"},
+			upstreamBody:     streamingBodyWithUsage,
+			wantStatus:       http.StatusOK,
+			wantUpstreamBody: map[string]any{"model": "resolved-food-review", "prompt": "hello", "stream": true},
+			wantResponseBody: streamingBodyWithUsage,
+			wantUsage:        Usage{PromptTokens: 7, TotalTokens: 17, CompletionTokens: 10},
+		},
+		{
+			name:        "invalid json is rejected",
+			requestBody: `{"model":`,
+			wantStatus:  http.StatusBadRequest,
+		},
+		{
+			name:        "too large body is rejected",
+			requestBody: `{"model":"food-review","prompt":"` + strings.Repeat("a", testMaxRequestBodyBytes) + `"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "saturated pool is rejected",
+			requestBody: `{"model":"food-review","prompt":"hello"}`,
+			directorErr: errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: "saturated"},
+			wantStatus:  http.StatusTooManyRequests,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotUpstreamBody map[string]any
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v1/completions" {
+					t.Errorf("Unexpected upstream path: %s", r.URL.Path)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&gotUpstreamBody); err != nil {
+					t.Errorf("Failed to decode upstream request body: %v", err)
+				}
+				for key, value := range test.upstreamHeaders {
+					w.Header().Set(key, value)
+				}
+				_, _ = io.WriteString(w, test.upstreamBody)
+			}))
+			defer upstream.Close()
+
+			director := &fakeDirector{endpoint: strings.TrimPrefix(upstream.URL, "http://"), err: test.directorErr}
+			proxy := httptest.NewServer(NewHTTPProxyServer(&fakeProxyDatastore{}, director, nil, false, testMaxRequestBodyBytes))
+			defer proxy.Close()
+
+			resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(test.requestBody))
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+			gotResponseBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response: %v", err)
+			}
+
+			if resp.StatusCode != test.wantStatus {
+				t.Fatalf("Unexpected status code, want %d, got %d: %s", test.wantStatus, resp.StatusCode, gotResponseBody)
+			}
+			if test.wantStatus != http.StatusOK {
+				return
+			}
+			if diff := cmp.Diff(test.wantUpstreamBody, gotUpstreamBody); diff != "" {
+				t.Errorf("Unexpected upstream body (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(test.wantResponseBody, string(gotResponseBody)); diff != "" {
+				t.Errorf("Unexpected response body (-want +got): %s", diff)
+			}
+			if director.responseSeen == nil {
+				t.Fatalf("Expected the director to handle the response")
+			}
+			if diff := cmp.Diff(test.wantUsage, director.responseSeen.Usage); diff != "" {
+				t.Errorf("Unexpected usage (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestHTTPProxyServerWithoutBody(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"data":[]}`)
+	}))
+	defer upstream.Close()
+
+	director := &fakeDirector{endpoint: strings.TrimPrefix(upstream.URL, "http://")}
+	_, port, _ := strings.Cut(director.endpoint, ":")
+	portNumber, err := strconv.ParseInt(port, 10, 32)
+	if err != nil {
+		t.Fatalf("Failed to parse upstream port: %v", err)
+	}
+	proxy := httptest.NewServer(NewHTTPProxyServer(&fakeProxyDatastore{port: int32(portNumber)}, director, nil, false, testMaxRequestBodyBytes))
+	defer proxy.Close()
+
+	resp, err := http.Get(proxy.URL + "/v1/models")
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status code, want %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+}
+
+func TestHTTPProxyServerTLSBackend(t *testing.T) {
+	upstream := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}`)
+	}))
+	defer upstream.Close()
+
+	director := &fakeDirector{endpoint: strings.TrimPrefix(upstream.URL, "https://")}
+	proxy := httptest.NewServer(NewHTTPProxyServer(&fakeProxyDatastore{}, director, upstream.Client().Transport, true, testMaxRequestBodyBytes))
+	defer proxy.Close()
+
+	resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(`{"model":"food-review","prompt":"hello"}`))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status code, want %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if diff := cmp.Diff(Usage{PromptTokens: 1, CompletionTokens: 2, TotalTokens: 3}, director.responseSeen.Usage); diff != "" {
+		t.Errorf("Unexpected usage (-want +got): %s", diff)
+	}
+}
+
+func TestHTTPProxyServerFallbackEndpoints(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			t.Errorf("Failed to read upstream request body: %v", err)
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	defer upstream.Close()
+	// An endpoint nothing listens on anymore.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	unreachable := listener.Addr().String()
+	_ = listener.Close()
+
+	tests := []struct {
+		name              string
+		fallbackEndpoints []string
+		wantStatus        int
+		wantEndpoint      string
+	}{
+		{
+			name:       "no fallback endpoints",
+			wantStatus: http.StatusBadGateway,
+		},
+		{
+			name:              "fallback endpoint",
+			fallbackEndpoints: []string{unreachable, strings.TrimPrefix(upstream.URL, "http://")},
+			wantStatus:        http.StatusOK,
+			wantEndpoint:      strings.TrimPrefix(upstream.URL, "http://"),
+		},
+		{
+			name:              "unreachable fallback endpoints",
+			fallbackEndpoints: []string{unreachable},
+			wantStatus:        http.StatusBadGateway,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			director := &fakeDirector{endpoint: unreachable, fallbackEndpoints: test.fallbackEndpoints}
+			proxy := httptest.NewServer(NewHTTPProxyServer(&fakeProxyDatastore{}, director, nil, false, testMaxRequestBodyBytes))
+			defer proxy.Close()
+
+			resp, err := http.Post(proxy.URL+"/v1/completions", "application/json", strings.NewReader(`{"model":"food-review","prompt":"hello"}`))
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != test.wantStatus {
+				t.Fatalf("Unexpected status code, want %d, got %d", test.wantStatus, resp.StatusCode)
+			}
+			if test.wantEndpoint != "" && director.responseSeen.TargetEndpoint != test.wantEndpoint {
+				t.Errorf("Unexpected target endpoint, want %s, got %s", test.wantEndpoint, director.responseSeen.TargetEndpoint)
+			}
+		})
+	}
+}
+
+func TestStreamedResponseBodyClose(t *testing.T) {
+	reqCtx := &RequestContext{}
+	body := &streamedResponseBody{ctx: context.Background(), reqCtx: reqCtx, body: io.NopCloser(strings.NewReader("data: {}\n\ndata: {}\n\n"))}
+
+	// The client goes away before the end of the stream.
+	if _, err := body.Read(make([]byte, 4)); err != nil {
+		t.Fatalf("Failed to read the stream: %v", err)
+	}
+	if err := body.Close(); err != nil {
+		t.Fatalf("Failed to close the stream: %v", err)
+	}
+	if !reqCtx.ResponseComplete {
+		t.Error("Expected the response to be completed when the stream is closed")
+	}
+	completed := reqCtx.ResponseCompleteTimestamp
+
+	// Closing again doesn't complete the response twice.
+	if err := body.Close(); err != nil {
+		t.Fatalf("Failed to close the stream: %v", err)
+	}
+	if reqCtx.ResponseCompleteTimestamp != completed {
+		t.Error("Expected the response to be completed once")
+	}
+}
@@ -18,18 +18,33 @@ package handlers
 
 import (
 	"context"
+	"maps"
 	"strconv"
+	"strings"
 	"time"
 
 	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/structpb"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 )
 
 func (s *StreamingServer) HandleRequestHeaders(ctx context.Context, reqCtx *RequestContext, req *extProcPb.ProcessingRequest_RequestHeaders) error {
 	reqCtx.RequestReceivedTimestamp = time.Now()
 
+	headers := make(map[string]string, len(req.RequestHeaders.Headers.GetHeaders()))
+	for _, header := range req.RequestHeaders.Headers.GetHeaders() {
+		if header.RawValue != nil {
+			headers[header.Key] = string(header.RawValue)
+		} else {
+			headers[header.Key] = header.Value
+		}
+	}
+	// Continue the trace of the caller if the request carries a W3C trace context.
+	_, reqCtx.requestSpan = tracing.Tracer().Start(tracing.Extract(ctx, headers), "Request", trace.WithSpanKind(trace.SpanKindServer))
+
 	// an EoS in the request headers means this request has no body or trailers.
 	if req.RequestHeaders.EndOfStream {
 		// We will route this request to a random pod as this is assumed to just be a GET
@@ -50,13 +65,7 @@ func (s *StreamingServer) HandleRequestHeaders(ctx context.Context, reqCtx *Requ
 		return nil
 	}
 
-	for _, header := range req.RequestHeaders.Headers.Headers {
-		if header.RawValue != nil {
-			reqCtx.Request.Headers[header.Key] = string(header.RawValue)
-		} else {
-			reqCtx.Request.Headers[header.Key] = header.Value
-		}
-	}
+	maps.Copy(reqCtx.Request.Headers, headers)
 	return nil
 }
 
@@ -91,17 +100,23 @@ func (s *StreamingServer) generateRequestHeaderResponse(reqCtx *RequestContext)
 				},
 			},
 		},
-		DynamicMetadata: s.generateMetadata(reqCtx.TargetEndpoint),
+		DynamicMetadata: s.generateMetadata(destinationEndpoints(reqCtx)),
 	}
 }
 
+// destinationEndpoints returns the target endpoint of the request followed by its fallback endpoints, in the
+// comma-separated format of the endpoint picker protocol, so that the proxy can go down the list on retries.
+func destinationEndpoints(reqCtx *RequestContext) string {
+	return strings.Join(append([]string{reqCtx.TargetEndpoint}, reqCtx.FallbackEndpoints...), ",")
+}
+
 func (s *StreamingServer) generateHeaders(reqCtx *RequestContext) []*configPb.HeaderValueOption {
 	// can likely refactor these two bespoke headers to be updated in PostDispatch, to centralize logic.
 	headers := []*configPb.HeaderValueOption{
 		{
 			Header: &configPb.HeaderValue{
 				Key:      s.destinationEndpointHintKey,
-				RawValue: []byte(reqCtx.TargetEndpoint),
+				RawValue: []byte(destinationEndpoints(reqCtx)),
 			},
 		},
 	}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	configPb "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestHandleRequestHeadersTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	server := NewStreamingServer("", "", nil, nil)
+	reqCtx := &RequestContext{Request: &Request{Headers: map[string]string{}}}
+	err := server.HandleRequestHeaders(context.Background(), reqCtx, &extProcPb.ProcessingRequest_RequestHeaders{
+		RequestHeaders: &extProcPb.HttpHeaders{
+			Headers: &configPb.HeaderMap{
+				Headers: []*configPb.HeaderValue{
+					{Key: "traceparent", RawValue: []byte("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")},
+					{Key: "content-type", Value: "application/json"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reqCtx.Request.Headers["content-type"] != "application/json" {
+		t.Errorf("Expected the request headers to be recorded, got %v", reqCtx.Request.Headers)
+	}
+	reqCtx.endSpans(nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected a single span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "Request" || span.SpanKind != trace.SpanKindServer {
+		t.Errorf("Unexpected span %s of kind %v", span.Name, span.SpanKind)
+	}
+	if span.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("Expected the span to be a child of the traceparent, got trace ID %s and parent %s", span.SpanContext.TraceID(), span.Parent.SpanID())
+	}
+}
+
+func TestDestinationEndpoints(t *testing.T) {
+	const key = "x-gateway-destination-endpoint"
+	server := NewStreamingServer("", key, nil, nil)
+
+	tests := []struct {
+		name              string
+		fallbackEndpoints []string
+		want              string
+	}{
+		{name: "target endpoint only", want: "10.0.0.1:8000"},
+		{name: "fallback endpoints", fallbackEndpoints: []string{"10.0.0.2:8000", "10.0.0.3:8000"}, want: "10.0.0.1:8000,10.0.0.2:8000,10.0.0.3:8000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reqCtx := &RequestContext{
+				TargetEndpoint:    "10.0.0.1:8000",
+				FallbackEndpoints: test.fallbackEndpoints,
+				Request:           &Request{Headers: map[string]string{}},
+			}
+			headers := server.generateHeaders(reqCtx)
+			if headers[0].Header.Key != key || string(headers[0].Header.RawValue) != test.want {
+				t.Errorf("Unexpected destination header %s: %s, want %s", headers[0].Header.Key, headers[0].Header.RawValue, test.want)
+			}
+			metadata := server.generateMetadata(destinationEndpoints(reqCtx))
+			if got := metadata.Fields[key].GetStringValue(); got != test.want {
+				t.Errorf("Unexpected destination metadata %s, want %s", got, test.want)
+			}
+		})
+	}
+}
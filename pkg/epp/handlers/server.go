@@ -26,6 +26,8 @@ import (
 	extProcPb "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
 	envoyTypePb "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/go-logr/logr"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -33,6 +35,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
@@ -82,6 +85,7 @@ type StreamingServer struct {
 type RequestContext struct {
 	TargetPod                 *backend.Pod
 	TargetEndpoint            string
+	FallbackEndpoints         []string
 	Model                     string
 	ResolvedTargetModel       string
 	RequestReceivedTimestamp  time.Time
@@ -95,12 +99,21 @@ type RequestContext struct {
 	Request                   *Request
 
 	SchedulingRequest *schedulingtypes.LLMRequest
+	// SchedulingTrace is the scheduling decision trace to return in the response headers, if requested.
+	SchedulingTrace *schedulingtypes.DecisionTrace
+	// Pipeline is the scheduler and plugins the Director handled the request with, so that the response is handled
+	// by the same plugins even if the configuration was reloaded meanwhile. It is opaque to the handlers.
+	Pipeline any
 
 	RequestState         StreamRequestState
 	modelServerStreaming bool
 
 	Response *Response
 
+	// requestSpan traces the whole lifetime of the request, responseSpan traces the response phase.
+	requestSpan  trace.Span
+	responseSpan trace.Span
+
 	reqHeaderResp  *extProcPb.ProcessingResponse
 	reqBodyResp    []*extProcPb.ProcessingResponse
 	reqTrailerResp *extProcPb.ProcessingResponse
@@ -166,6 +179,9 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			metrics.DecRunningRequests(reqCtx.Model)
 		}
 	}(err, reqCtx)
+	defer func() {
+		reqCtx.endSpans(err)
+	}()
 
 	for {
 		select {
@@ -193,6 +209,7 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 				ctx = log.IntoContext(ctx, logger)
 			}
 			err = s.HandleRequestHeaders(ctx, reqCtx, v)
+			ctx = trace.ContextWithSpan(ctx, reqCtx.requestSpan)
 		case *extProcPb.ProcessingRequest_RequestBody:
 			loggerTrace.Info("Incoming body chunk", "EoS", v.RequestBody.EndOfStream)
 			// In the stream case, we can receive multiple request bodies.
@@ -246,8 +263,10 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 			}
 			reqCtx.RequestState = ResponseRecieved
 
+			var responseCtx context.Context
+			responseCtx, reqCtx.responseSpan = tracing.Tracer().Start(ctx, "Response")
 			var responseErr error
-			reqCtx, responseErr = s.HandleResponseHeaders(ctx, reqCtx, v)
+			reqCtx, responseErr = s.HandleResponseHeaders(responseCtx, reqCtx, v)
 			if responseErr != nil {
 				logger.V(logutil.DEFAULT).Error(responseErr, "Failed to process response headers", "request", req)
 			}
@@ -265,6 +284,7 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 					reqCtx.ResponseCompleteTimestamp = time.Now()
 					metrics.RecordRequestLatencies(ctx, reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.RequestReceivedTimestamp, reqCtx.ResponseCompleteTimestamp)
 					metrics.RecordResponseSizes(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.ResponseSize)
+					reqCtx.endResponseSpan()
 				}
 
 				reqCtx.respBodyResp = generateResponseBodyResponses(v.ResponseBody.Body, v.ResponseBody.EndOfStream)
@@ -295,6 +315,7 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 						metrics.RecordInputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Usage.PromptTokens)
 						metrics.RecordOutputTokens(reqCtx.Model, reqCtx.ResolvedTargetModel, reqCtx.Usage.CompletionTokens)
 					}
+					reqCtx.endResponseSpan()
 				}
 			}
 		case *extProcPb.ProcessingRequest_ResponseTrailers:
@@ -321,6 +342,28 @@ func (s *StreamingServer) Process(srv extProcPb.ExternalProcessor_ProcessServer)
 	}
 }
 
+// endResponseSpan ends the span of the response phase, if any.
+func (r *RequestContext) endResponseSpan() {
+	if r.responseSpan != nil {
+		r.responseSpan.SetAttributes(tracing.ResponseSizeKey.Int(r.ResponseSize),
+			tracing.PromptTokensKey.Int(r.Usage.PromptTokens), tracing.OutputTokensKey.Int(r.Usage.CompletionTokens))
+		r.responseSpan.End()
+		r.responseSpan = nil
+	}
+}
+
+// endSpans ends the spans of the request that are still open, e.g. if the stream was interrupted.
+func (r *RequestContext) endSpans(err error) {
+	r.endResponseSpan()
+	if r.requestSpan != nil {
+		if r.ResponseStatusCode != "" {
+			r.requestSpan.SetStatus(otelcodes.Error, r.ResponseStatusCode)
+		}
+		tracing.EndSpan(r.requestSpan, err)
+		r.requestSpan = nil
+	}
+}
+
 // updateStateAndSendIfNeeded checks state and can send mutiple responses in a single pass, but only if ordered properly.
 // Order of requests matter in FULL_DUPLEX_STREAMING. For both request and response, the order of response sent back MUST be: Header->Body->Trailer, with trailer being optional.
 func (r *RequestContext) updateStateAndSendIfNeeded(srv extProcPb.ExternalProcessor_ProcessServer, logger logr.Logger) error {
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadreport updates the metrics of the pods with the load reported by their model servers, either pushed to
+// an endpoint in lieu of having their metrics scraped, or attached to their responses as ORCA load reports.
+package loadreport
+
+import (
+	"maps"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+)
+
+// LoadReport is the load of a pod, pushed by its model server or a sidecar. The fields that are omitted keep their
+// previous values.
+type LoadReport struct {
+	// Namespace is the namespace of the pod, the namespace of the InferencePool if empty.
+	Namespace string `json:"namespace,omitempty"`
+	// Pod is the name of the pod.
+	Pod string `json:"pod"`
+
+	WaitingQueueSize        *int     `json:"waitingQueueSize,omitempty"`
+	RunningQueueSize        *int     `json:"runningQueueSize,omitempty"`
+	KVCacheUsagePercent     *float64 `json:"kvCacheUsagePercent,omitempty"`
+	KvCacheMaxTokenCapacity *int     `json:"kvCacheMaxTokenCapacity,omitempty"`
+	// ActiveModels is the list of the LoRA adapters loaded on GPU, and WaitingModels the list of the adapters
+	// requested by the waiting requests. They are kept if null, while an empty list clears them.
+	ActiveModels    []string `json:"activeModels"`
+	WaitingModels   []string `json:"waitingModels"`
+	MaxActiveModels *int     `json:"maxActiveModels,omitempty"`
+	// Custom holds the custom metrics declared in the configuration, by name. The reported metrics are merged into
+	// the previous ones.
+	Custom map[string]float64 `json:"custom,omitempty"`
+}
+
+// Apply returns the metrics updated by the report.
+func (r *LoadReport) Apply(existing *backendmetrics.MetricsState) *backendmetrics.MetricsState {
+	updated := existing.Clone()
+	if r.WaitingQueueSize != nil {
+		updated.WaitingQueueSize = *r.WaitingQueueSize
+	}
+	if r.RunningQueueSize != nil {
+		updated.RunningQueueSize = *r.RunningQueueSize
+	}
+	if r.KVCacheUsagePercent != nil {
+		updated.KVCacheUsagePercent = *r.KVCacheUsagePercent
+	}
+	if r.KvCacheMaxTokenCapacity != nil {
+		updated.KvCacheMaxTokenCapacity = *r.KvCacheMaxTokenCapacity
+	}
+	if r.ActiveModels != nil {
+		updated.ActiveModels = modelSet(r.ActiveModels)
+	}
+	if r.WaitingModels != nil {
+		updated.WaitingModels = modelSet(r.WaitingModels)
+	}
+	if r.MaxActiveModels != nil {
+		updated.MaxActiveModels = *r.MaxActiveModels
+	}
+	if len(r.Custom) > 0 {
+		if updated.Custom == nil {
+			updated.Custom = make(map[string]float64, len(r.Custom))
+		}
+		maps.Copy(updated.Custom, r.Custom)
+	}
+	return updated
+}
+
+// modelSet returns the models in the format of the MetricsState, where the values are unused.
+func modelSet(models []string) map[string]int {
+	set := make(map[string]int, len(models))
+	for _, model := range models {
+		set[model] = 0
+	}
+	return set
+}
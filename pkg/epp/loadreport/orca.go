@@ -0,0 +1,208 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadreport
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// OrcaHeaderKey is the response header carrying the ORCA load report of the model server, in the TEXT, JSON or
+	// BIN format.
+	OrcaHeaderKey = "endpoint-load-metrics"
+	// OrcaBinaryHeaderKey is the response header carrying the ORCA load report as a base64 serialized protobuf.
+	OrcaBinaryHeaderKey = "endpoint-load-metrics-bin"
+
+	// DefaultOrcaMapping maps the named metrics reported by vLLM to the metrics of the pods.
+	DefaultOrcaMapping = "waitingQueueSize=named_metrics.num_requests_waiting," +
+		"runningQueueSize=named_metrics.num_requests_running," +
+		"kvCacheUsagePercent=named_metrics.kv_cache_usage_perc"
+
+	customFieldPrefix = "custom."
+)
+
+// orcaFields are the fields of the load reports the ORCA metrics map to.
+var orcaFields = []string{"waitingQueueSize", "runningQueueSize", "kvCacheUsagePercent", "kvCacheMaxTokenCapacity", "maxActiveModels"}
+
+// OrcaMapping maps the fields of the load reports, e.g. waitingQueueSize or custom.<name>, to the ORCA metrics, e.g.
+// cpu_utilization or named_metrics.<name>.
+type OrcaMapping map[string]string
+
+// ParseOrcaMapping parses a comma-separated list of field=metric pairs.
+func ParseOrcaMapping(mappingStr string) (OrcaMapping, error) {
+	mapping := OrcaMapping{}
+	for _, pair := range strings.Split(mappingStr, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		field, metric, ok := strings.Cut(pair, "=")
+		field, metric = strings.TrimSpace(field), strings.TrimSpace(metric)
+		if !ok || field == "" || metric == "" {
+			return nil, fmt.Errorf("invalid ORCA mapping %q, expected field=metric", pair)
+		}
+		if !strings.HasPrefix(field, customFieldPrefix) && !slices.Contains(orcaFields, field) {
+			return nil, fmt.Errorf("unknown field %q in ORCA mapping, expected one of %s or %s<name>", field,
+				strings.Join(orcaFields, ", "), customFieldPrefix)
+		}
+		mapping[field] = metric
+	}
+	if len(mapping) == 0 {
+		return nil, errors.New("the ORCA mapping is empty")
+	}
+	return mapping, nil
+}
+
+// String returns the mapping in the format parsed by ParseOrcaMapping.
+func (m OrcaMapping) String() string {
+	pairs := make([]string, 0, len(m))
+	for field, metric := range m {
+		pairs = append(pairs, field+"="+metric)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// LoadReport returns the load report of the mapped ORCA metrics, or nil if none of them is reported.
+func (m OrcaMapping) LoadReport(metrics map[string]float64) *LoadReport {
+	report := &LoadReport{}
+	found := false
+	for field, metric := range m {
+		value, ok := metrics[metric]
+		if !ok {
+			continue
+		}
+		found = true
+		switch field {
+		case "waitingQueueSize":
+			report.WaitingQueueSize = ptr.To(int(value))
+		case "runningQueueSize":
+			report.RunningQueueSize = ptr.To(int(value))
+		case "kvCacheUsagePercent":
+			report.KVCacheUsagePercent = ptr.To(value)
+		case "kvCacheMaxTokenCapacity":
+			report.KvCacheMaxTokenCapacity = ptr.To(int(value))
+		case "maxActiveModels":
+			report.MaxActiveModels = ptr.To(int(value))
+		default:
+			if report.Custom == nil {
+				report.Custom = map[string]float64{}
+			}
+			report.Custom[strings.TrimPrefix(field, customFieldPrefix)] = value
+		}
+	}
+	if !found {
+		return nil
+	}
+	return report
+}
+
+// ParseOrcaHeaders returns the metrics of the ORCA load report in the response headers, keyed by their names in the
+// TEXT format, e.g. cpu_utilization or named_metrics.<name>. It returns nil if the headers carry no load report.
+func ParseOrcaHeaders(headers map[string]string) (map[string]float64, error) {
+	if value, ok := headers[OrcaHeaderKey]; ok {
+		format, report, _ := strings.Cut(strings.TrimSpace(value), " ")
+		switch format {
+		case "TEXT":
+			return parseOrcaText(report)
+		case "JSON":
+			orcaReport := &orcav3.OrcaLoadReport{}
+			if err := protojson.Unmarshal([]byte(report), orcaReport); err != nil {
+				return nil, fmt.Errorf("invalid JSON ORCA load report: %w", err)
+			}
+			return orcaMetrics(orcaReport), nil
+		case "BIN":
+			return parseOrcaBinary(report)
+		default:
+			return nil, fmt.Errorf("unsupported ORCA load report format %q", format)
+		}
+	}
+	if value, ok := headers[OrcaBinaryHeaderKey]; ok {
+		return parseOrcaBinary(value)
+	}
+	return nil, nil
+}
+
+func parseOrcaText(report string) (map[string]float64, error) {
+	metrics := map[string]float64{}
+	for _, pair := range strings.Split(report, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, valueStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid metric %q in TEXT ORCA load report", pair)
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(valueStr), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of metric %q in TEXT ORCA load report: %w", name, err)
+		}
+		metrics[strings.TrimSpace(name)] = value
+	}
+	return metrics, nil
+}
+
+func parseOrcaBinary(report string) (map[string]float64, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(report))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 ORCA load report: %w", err)
+	}
+	orcaReport := &orcav3.OrcaLoadReport{}
+	if err := proto.Unmarshal(data, orcaReport); err != nil {
+		return nil, fmt.Errorf("invalid binary ORCA load report: %w", err)
+	}
+	return orcaMetrics(orcaReport), nil
+}
+
+// orcaMetrics flattens the load report. As the protobuf doesn't distinguish the unset scalar metrics from zero, only
+// the non-zero ones are returned.
+func orcaMetrics(report *orcav3.OrcaLoadReport) map[string]float64 {
+	metrics := map[string]float64{}
+	for name, value := range map[string]float64{
+		"cpu_utilization":         report.GetCpuUtilization(),
+		"mem_utilization":         report.GetMemUtilization(),
+		"application_utilization": report.GetApplicationUtilization(),
+		"rps_fractional":          report.GetRpsFractional(),
+		"eps":                     report.GetEps(),
+	} {
+		if value != 0 {
+			metrics[name] = value
+		}
+	}
+	for prefix, values := range map[string]map[string]float64{
+		"named_metrics.": report.GetNamedMetrics(),
+		"utilization.":   report.GetUtilization(),
+		"request_cost.":  report.GetRequestCost(),
+	} {
+		for name, value := range values {
+			metrics[prefix+name] = value
+		}
+	}
+	return metrics
+}
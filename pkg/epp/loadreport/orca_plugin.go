@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	OrcaLoadReportPluginType = "orca-load-report"
+)
+
+type orcaLoadReportPluginParameters struct {
+	Metrics string `json:"metrics" description:"The comma-separated field=metric pairs mapping the ORCA metrics, e.g. named_metrics.<name>, to the metrics of the pods: waitingQueueSize, runningQueueSize, kvCacheUsagePercent, kvCacheMaxTokenCapacity, maxActiveModels or custom.<name>."`
+}
+
+// OrcaLoadReportPluginParameterSchema is the schema of the parameters of OrcaLoadReportPlugin.
+var OrcaLoadReportPluginParameterSchema = plugins.NewParameterSchema(orcaLoadReportPluginParameters{Metrics: DefaultOrcaMapping})
+
+// compile-time type assertion
+var _ requestcontrol.PostResponse = &OrcaLoadReportPlugin{}
+
+// podGetter looks up the metrics of the pods by name.
+type podGetter interface {
+	PodGet(namespacedName k8stypes.NamespacedName) backendmetrics.PodMetrics
+}
+
+// OrcaLoadReportPluginFactory defines the factory function for OrcaLoadReportPlugin. The plugin updates the pods
+// looked up through the handle.
+func OrcaLoadReportPluginFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := orcaLoadReportPluginParameters{Metrics: DefaultOrcaMapping}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' plugin - %w", OrcaLoadReportPluginType, err)
+		}
+	}
+	mapping, err := ParseOrcaMapping(parameters.Metrics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' plugin - %w", OrcaLoadReportPluginType, err)
+	}
+	return NewOrcaLoadReportPlugin(handle, mapping).WithName(name), nil
+}
+
+// NewOrcaLoadReportPlugin initializes a new OrcaLoadReportPlugin updating the pods of the datastore, and returns its
+// pointer.
+func NewOrcaLoadReportPlugin(datastore podGetter, mapping OrcaMapping) *OrcaLoadReportPlugin {
+	return &OrcaLoadReportPlugin{
+		name:      OrcaLoadReportPluginType,
+		datastore: datastore,
+		mapping:   mapping,
+	}
+}
+
+// OrcaLoadReportPlugin updates the metrics of the pod that served a request with the ORCA load report attached to
+// the headers of the response, so that the pods under heavy traffic report their load faster than they are scraped.
+// The pods keep being scraped for the metrics that are not mapped.
+type OrcaLoadReportPlugin struct {
+	name      string
+	datastore podGetter
+	mapping   OrcaMapping
+}
+
+// Type returns the type of the plugin.
+func (p *OrcaLoadReportPlugin) Type() string {
+	return OrcaLoadReportPluginType
+}
+
+// Name returns the name of the plugin.
+func (p *OrcaLoadReportPlugin) Name() string {
+	return p.name
+}
+
+// WithName sets the name of the plugin.
+func (p *OrcaLoadReportPlugin) WithName(name string) *OrcaLoadReportPlugin {
+	p.name = name
+	return p
+}
+
+// PostResponse parses the ORCA load report in the response headers and updates the metrics of the target pod.
+func (p *OrcaLoadReportPlugin) PostResponse(ctx context.Context, _ *types.LLMRequest, response *requestcontrol.Response,
+	targetPod *backend.Pod) {
+	if targetPod == nil || response == nil {
+		return
+	}
+	logger := log.FromContext(ctx)
+	metrics, err := ParseOrcaHeaders(response.Headers)
+	if err != nil {
+		logger.V(logutil.DEBUG).Info("Failed to parse the ORCA load report", "pod", targetPod.NamespacedName, "err", err)
+		return
+	}
+	report := p.mapping.LoadReport(metrics)
+	if report == nil {
+		return
+	}
+	pm := p.datastore.PodGet(targetPod.NamespacedName)
+	if pm == nil {
+		return
+	}
+	pm.UpdateMetrics(report.Apply)
+	logger.V(logutil.TRACE).Info("Updated metrics from the ORCA load report", "pod", targetPod.NamespacedName)
+}
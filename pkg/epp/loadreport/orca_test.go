@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadreport
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	orcav3 "github.com/cncf/xds/go/xds/data/orca/v3"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
+	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
+)
+
+func TestParseOrcaHeaders(t *testing.T) {
+	binaryReport, err := proto.Marshal(&orcav3.OrcaLoadReport{
+		CpuUtilization: 0.3,
+		NamedMetrics:   map[string]float64{"num_requests_waiting": 4},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal the ORCA load report: %v", err)
+	}
+	encodedReport := base64.StdEncoding.EncodeToString(binaryReport)
+	want := map[string]float64{"cpu_utilization": 0.3, "named_metrics.num_requests_waiting": 4}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    map[string]float64
+		wantErr bool
+	}{
+		{
+			name:    "text",
+			headers: map[string]string{OrcaHeaderKey: "TEXT cpu_utilization=0.3, named_metrics.num_requests_waiting=4"},
+			want:    want,
+		},
+		{
+			name:    "json",
+			headers: map[string]string{OrcaHeaderKey: `JSON {"cpu_utilization": 0.3, "named_metrics": {"num_requests_waiting": 4}}`},
+			want:    want,
+		},
+		{
+			name:    "binary",
+			headers: map[string]string{OrcaHeaderKey: "BIN " + encodedReport},
+			want:    want,
+		},
+		{
+			name:    "binary header",
+			headers: map[string]string{OrcaBinaryHeaderKey: encodedReport},
+			want:    want,
+		},
+		{
+			name:    "no report",
+			headers: map[string]string{"content-type": "application/json"},
+		},
+		{
+			name:    "unknown format",
+			headers: map[string]string{OrcaHeaderKey: "YAML cpu_utilization: 0.3"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid text value",
+			headers: map[string]string{OrcaHeaderKey: "TEXT cpu_utilization=high"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid binary",
+			headers: map[string]string{OrcaBinaryHeaderKey: "not base64!"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseOrcaHeaders(test.headers)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("ParseOrcaHeaders() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOrcaHeaders() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected metrics (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestParseOrcaMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		mappingStr string
+		want       OrcaMapping
+		wantErr    bool
+	}{
+		{
+			name:       "default",
+			mappingStr: DefaultOrcaMapping,
+			want: OrcaMapping{
+				"waitingQueueSize":    "named_metrics.num_requests_waiting",
+				"runningQueueSize":    "named_metrics.num_requests_running",
+				"kvCacheUsagePercent": "named_metrics.kv_cache_usage_perc",
+			},
+		},
+		{
+			name:       "custom metric",
+			mappingStr: " kvCacheUsagePercent = mem_utilization , custom.cpu=cpu_utilization",
+			want:       OrcaMapping{"kvCacheUsagePercent": "mem_utilization", "custom.cpu": "cpu_utilization"},
+		},
+		{name: "empty", mappingStr: "", wantErr: true},
+		{name: "unknown field", mappingStr: "queue=named_metrics.queue", wantErr: true},
+		{name: "missing metric", mappingStr: "waitingQueueSize=", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseOrcaMapping(test.mappingStr)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("ParseOrcaMapping() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseOrcaMapping() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected mapping (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestOrcaLoadReportPlugin(t *testing.T) {
+	pod := &backendmetrics.FakePodMetrics{
+		Pod: &backend.Pod{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"}},
+		Metrics: &backendmetrics.MetricsState{
+			WaitingQueueSize:    1,
+			RunningQueueSize:    2,
+			KVCacheUsagePercent: 0.1,
+			ActiveModels:        map[string]int{"lora1": 0},
+			WaitingModels:       map[string]int{},
+		},
+	}
+	ds := &fakeDatastore{pods: map[types.NamespacedName]backendmetrics.PodMetrics{pod.Pod.NamespacedName: pod}}
+	mapping, err := ParseOrcaMapping(DefaultOrcaMapping + ",custom.cpu=cpu_utilization")
+	if err != nil {
+		t.Fatalf("ParseOrcaMapping() unexpected error: %v", err)
+	}
+	plugin := NewOrcaLoadReportPlugin(ds, mapping)
+
+	// A response without a load report, or a malformed one, leaves the metrics untouched.
+	for _, headers := range []map[string]string{{}, {OrcaHeaderKey: "TEXT named_metrics.num_requests_waiting"}} {
+		plugin.PostResponse(context.Background(), nil, &requestcontrol.Response{Headers: headers}, pod.Pod)
+		if pod.Metrics.WaitingQueueSize != 1 {
+			t.Fatalf("Unexpected update of the metrics by headers %v: %v", headers, pod.Metrics)
+		}
+	}
+
+	headers := map[string]string{
+		OrcaHeaderKey: "TEXT cpu_utilization=0.7, named_metrics.num_requests_waiting=5, named_metrics.kv_cache_usage_perc=0.8",
+	}
+	plugin.PostResponse(context.Background(), nil, &requestcontrol.Response{Headers: headers}, pod.Pod)
+	want := &backendmetrics.MetricsState{
+		WaitingQueueSize:    5,
+		RunningQueueSize:    2,
+		KVCacheUsagePercent: 0.8,
+		ActiveModels:        map[string]int{"lora1": 0},
+		WaitingModels:       map[string]int{},
+		Custom:              map[string]float64{"cpu": 0.7},
+	}
+	if diff := cmp.Diff(want, pod.GetMetrics()); diff != "" {
+		t.Errorf("Unexpected metrics (-want +got): %s", diff)
+	}
+}
+
+func TestOrcaLoadReportPluginFactory(t *testing.T) {
+	tests := []struct {
+		name        string
+		parameters  string
+		wantMapping string
+		wantErr     bool
+	}{
+		{name: "default mapping", wantMapping: DefaultOrcaMapping},
+		{name: "empty parameters", parameters: `{}`, wantMapping: DefaultOrcaMapping},
+		{
+			name:        "custom mapping",
+			parameters:  `{"metrics": "waitingQueueSize=named_metrics.queue,custom.cpu=cpu_utilization"}`,
+			wantMapping: "waitingQueueSize=named_metrics.queue,custom.cpu=cpu_utilization",
+		},
+		{name: "unknown field", parameters: `{"metrics": "unknown=cpu_utilization"}`, wantErr: true},
+		{name: "empty mapping", parameters: `{"metrics": ""}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plugin, err := OrcaLoadReportPluginFactory("orca", json.RawMessage(test.parameters), utils.NewTestHandle())
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("OrcaLoadReportPluginFactory() expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OrcaLoadReportPluginFactory() unexpected error: %v", err)
+			}
+			orcaPlugin := plugin.(*OrcaLoadReportPlugin)
+			if orcaPlugin.Name() != "orca" {
+				t.Errorf("Unexpected name %q", orcaPlugin.Name())
+			}
+			wantMapping, _ := ParseOrcaMapping(test.wantMapping)
+			if diff := cmp.Diff(wantMapping, orcaPlugin.mapping); diff != "" {
+				t.Errorf("Unexpected mapping (-want +got): %s", diff)
+			}
+		})
+	}
+}
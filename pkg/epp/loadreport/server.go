@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadreport
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// ReportPath is the HTTP path the load reports are pushed to.
+	ReportPath = "/v1/loadreport"
+
+	// maxReportSize bounds the size of the body of a load report.
+	maxReportSize = 1 << 20
+)
+
+// Datastore is the subset of the datastore the server updates the pods of.
+type Datastore interface {
+	PoolGet() (*v1alpha2.InferencePool, error)
+	PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics
+}
+
+// NewServer creates a new Server updating the pods of the datastore. If authToken isn't empty, the load reports must
+// carry it as a bearer token.
+func NewServer(datastore Datastore, authToken string) *Server {
+	return &Server{
+		datastore: datastore,
+		authToken: authToken,
+	}
+}
+
+// Server is the HTTP endpoint the model servers, or their sidecars, push their load reports to. The metrics of a pod
+// that pushes are not scraped, and their staleness is tracked the same way as the scraped ones. A load report is only
+// accepted from the address of the pod it reports the load of.
+type Server struct {
+	datastore Datastore
+	authToken string
+}
+
+// ServeHTTP handles a load report, a LoadReport in JSON POSTed to the ReportPath.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := log.FromContext(r.Context())
+	if r.URL.Path != ReportPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !s.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	report := &LoadReport{}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxReportSize))
+	if err == nil {
+		err = json.Unmarshal(body, report)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid load report: %v", err), http.StatusBadRequest)
+		return
+	}
+	if report.Pod == "" {
+		http.Error(w, "invalid load report: the pod is required", http.StatusBadRequest)
+		return
+	}
+
+	namespace := report.Namespace
+	if namespace == "" {
+		pool, err := s.datastore.PoolGet()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		namespace = pool.Namespace
+	}
+	namespacedName := types.NamespacedName{Namespace: namespace, Name: report.Pod}
+	pm := s.datastore.PodGet(namespacedName)
+	if pm == nil {
+		http.Error(w, fmt.Sprintf("pod %s not found", namespacedName), http.StatusNotFound)
+		return
+	}
+	if !sentFrom(r, pm.GetPod()) {
+		http.Error(w, fmt.Sprintf("the load report of pod %s must be sent from the pod", namespacedName), http.StatusForbidden)
+		return
+	}
+	pm.PushMetrics(report.Apply)
+	logger.V(logutil.TRACE).Info("Received load report", "pod", namespacedName)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sentFrom returns true if the request was sent from the address of the pod, so that a pod, or its sidecar, can only
+// report its own load.
+func sentFrom(r *http.Request, pod *backend.Pod) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	remoteIP := net.ParseIP(host)
+	return remoteIP != nil && remoteIP.Equal(net.ParseIP(pod.Address))
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.authToken == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.authToken)) == 1
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadreport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+)
+
+type fakeDatastore struct {
+	pods map[types.NamespacedName]backendmetrics.PodMetrics
+}
+
+func (f *fakeDatastore) PoolGet() (*v1alpha2.InferencePool, error) {
+	return &v1alpha2.InferencePool{ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: "default"}}, nil
+}
+
+func (f *fakeDatastore) PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics {
+	return f.pods[namespacedName]
+}
+
+func TestServer(t *testing.T) {
+	const token = "secret"
+	tests := []struct {
+		name        string
+		clientToken string
+		podAddress  string
+		report      *LoadReport
+		want        *backendmetrics.MetricsState
+		wantErr     bool
+	}{
+		{
+			name:        "full report",
+			clientToken: token,
+			report: &LoadReport{
+				Pod:                     "pod1",
+				WaitingQueueSize:        ptr.To(3),
+				RunningQueueSize:        ptr.To(5),
+				KVCacheUsagePercent:     ptr.To(0.5),
+				KvCacheMaxTokenCapacity: ptr.To(1024),
+				ActiveModels:            []string{"lora1", "lora2"},
+				WaitingModels:           []string{},
+				MaxActiveModels:         ptr.To(4),
+				Custom:                  map[string]float64{"ttft_p90": 0.2},
+			},
+			want: &backendmetrics.MetricsState{
+				WaitingQueueSize:        3,
+				RunningQueueSize:        5,
+				KVCacheUsagePercent:     0.5,
+				KvCacheMaxTokenCapacity: 1024,
+				ActiveModels:            map[string]int{"lora1": 0, "lora2": 0},
+				WaitingModels:           map[string]int{},
+				MaxActiveModels:         4,
+				Custom:                  map[string]float64{"ttft_p90": 0.2, "running": 1},
+			},
+		},
+		{
+			name:        "partial report keeps the previous values",
+			clientToken: token,
+			report:      &LoadReport{Namespace: "default", Pod: "pod1", WaitingQueueSize: ptr.To(7)},
+			want: &backendmetrics.MetricsState{
+				WaitingQueueSize:    7,
+				RunningQueueSize:    1,
+				KVCacheUsagePercent: 0.1,
+				ActiveModels:        map[string]int{"lora1": 0},
+				WaitingModels:       map[string]int{"lora2": 0},
+				Custom:              map[string]float64{"running": 1},
+			},
+		},
+		{
+			name:    "missing token",
+			report:  &LoadReport{Pod: "pod1", WaitingQueueSize: ptr.To(7)},
+			wantErr: true,
+		},
+		{
+			name:        "wrong token",
+			clientToken: "wrong",
+			report:      &LoadReport{Pod: "pod1", WaitingQueueSize: ptr.To(7)},
+			wantErr:     true,
+		},
+		{
+			name:        "report sent from another address",
+			clientToken: token,
+			podAddress:  "10.0.0.1",
+			report:      &LoadReport{Pod: "pod1", WaitingQueueSize: ptr.To(7)},
+			wantErr:     true,
+		},
+		{
+			name:        "unknown pod",
+			clientToken: token,
+			report:      &LoadReport{Pod: "unknown", WaitingQueueSize: ptr.To(7)},
+			wantErr:     true,
+		},
+		{
+			name:        "no pod",
+			clientToken: token,
+			report:      &LoadReport{WaitingQueueSize: ptr.To(7)},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// The test server is reached from the loopback address.
+			podAddress := "127.0.0.1"
+			if test.podAddress != "" {
+				podAddress = test.podAddress
+			}
+			pod := &backendmetrics.FakePodMetrics{
+				Pod: &backend.Pod{NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"}, Address: podAddress},
+				Metrics: &backendmetrics.MetricsState{
+					RunningQueueSize:    1,
+					KVCacheUsagePercent: 0.1,
+					ActiveModels:        map[string]int{"lora1": 0},
+					WaitingModels:       map[string]int{"lora2": 0},
+					Custom:              map[string]float64{"running": 1},
+				},
+			}
+			initial := pod.Metrics.Clone()
+			ds := &fakeDatastore{pods: map[types.NamespacedName]backendmetrics.PodMetrics{pod.Pod.NamespacedName: pod}}
+			server := httptest.NewServer(NewServer(ds, token))
+			defer server.Close()
+
+			client := &Client{URL: server.URL, AuthToken: test.clientToken}
+			err := client.Push(context.Background(), test.report)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("Push() expected an error, got nil")
+				}
+				if diff := cmp.Diff(initial, pod.GetMetrics()); diff != "" {
+					t.Errorf("Unexpected update of the metrics (-want +got): %s", diff)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Push() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.want, pod.GetMetrics(), cmpopts.IgnoreFields(backendmetrics.MetricsState{}, "UpdateTime")); diff != "" {
+				t.Errorf("Unexpected metrics (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestServerRejectsOtherRequests(t *testing.T) {
+	server := httptest.NewServer(NewServer(&fakeDatastore{}, ""))
+	defer server.Close()
+
+	for path, want := range map[string]int{ReportPath: http.StatusMethodNotAllowed, "/other": http.StatusNotFound} {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s unexpected error: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s: got status %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+}
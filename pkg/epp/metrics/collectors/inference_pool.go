@@ -17,6 +17,8 @@ limitations under the License.
 package collectors
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	compbasemetrics "k8s.io/component-base/metrics"
 
@@ -33,6 +35,15 @@ var (
 			"model_server_pod",
 		}, nil,
 	)
+
+	descInferencePoolPerPodMetricsAge = prometheus.NewDesc(
+		"inference_pool_per_pod_metrics_age_seconds",
+		metricsutil.HelpMsgWithStability("The time since the metrics of each underlying pod were last updated.", compbasemetrics.ALPHA),
+		[]string{
+			"name",
+			"model_server_pod",
+		}, nil,
+	)
 )
 
 type inferencePoolMetricsCollector struct {
@@ -53,6 +64,7 @@ func NewInferencePoolMetricsCollector(ds datastore.Datastore) prometheus.Collect
 // DescribeWithStability implements the prometheus.Collector interface.
 func (c *inferencePoolMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- descInferencePoolPerPodQueueSize
+	ch <- descInferencePoolPerPodMetricsAge
 }
 
 // CollectWithStability implements the prometheus.Collector interface.
@@ -68,12 +80,23 @@ func (c *inferencePoolMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	for _, pod := range podMetrics {
+		metrics := pod.GetMetrics()
 		ch <- prometheus.MustNewConstMetric(
 			descInferencePoolPerPodQueueSize,
 			prometheus.GaugeValue,
-			float64(pod.GetMetrics().WaitingQueueSize),
+			float64(metrics.WaitingQueueSize),
 			pool.Name,
 			pod.GetPod().NamespacedName.Name,
 		)
+		// The pods whose metrics were never updated have no age.
+		if !metrics.UpdateTime.IsZero() {
+			ch <- prometheus.MustNewConstMetric(
+				descInferencePoolPerPodMetricsAge,
+				prometheus.GaugeValue,
+				time.Since(metrics.UpdateTime).Seconds(),
+				pool.Name,
+				pod.GetPod().NamespacedName.Name,
+			)
+		}
 	}
 }
@@ -245,6 +245,91 @@ var (
 		[]string{},
 	)
 
+	// Shadow Scheduling Profile Metrics
+	shadowProfileAgreement = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "scheduler_shadow_profile_agreement_total",
+			Help: metricsutil.HelpMsgWithStability("Counter of the comparisons of the pods picked by a shadow profile and the primary profile, "+
+				"broken out by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"primary_profile", "shadow_profile", "result"},
+	)
+
+	shadowEvaluationPodQueueSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: InferenceExtension,
+			Name:      "scheduler_shadow_evaluation_pod_queue_size",
+			Help: metricsutil.HelpMsgWithStability("Waiting queue size of the pod picked by each profile, "+
+				"for the requests the shadow profiles ran for.", compbasemetrics.ALPHA),
+			Buckets: []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500},
+		},
+		[]string{"profile"},
+	)
+
+	shadowEvaluationPodKVCacheUtilization = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: InferenceExtension,
+			Name:      "scheduler_shadow_evaluation_pod_kv_cache_utilization",
+			Help: metricsutil.HelpMsgWithStability("KV-cache utilization of the pod picked by each profile, "+
+				"for the requests the shadow profiles ran for.", compbasemetrics.ALPHA),
+			Buckets: []float64{0.0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0},
+		},
+		[]string{"profile"},
+	)
+
+	shadowEvaluationPrefixMatchRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: InferenceExtension,
+			Name:      "scheduler_shadow_evaluation_prefix_match_ratio",
+			Help: metricsutil.HelpMsgWithStability("Ratio of the prompt prefix cached by the pod picked by each profile, "+
+				"for the requests the shadow profiles ran for.", compbasemetrics.ALPHA),
+			Buckets: []float64{0.0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0},
+		},
+		[]string{"profile"},
+	)
+
+	// Configuration Metrics
+	configReloadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "config_reload_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of configuration reloads broken out by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"result"},
+	)
+
+	configLastReloadSuccessful = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: InferenceExtension,
+			Name:      "config_last_reload_successful",
+			Help:      metricsutil.HelpMsgWithStability("Whether the last configuration reload succeeded (1) or failed (0).", compbasemetrics.ALPHA),
+		},
+		[]string{},
+	)
+
+	// Model Server Scrape Metrics
+	modelServerScrapeCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: InferenceExtension,
+			Name:      "model_server_scrape_total",
+			Help:      metricsutil.HelpMsgWithStability("Counter of the scrapes of the metrics of the model servers broken out by result.", compbasemetrics.ALPHA),
+		},
+		[]string{"result"},
+	)
+
+	modelServerScrapeLatencies = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: InferenceExtension,
+			Name:      "model_server_scrape_duration_seconds",
+			Help:      metricsutil.HelpMsgWithStability("Model server metrics scrape latency distribution in seconds.", compbasemetrics.ALPHA),
+			Buckets: []float64{
+				0.001, 0.002, 0.005, 0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1, 2, 5,
+			},
+		},
+		[]string{},
+	)
+
 	// Info Metrics
 	InferenceExtensionInfo = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -280,6 +365,14 @@ func Register(customCollectors ...prometheus.Collector) {
 		metrics.Registry.MustRegister(PrefixCacheSize)
 		metrics.Registry.MustRegister(PrefixCacheHitRatio)
 		metrics.Registry.MustRegister(PrefixCacheHitLength)
+		metrics.Registry.MustRegister(shadowProfileAgreement)
+		metrics.Registry.MustRegister(shadowEvaluationPodQueueSize)
+		metrics.Registry.MustRegister(shadowEvaluationPodKVCacheUtilization)
+		metrics.Registry.MustRegister(shadowEvaluationPrefixMatchRatio)
+		metrics.Registry.MustRegister(configReloadCounter)
+		metrics.Registry.MustRegister(configLastReloadSuccessful)
+		metrics.Registry.MustRegister(modelServerScrapeCounter)
+		metrics.Registry.MustRegister(modelServerScrapeLatencies)
 		for _, collector := range customCollectors {
 			metrics.Registry.MustRegister(collector)
 		}
@@ -307,6 +400,14 @@ func Reset() {
 	PrefixCacheSize.Reset()
 	PrefixCacheHitRatio.Reset()
 	PrefixCacheHitLength.Reset()
+	shadowProfileAgreement.Reset()
+	shadowEvaluationPodQueueSize.Reset()
+	shadowEvaluationPodKVCacheUtilization.Reset()
+	shadowEvaluationPrefixMatchRatio.Reset()
+	configReloadCounter.Reset()
+	configLastReloadSuccessful.Reset()
+	modelServerScrapeCounter.Reset()
+	modelServerScrapeLatencies.Reset()
 }
 
 // RecordRequstCounter records the number of requests.
@@ -440,3 +541,50 @@ func RecordPrefixCacheMatch(matchedLength, totalLength int) {
 func RecordInferenceExtensionInfo() {
 	InferenceExtensionInfo.WithLabelValues(CommitSHA, BuildRef).Set(1)
 }
+
+// RecordShadowProfileAgreement records whether a shadow profile picked the same pod as the primary profile. A shadow
+// profile that failed to pick a pod is recorded as an error.
+func RecordShadowProfileAgreement(primaryProfile, shadowProfile string, agreed, failed bool) {
+	result := "disagree"
+	switch {
+	case failed:
+		result = "error"
+	case agreed:
+		result = "agree"
+	}
+	shadowProfileAgreement.WithLabelValues(primaryProfile, shadowProfile, result).Inc()
+}
+
+// RecordShadowEvaluationPod records the predicted cost of the pod picked by a profile, for a request the shadow
+// profiles ran for.
+func RecordShadowEvaluationPod(profile string, queueSize int, kvCacheUtilization float64) {
+	shadowEvaluationPodQueueSize.WithLabelValues(profile).Observe(float64(queueSize))
+	shadowEvaluationPodKVCacheUtilization.WithLabelValues(profile).Observe(kvCacheUtilization)
+}
+
+// RecordShadowEvaluationPrefixMatch records the ratio of the prompt prefix cached by the pod picked by a profile, for
+// a request the shadow profiles ran for.
+func RecordShadowEvaluationPrefixMatch(profile string, ratio float64) {
+	shadowEvaluationPrefixMatchRatio.WithLabelValues(profile).Observe(ratio)
+}
+
+// RecordConfigReload records the result of a configuration reload.
+func RecordConfigReload(success bool) {
+	if success {
+		configReloadCounter.WithLabelValues("success").Inc()
+		configLastReloadSuccessful.WithLabelValues().Set(1)
+	} else {
+		configReloadCounter.WithLabelValues("failure").Inc()
+		configLastReloadSuccessful.WithLabelValues().Set(0)
+	}
+}
+
+// RecordModelServerScrape records the result and the latency of a scrape of the metrics of a model server.
+func RecordModelServerScrape(success bool, duration time.Duration) {
+	if success {
+		modelServerScrapeCounter.WithLabelValues("success").Inc()
+	} else {
+		modelServerScrapeCounter.WithLabelValues("failure").Inc()
+	}
+	modelServerScrapeLatencies.WithLabelValues().Observe(duration.Seconds())
+}
@@ -762,3 +762,67 @@ func TestPrefixCacheMetrics(t *testing.T) {
 		}
 	})
 }
+
+func TestConfigReloadMetrics(t *testing.T) {
+	Register()
+	RecordConfigReload(true)
+	RecordConfigReload(false)
+	RecordConfigReload(true)
+
+	wantConfigReload, err := os.Open("testdata/config_reload_metrics")
+	defer func() {
+		if err := wantConfigReload.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutil.GatherAndCompare(metrics.Registry, wantConfigReload,
+		"inference_extension_config_reload_total", "inference_extension_config_last_reload_successful"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestModelServerScrapeMetrics(t *testing.T) {
+	Register()
+	RecordModelServerScrape(true, 5*time.Millisecond)
+	RecordModelServerScrape(true, 3*time.Millisecond)
+	RecordModelServerScrape(false, 5*time.Second)
+
+	wantModelServerScrape, err := os.Open("testdata/model_server_scrape_metrics")
+	defer func() {
+		if err := wantModelServerScrape.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutil.GatherAndCompare(metrics.Registry, wantModelServerScrape,
+		"inference_extension_model_server_scrape_total", "inference_extension_model_server_scrape_duration_seconds"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestShadowProfileAgreementMetrics(t *testing.T) {
+	Register()
+	RecordShadowProfileAgreement("default", "candidate", true, false)
+	RecordShadowProfileAgreement("default", "candidate", false, false)
+	RecordShadowProfileAgreement("default", "candidate", true, false)
+	RecordShadowProfileAgreement("default", "candidate", false, true)
+
+	wantShadowProfileAgreement, err := os.Open("testdata/shadow_profile_agreement_metric")
+	defer func() {
+		if err := wantShadowProfileAgreement.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutil.GatherAndCompare(metrics.Registry, wantShadowProfileAgreement,
+		"inference_extension_scheduler_shadow_profile_agreement_total"); err != nil {
+		t.Error(err)
+	}
+}
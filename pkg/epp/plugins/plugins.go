@@ -16,6 +16,12 @@ limitations under the License.
 
 package plugins
 
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+)
+
 // Plugin defines the interface for a plugin.
 // This interface should be embedded in all plugins across the code.
 type Plugin interface {
@@ -29,6 +35,14 @@ type Plugin interface {
 type Handle interface {
 	// Plugins returns the sub-handle for working with instantiated plugins
 	Plugins() HandlePlugins
+
+	// PodGet returns the metrics of the pod of the pool with the given name, or nil if the pod is unknown or the
+	// plugins are not instantiated to serve requests, e.g. to validate a configuration.
+	PodGet(namespacedName types.NamespacedName) backendmetrics.PodMetrics
+
+	// CustomMetrics returns the names of the custom metrics declared in the configuration the plugins are instantiated
+	// from.
+	CustomMetrics() []string
 }
 
 // HandlePlugins defines a set of APIs to work with instantiated plugins
@@ -45,3 +59,22 @@ type HandlePlugins interface {
 	// GetAllPluginsWithNames returns all of the known plugins with their names
 	GetAllPluginsWithNames() map[string]Plugin
 }
+
+// CustomMetricsPlugin is implemented by the plugins that depend on custom metrics declared in the configuration.
+// When the configuration is reloaded, such a plugin is only reused if the metrics it depends on are still declared.
+type CustomMetricsPlugin interface {
+	Plugin
+	// CustomMetrics returns the names of the custom metrics the plugin depends on.
+	CustomMetrics() []string
+}
+
+// ReferencingPlugin is implemented by the plugins that reference other plugin instances by name. The references are
+// resolved once all the plugins of the configuration are instantiated, so that a plugin can reference the plugins
+// declared after it. The loader rejects the configurations whose references form a cycle.
+type ReferencingPlugin interface {
+	Plugin
+	// References returns the names of the referenced plugin instances.
+	References() []string
+	// ResolveReferences looks up the referenced plugin instances through the handle.
+	ResolveReferences(handle Handle) error
+}
@@ -24,10 +24,30 @@ import (
 // specified in a configuration.
 type FactoryFunc func(name string, parameters json.RawMessage, handle Handle) (Plugin, error)
 
+// Registration is the registration of a plugin type in the Registry.
+type Registration struct {
+	// Factory is the factory function of the plugin type.
+	Factory FactoryFunc
+	// Parameters is the schema of the parameters of the plugin type. It is nil if the plugin type didn't publish a
+	// schema, in which case the parameters are only checked by the factory function.
+	Parameters *ParameterSchema
+	// ExtensionPoints are the extension points implemented by the plugin type, e.g. Filter or Scorer. It is empty if
+	// the plugin type didn't declare them.
+	ExtensionPoints []string
+}
+
 // Register is a static function that can be called to register plugin factory functions.
 func Register(pluginType string, factory FactoryFunc) {
-	Registry[pluginType] = factory
+	RegisterWithSchema(pluginType, factory, nil)
+}
+
+// RegisterWithSchema registers a plugin factory function with the schema of the parameters of the plugin type.
+// The parameters of the configured plugins are strictly validated against the schema before the factory is called.
+// The extension points implemented by the plugin type are declared for documentation, e.g. by the explain-plugins
+// subcommand.
+func RegisterWithSchema(pluginType string, factory FactoryFunc, parameters *ParameterSchema, extensionPoints ...string) {
+	Registry[pluginType] = Registration{Factory: factory, Parameters: parameters, ExtensionPoints: extensionPoints}
 }
 
-// Registry is a mapping from plugin name to Factory function
-var Registry map[string]FactoryFunc = map[string]FactoryFunc{}
+// Registry is a mapping from plugin type to its Registration
+var Registry map[string]Registration = map[string]Registration{}
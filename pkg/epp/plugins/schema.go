@@ -0,0 +1,124 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ParameterSchema is the JSON schema of the parameters of a plugin type. It is built from the Go struct the factory
+// function of the plugin decodes the parameters into, so that the schema and the decoding can't drift apart.
+type ParameterSchema struct {
+	Type        string                      `json:"type"`
+	Description string                      `json:"description,omitempty"`
+	Default     any                         `json:"default,omitempty"`
+	Properties  map[string]*ParameterSchema `json:"properties,omitempty"`
+	Items       *ParameterSchema            `json:"items,omitempty"`
+	// AdditionalProperties is false for structs, whose unknown fields are rejected.
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+
+	goType reflect.Type
+}
+
+// NewParameterSchema returns the schema of the parameters decoded into the struct type of defaults. The values of
+// defaults are the default values of the parameters. The description of a parameter is taken from the description
+// tag of its field.
+func NewParameterSchema(defaults any) *ParameterSchema {
+	value := reflect.ValueOf(defaults)
+	schema := schemaOf(value.Type(), value)
+	schema.Default = nil
+	return schema
+}
+
+// NoParameters is the schema of the plugins that don't take any parameters.
+var NoParameters = NewParameterSchema(struct{}{})
+
+func schemaOf(t reflect.Type, value reflect.Value) *ParameterSchema {
+	schema := &ParameterSchema{goType: t}
+	if value.IsValid() && !value.IsZero() {
+		schema.Default = value.Interface()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		schema.Type = "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		schema.Type = "integer"
+	case reflect.Float32, reflect.Float64:
+		schema.Type = "number"
+	case reflect.String:
+		schema.Type = "string"
+	case reflect.Slice, reflect.Array:
+		schema.Type = "array"
+		schema.Items = schemaOf(t.Elem(), reflect.Value{})
+	case reflect.Map:
+		schema.Type = "object"
+	case reflect.Pointer:
+		var elem reflect.Value
+		if value.IsValid() && !value.IsNil() {
+			elem = value.Elem()
+		}
+		schema = schemaOf(t.Elem(), elem)
+	case reflect.Struct:
+		schema.Type = "object"
+		schema.Default = nil
+		schema.Properties = map[string]*ParameterSchema{}
+		schema.AdditionalProperties = new(bool)
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if !field.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			var fieldValue reflect.Value
+			if value.IsValid() {
+				fieldValue = value.Field(i)
+			}
+			property := schemaOf(field.Type, fieldValue)
+			property.Description = field.Tag.Get("description")
+			schema.Properties[name] = property
+		}
+	default:
+		schema.Type = t.Kind().String()
+	}
+	return schema
+}
+
+// Validate strictly decodes the parameters, rejecting the unknown fields and the values of the wrong type.
+func (s *ParameterSchema) Validate(parameters json.RawMessage) error {
+	if len(bytes.TrimSpace(parameters)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(parameters))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(reflect.New(s.goType).Interface()); err != nil {
+		return fmt.Errorf("invalid parameters - %w", err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid parameters - unexpected data after the parameters")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testParameters struct {
+	Threshold int      `json:"threshold" description:"The threshold."`
+	Ratio     float64  `json:"ratio,omitempty"`
+	Headers   []string `json:"headers"`
+	Nested    struct {
+		Enabled bool `json:"enabled"`
+	} `json:"nested"`
+	ignored int //nolint:unused
+}
+
+func TestNewParameterSchema(t *testing.T) {
+	schema := NewParameterSchema(testParameters{Threshold: 10})
+	got, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Failed to marshal the schema: %v", err)
+	}
+	want := `{"type":"object","properties":{` +
+		`"headers":{"type":"array","items":{"type":"string"}},` +
+		`"nested":{"type":"object","properties":{"enabled":{"type":"boolean"}},"additionalProperties":false},` +
+		`"ratio":{"type":"number"},` +
+		`"threshold":{"type":"integer","description":"The threshold.","default":10}},` +
+		`"additionalProperties":false}`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("Unexpected schema (-want +got): %s", diff)
+	}
+}
+
+func TestParameterSchemaValidate(t *testing.T) {
+	schema := NewParameterSchema(testParameters{})
+	tests := []struct {
+		name       string
+		schema     *ParameterSchema
+		parameters string
+		wantErr    bool
+	}{
+		{name: "no parameters", parameters: ""},
+		{name: "null parameters", parameters: "null"},
+		{name: "valid parameters", parameters: `{"threshold": 5, "headers": ["a"], "nested": {"enabled": true}}`},
+		{name: "unknown field", parameters: `{"treshold": 5}`, wantErr: true},
+		{name: "unknown nested field", parameters: `{"nested": {"enable": true}}`, wantErr: true},
+		{name: "wrong type", parameters: `{"threshold": "5"}`, wantErr: true},
+		{name: "trailing data", parameters: `{"threshold": 5} {}`, wantErr: true},
+		{name: "no parameters allowed", schema: NoParameters, parameters: `{"threshold": 5}`, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			theSchema := schema
+			if test.schema != nil {
+				theSchema = test.schema
+			}
+			err := theSchema.Validate(json.RawMessage(test.parameters))
+			if (err != nil) != test.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
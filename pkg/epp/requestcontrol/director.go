@@ -20,20 +20,26 @@ package requestcontrol
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	schedulingtypes "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 	requtil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/request"
@@ -51,22 +57,40 @@ type SaturationDetector interface {
 
 // NewDirectorWithConfig creates a new Director instance with all dependencies.
 func NewDirectorWithConfig(datastore datastore.Datastore, scheduler Scheduler, saturationDetector SaturationDetector, config *Config) *Director {
-	return &Director{
-		datastore:           datastore,
-		scheduler:           scheduler,
-		saturationDetector:  saturationDetector,
-		preRequestPlugins:   config.preRequestPlugins,
-		postResponsePlugins: config.postResponsePlugins,
+	director := &Director{
+		datastore:          datastore,
+		saturationDetector: saturationDetector,
 	}
+	director.Update(scheduler, config)
+	return director
 }
 
 // Director orchestrates the request handling flow, including scheduling.
 type Director struct {
-	datastore           datastore.Datastore
+	datastore          datastore.Datastore
+	saturationDetector SaturationDetector
+	// pipeline holds the scheduler and the plugins, it is swapped as a whole when the configuration is reloaded.
+	pipeline atomic.Pointer[pipeline]
+}
+
+type pipeline struct {
 	scheduler           Scheduler
-	saturationDetector  SaturationDetector
 	preRequestPlugins   []PreRequest
 	postResponsePlugins []PostResponse
+	// schedulingTraceHeader enables returning the scheduling decision trace to the requests that ask for it.
+	schedulingTraceHeader bool
+}
+
+// Update atomically replaces the scheduler and the requestcontrol plugins of the Director, e.g. when the
+// configuration is reloaded. The requests handled after the call use the new scheduler and plugins, while the
+// responses of the requests handled before the call are still handled by the previous plugins.
+func (d *Director) Update(scheduler Scheduler, config *Config) {
+	d.pipeline.Store(&pipeline{
+		scheduler:             scheduler,
+		preRequestPlugins:     config.preRequestPlugins,
+		postResponsePlugins:   config.postResponsePlugins,
+		schedulingTraceHeader: config.schedulingTraceHeader,
+	})
 }
 
 // HandleRequest orchestrates the request lifecycle:
@@ -119,18 +143,25 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 
 	// Prepare LLMRequest (needed for both saturation detection and Scheduler)
 	reqCtx.SchedulingRequest = &schedulingtypes.LLMRequest{
-		RequestId:   reqCtx.Request.Headers[requtil.RequestIdHeaderKey],
-		TargetModel: reqCtx.ResolvedTargetModel,
-		Prompt:      prompt,
-		Headers:     reqCtx.Request.Headers,
+		RequestId:         reqCtx.Request.Headers[requtil.RequestIdHeaderKey],
+		TargetModel:       reqCtx.ResolvedTargetModel,
+		Prompt:            prompt,
+		Headers:           reqCtx.Request.Headers,
+		Body:              reqCtx.Request.Body,
+		SchedulingProfile: modelObj.Spec.SchedulingProfile,
 	}
 
 	logger = logger.WithValues("model", reqCtx.Model, "resolvedTargetModel", reqCtx.ResolvedTargetModel, "criticality", requestCriticality)
 	ctx = log.IntoContext(ctx, logger)
 	logger.V(logutil.DEBUG).Info("LLM request assembled")
 
+	trace.SpanFromContext(ctx).SetAttributes(tracing.ModelKey.String(reqCtx.Model), tracing.TargetModelKey.String(reqCtx.ResolvedTargetModel))
+
 	// --- 2. Admission Control check --
-	if err := d.admitRequest(ctx, requestCriticality); err != nil {
+	admissionCtx, admissionSpan := tracing.Tracer().Start(ctx, "Admission", trace.WithAttributes(tracing.CriticalityKey.String(string(requestCriticality))))
+	err = d.admitRequest(admissionCtx, requestCriticality)
+	tracing.EndSpan(admissionSpan, err)
+	if err != nil {
 		return reqCtx, err
 	}
 
@@ -138,8 +169,10 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 	// Snapshot pod metrics from the datastore to:
 	// 1. Reduce concurrent access to the datastore.
 	// 2. Ensure consistent data during the scheduling operation of a request between all scheduling cycles.
-	candidatePods := schedulingtypes.ToSchedulerPodMetrics(d.datastore.PodGetAll())
-	results, err := d.scheduler.Schedule(ctx, reqCtx.SchedulingRequest, candidatePods)
+	pipeline := d.pipeline.Load()
+	reqCtx.Pipeline = pipeline
+	candidatePods := schedulingtypes.ToSchedulerPodMetrics(withKnownMetrics(d.datastore.PodGetAll()))
+	results, err := pipeline.scheduler.Schedule(ctx, reqCtx.SchedulingRequest, candidatePods)
 	if err != nil {
 		return reqCtx, errutil.Error{Code: errutil.InferencePoolResourceExhausted, Msg: fmt.Errorf("failed to find target pod: %w", err).Error()}
 	}
@@ -147,7 +180,7 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 	// --- 4. Prepare Request (Populates RequestContext and call PreRequest plugins) ---
 	// Insert target endpoint to instruct Envoy to route requests to the specified target pod and attach the port number.
 	// Invoke PreRequest registered plugins.
-	reqCtx, err = d.prepareRequest(ctx, reqCtx, results)
+	reqCtx, err = d.prepareRequest(ctx, pipeline, reqCtx, results)
 	if err != nil {
 		return reqCtx, err
 	}
@@ -155,6 +188,22 @@ func (d *Director) HandleRequest(ctx context.Context, reqCtx *handlers.RequestCo
 	return reqCtx, nil
 }
 
+// withKnownMetrics returns the pods whose metrics were fetched at least once, so that the plugins don't mistake the
+// zero values of the pods that were never scraped for an idle pod. If no pod has known metrics, e.g. right after the
+// EPP started, all the pods are returned. This policy is documented in the metrics freshness configuration guide.
+func withKnownMetrics(pods []backendmetrics.PodMetrics) []backendmetrics.PodMetrics {
+	known := make([]backendmetrics.PodMetrics, 0, len(pods))
+	for _, pod := range pods {
+		if !pod.GetMetrics().IsUnknown() {
+			known = append(known, pod)
+		}
+	}
+	if len(known) == 0 {
+		return pods
+	}
+	return known
+}
+
 // admitRequest handles admission control to decide whether or not to accept the request
 // based on the request criticality and system saturation state.
 func (d *Director) admitRequest(ctx context.Context, requestCriticality v1alpha2.Criticality) error {
@@ -178,13 +227,18 @@ func (d *Director) admitRequest(ctx context.Context, requestCriticality v1alpha2
 
 // prepareRequest populates the RequestContext and calls the registered PreRequest plugins
 // for allowing plugging customized logic based on the scheduling results.
-func (d *Director) prepareRequest(ctx context.Context, reqCtx *handlers.RequestContext, result *schedulingtypes.SchedulingResult) (*handlers.RequestContext, error) {
+func (d *Director) prepareRequest(ctx context.Context, pipeline *pipeline, reqCtx *handlers.RequestContext,
+	result *schedulingtypes.SchedulingResult) (*handlers.RequestContext, error) {
 	logger := log.FromContext(ctx)
 	if result == nil || len(result.ProfileResults) == 0 {
 		return reqCtx, errutil.Error{Code: errutil.Internal, Msg: "results must be greater than zero"}
 	}
 	// primary profile is used to set destination
-	targetPod := result.ProfileResults[result.PrimaryProfileName].TargetPod.GetPod()
+	primaryResult := result.ProfileResults[result.PrimaryProfileName]
+	if primaryResult == nil || primaryResult.TargetPod == nil {
+		return reqCtx, errutil.Error{Code: errutil.Internal, Msg: "no pod was picked by the primary profile"}
+	}
+	targetPod := primaryResult.TargetPod.GetPod()
 
 	pool, err := d.datastore.PoolGet()
 	if err != nil {
@@ -197,19 +251,44 @@ func (d *Director) prepareRequest(ctx context.Context, reqCtx *handlers.RequestC
 
 	reqCtx.TargetPod = targetPod
 	reqCtx.TargetEndpoint = endpoint
+	// the other pods ranked by the picker are the fallback endpoints of the request
+	reqCtx.FallbackEndpoints = nil
+	for _, rankedPod := range primaryResult.RankedPods {
+		if pod := rankedPod.GetPod(); pod.NamespacedName != targetPod.NamespacedName {
+			reqCtx.FallbackEndpoints = append(reqCtx.FallbackEndpoints, net.JoinHostPort(pod.Address, strconv.Itoa(targetPort)))
+		}
+	}
+	if pipeline.schedulingTraceHeader && strings.EqualFold(reqCtx.Request.Headers[requtil.SchedulingTraceHeaderKey], "true") {
+		reqCtx.SchedulingTrace = result.DecisionTrace
+	}
+	trace.SpanFromContext(ctx).SetAttributes(tracing.TargetEndpointKey.String(endpoint))
 
-	d.runPreRequestPlugins(ctx, reqCtx.SchedulingRequest, result, targetPort)
+	runPreRequestPlugins(ctx, pipeline.preRequestPlugins, reqCtx.SchedulingRequest, result, targetPort)
 
 	return reqCtx, nil
 }
 
+// HandleResponse calls the PostResponse plugins of the pipeline the request was handled with, rather than those of
+// the current configuration, and returns the scheduling decision trace in the response headers if requested.
 func (d *Director) HandleResponse(ctx context.Context, reqCtx *handlers.RequestContext) (*handlers.RequestContext, error) {
 	response := &Response{
 		RequestId: reqCtx.Request.Headers[requtil.RequestIdHeaderKey],
 		Headers:   reqCtx.Response.Headers,
 	}
 
-	d.runPostResponsePlugins(ctx, reqCtx.SchedulingRequest, response, reqCtx.TargetPod)
+	pipeline, ok := reqCtx.Pipeline.(*pipeline)
+	if !ok {
+		pipeline = d.pipeline.Load()
+	}
+	runPostResponsePlugins(ctx, pipeline.postResponsePlugins, reqCtx.SchedulingRequest, response, reqCtx.TargetPod)
+
+	if reqCtx.SchedulingTrace != nil {
+		schedulingTrace, err := json.Marshal(reqCtx.SchedulingTrace)
+		if err != nil {
+			return reqCtx, err
+		}
+		reqCtx.Response.Headers[requtil.SchedulingTraceHeaderKey] = string(schedulingTrace)
+	}
 
 	return reqCtx, nil
 }
@@ -255,21 +334,26 @@ func RandomWeightedDraw(logger logr.Logger, model *v1alpha2.InferenceModel, seed
 	return ""
 }
 
-func (d *Director) runPreRequestPlugins(ctx context.Context, request *schedulingtypes.LLMRequest, schedulingResult *schedulingtypes.SchedulingResult,
-	targetPort int) {
-	for _, plugin := range d.preRequestPlugins {
+func runPreRequestPlugins(ctx context.Context, plugins []PreRequest, request *schedulingtypes.LLMRequest,
+	schedulingResult *schedulingtypes.SchedulingResult, targetPort int) {
+	for _, plugin := range plugins {
 		log.FromContext(ctx).V(logutil.DEBUG).Info("Running pre-request plugin", "plugin", plugin.Type())
+		spanCtx, span := tracing.StartPluginSpan(ctx, PreRequestPluginType, plugin)
 		before := time.Now()
-		plugin.PreRequest(ctx, request, schedulingResult, targetPort)
+		plugin.PreRequest(spanCtx, request, schedulingResult, targetPort)
 		metrics.RecordRequestControlPluginProcessingLatency(PreRequestPluginType, plugin.Type(), time.Since(before))
+		span.End()
 	}
 }
 
-func (d *Director) runPostResponsePlugins(ctx context.Context, request *schedulingtypes.LLMRequest, response *Response, targetPod *backend.Pod) {
-	for _, plugin := range d.postResponsePlugins {
+func runPostResponsePlugins(ctx context.Context, plugins []PostResponse, request *schedulingtypes.LLMRequest, response *Response,
+	targetPod *backend.Pod) {
+	for _, plugin := range plugins {
 		log.FromContext(ctx).V(logutil.DEBUG).Info("Running post-response plugin", "plugin", plugin.Type())
+		spanCtx, span := tracing.StartPluginSpan(ctx, PostResponsePluginType, plugin)
 		before := time.Now()
-		plugin.PostResponse(ctx, request, response, targetPod)
+		plugin.PostResponse(spanCtx, request, response, targetPod)
 		metrics.RecordRequestControlPluginProcessingLatency(PostResponsePluginType, plugin.Type(), time.Since(before))
+		span.End()
 	}
 }
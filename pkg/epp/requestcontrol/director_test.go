@@ -142,6 +142,27 @@ func TestDirector_HandleRequest(t *testing.T) {
 		PrimaryProfileName: "testProfile",
 	}
 
+	targetPod := defaultSuccessfulScheduleResults.ProfileResults["testProfile"].TargetPod
+	rankedScheduleResults := &schedulingtypes.SchedulingResult{
+		ProfileResults: map[string]*schedulingtypes.ProfileRunResult{
+			"testProfile": {
+				TargetPod: targetPod,
+				RankedPods: []schedulingtypes.Pod{
+					targetPod,
+					&schedulingtypes.ScoredPod{
+						Pod: &schedulingtypes.PodMetrics{
+							Pod: &backend.Pod{
+								Address:        "192.168.1.101",
+								NamespacedName: k8stypes.NamespacedName{Name: "pod2", Namespace: "default"},
+							},
+						},
+					},
+				},
+			},
+		},
+		PrimaryProfileName: "testProfile",
+	}
+
 	tests := []struct {
 		name                   string
 		reqBodyMap             map[string]interface{}
@@ -172,6 +193,27 @@ func TestDirector_HandleRequest(t *testing.T) {
 			},
 			wantMutatedBodyModel: model,
 		},
+		{
+			name: "successful request with ranked pods",
+			reqBodyMap: map[string]interface{}{
+				"model":  model,
+				"prompt": "critical prompt",
+			},
+			schedulerMockSetup: func(m *mockScheduler) {
+				m.scheduleResults = rankedScheduleResults
+			},
+			wantReqCtx: &handlers.RequestContext{
+				Model:               model,
+				ResolvedTargetModel: model,
+				TargetPod: &backend.Pod{
+					NamespacedName: types.NamespacedName{Namespace: "default", Name: "pod1"},
+					Address:        "192.168.1.100",
+				},
+				TargetEndpoint:    "192.168.1.100:8000",
+				FallbackEndpoints: []string{"192.168.1.101:8000"},
+			},
+			wantMutatedBodyModel: model,
+		},
 		{
 			name: "successful chat completions request (critical, saturation ignored)",
 			reqBodyMap: map[string]interface{}{
@@ -342,6 +384,20 @@ func TestDirector_HandleRequest(t *testing.T) {
 			},
 			wantErrCode: errutil.Internal,
 		},
+		{
+			name: "scheduler returns a result without target pod",
+			reqBodyMap: map[string]interface{}{
+				"model":  model,
+				"prompt": "prompt for an empty profile result",
+			},
+			schedulerMockSetup: func(m *mockScheduler) {
+				m.scheduleResults = &schedulingtypes.SchedulingResult{
+					ProfileResults:     map[string]*schedulingtypes.ProfileRunResult{"testProfile": {}},
+					PrimaryProfileName: "testProfile",
+				}
+			},
+			wantErrCode: errutil.Internal,
+		},
 	}
 
 	for _, test := range tests {
@@ -385,6 +441,7 @@ func TestDirector_HandleRequest(t *testing.T) {
 					"reqCtx.ResolvedTargetModel mismatch")
 				assert.Equal(t, test.wantReqCtx.TargetPod, returnedReqCtx.TargetPod, "reqCtx.TargetPod mismatch")
 				assert.Equal(t, test.wantReqCtx.TargetEndpoint, returnedReqCtx.TargetEndpoint, "reqCtx.TargetEndpoint mismatch")
+				assert.Equal(t, test.wantReqCtx.FallbackEndpoints, returnedReqCtx.FallbackEndpoints, "reqCtx.FallbackEndpoints mismatch")
 			}
 
 			if test.wantMutatedBodyModel != "" {
@@ -515,6 +572,23 @@ func TestGetRandomPod(t *testing.T) {
 	}
 }
 
+func TestWithKnownMetrics(t *testing.T) {
+	known := &backendmetrics.FakePodMetrics{
+		Pod:     &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "known"}},
+		Metrics: &backendmetrics.MetricsState{WaitingQueueSize: 3, UpdateTime: time.Now()},
+	}
+	unknown := &backendmetrics.FakePodMetrics{
+		Pod:     &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "unknown"}},
+		Metrics: &backendmetrics.MetricsState{},
+	}
+
+	got := withKnownMetrics([]backendmetrics.PodMetrics{unknown, known})
+	assert.Equal(t, []backendmetrics.PodMetrics{known}, got, "pods with unknown metrics should be excluded")
+
+	got = withKnownMetrics([]backendmetrics.PodMetrics{unknown})
+	assert.Equal(t, []backendmetrics.PodMetrics{unknown}, got, "all pods should be kept if none has known metrics")
+}
+
 func pointer(v int32) *int32 {
 	return &v
 }
@@ -571,3 +645,143 @@ func (p *testPostResponse) PostResponse(_ context.Context, _ *schedulingtypes.LL
 	p.lastRespOnResponse = response
 	p.lastTargetPodOnResponse = targetPod.NamespacedName.String()
 }
+
+func TestDirector_SchedulingTraceHeader(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	ds := datastore.NewDatastore(t.Context(), backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second))
+	pool := &v1alpha2.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
+		Spec:       v1alpha2.InferencePoolSpec{TargetPortNumber: int32(8000)},
+	}
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	if err := ds.PoolSet(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), pool); err != nil {
+		t.Fatalf("Error while setting inference pool: %v", err)
+	}
+
+	decisionTrace := schedulingtypes.NewDecisionTrace()
+	decisionTrace.StartProfile("testProfile").CandidatePods = []string{"default/pod1"}
+	mockSched := &mockScheduler{
+		scheduleResults: &schedulingtypes.SchedulingResult{
+			ProfileResults: map[string]*schedulingtypes.ProfileRunResult{
+				"testProfile": {
+					TargetPod: &schedulingtypes.PodMetrics{
+						Pod: &backend.Pod{Address: "192.168.1.100", NamespacedName: k8stypes.NamespacedName{Name: "pod1", Namespace: "default"}},
+					},
+				},
+			},
+			PrimaryProfileName: "testProfile",
+			DecisionTrace:      decisionTrace,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		headerEnabled  bool
+		requestHeaders map[string]string
+		wantHeader     string
+	}{
+		{
+			name:           "enabled and requested",
+			headerEnabled:  true,
+			requestHeaders: map[string]string{requtil.SchedulingTraceHeaderKey: "true"},
+			wantHeader:     `{"profiles":[{"name":"testProfile","candidatePods":["default/pod1"]}]}`,
+		},
+		{
+			name:           "enabled but not requested",
+			headerEnabled:  true,
+			requestHeaders: map[string]string{},
+		},
+		{
+			name:           "requested but disabled",
+			requestHeaders: map[string]string{requtil.SchedulingTraceHeaderKey: "true"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			director := NewDirectorWithConfig(ds, mockSched, &mockSaturationDetector{}, NewConfig().WithSchedulingTraceHeader(test.headerEnabled))
+			reqCtx := &handlers.RequestContext{
+				Request: &handlers.Request{
+					Body:    map[string]interface{}{"model": "food-review", "prompt": "test prompt"},
+					Headers: test.requestHeaders,
+				},
+				Response: &handlers.Response{Headers: map[string]string{}},
+			}
+			if _, err := director.HandleRequest(ctx, reqCtx); err != nil {
+				t.Fatalf("HandleRequest() returned unexpected error: %v", err)
+			}
+			if _, err := director.HandleResponse(ctx, reqCtx); err != nil {
+				t.Fatalf("HandleResponse() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(test.wantHeader, reqCtx.Response.Headers[requtil.SchedulingTraceHeaderKey]); diff != "" {
+				t.Errorf("Unexpected scheduling trace header (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestDirector_Update(t *testing.T) {
+	ctx := logutil.NewTestLoggerIntoContext(context.Background())
+	ds := datastore.NewDatastore(t.Context(), backendmetrics.NewPodMetricsFactory(&backendmetrics.FakePodMetricsClient{}, time.Second))
+	pool := &v1alpha2.InferencePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pool", Namespace: "default"},
+		Spec:       v1alpha2.InferencePoolSpec{TargetPortNumber: int32(8000)},
+	}
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	if err := ds.PoolSet(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), pool); err != nil {
+		t.Fatalf("Error while setting inference pool: %v", err)
+	}
+	newRequestContext := func() *handlers.RequestContext {
+		return &handlers.RequestContext{
+			Request: &handlers.Request{
+				Body:    map[string]interface{}{"model": "food-review", "prompt": "test prompt"},
+				Headers: map[string]string{},
+			},
+		}
+	}
+
+	director := NewDirectorWithConfig(ds, &mockScheduler{scheduleErr: errors.New("no pods")}, &mockSaturationDetector{}, NewConfig())
+	if _, err := director.HandleRequest(ctx, newRequestContext()); err == nil {
+		t.Fatalf("Expected the initial scheduler to fail the request")
+	}
+
+	scheduler := &mockScheduler{
+		scheduleResults: &schedulingtypes.SchedulingResult{
+			ProfileResults: map[string]*schedulingtypes.ProfileRunResult{
+				"testProfile": {
+					TargetPod: &schedulingtypes.PodMetrics{
+						Pod: &backend.Pod{Address: "192.168.1.100", NamespacedName: k8stypes.NamespacedName{Name: "pod1", Namespace: "default"}},
+					},
+				},
+			},
+			PrimaryProfileName: "testProfile",
+		},
+	}
+	director.Update(scheduler, NewConfig())
+	reqCtx, err := director.HandleRequest(ctx, newRequestContext())
+	if err != nil {
+		t.Fatalf("HandleRequest() returned unexpected error after the update: %v", err)
+	}
+	if diff := cmp.Diff("192.168.1.100:8000", reqCtx.TargetEndpoint); diff != "" {
+		t.Errorf("Unexpected target endpoint (-want +got): %s", diff)
+	}
+
+	// The response of a request handled before an update is handled by the plugins the request was handled with.
+	before := &testPostResponse{TypeRes: "before"}
+	after := &testPostResponse{TypeRes: "after"}
+	director.Update(scheduler, NewConfig().WithPostResponsePlugins(before))
+	reqCtx, err = director.HandleRequest(ctx, newRequestContext())
+	if err != nil {
+		t.Fatalf("HandleRequest() returned unexpected error: %v", err)
+	}
+	director.Update(scheduler, NewConfig().WithPostResponsePlugins(after))
+	reqCtx.Response = &handlers.Response{Headers: map[string]string{}}
+	if _, err := director.HandleResponse(ctx, reqCtx); err != nil {
+		t.Fatalf("HandleResponse() returned unexpected error: %v", err)
+	}
+	if before.lastRespOnResponse == nil || after.lastRespOnResponse != nil {
+		t.Error("Expected the response to be handled by the plugins of the configuration the request was handled with")
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package requestcontrol
 
 import (
+	"slices"
+
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 )
 
@@ -30,8 +32,9 @@ func NewConfig() *Config {
 
 // Config provides a configuration for the requestcontrol plugins.
 type Config struct {
-	preRequestPlugins   []PreRequest
-	postResponsePlugins []PostResponse
+	preRequestPlugins     []PreRequest
+	postResponsePlugins   []PostResponse
+	schedulingTraceHeader bool
 }
 
 // WithPreRequestPlugins sets the given plugins as the PreRequest plugins.
@@ -48,6 +51,23 @@ func (c *Config) WithPostResponsePlugins(plugins ...PostResponse) *Config {
 	return c
 }
 
+// WithSchedulingTraceHeader sets whether the scheduling decision trace is returned in the x-gateway-scheduling-trace
+// response header of the requests that ask for it. The trace exposes the pods of the pool and their scores, so this
+// is meant for debugging only.
+func (c *Config) WithSchedulingTraceHeader(enabled bool) *Config {
+	c.schedulingTraceHeader = enabled
+	return c
+}
+
+// Clone returns a copy of the Config, which can be extended without modifying the original one.
+func (c *Config) Clone() *Config {
+	return &Config{
+		preRequestPlugins:     slices.Clone(c.preRequestPlugins),
+		postResponsePlugins:   slices.Clone(c.postResponsePlugins),
+		schedulingTraceHeader: c.schedulingTraceHeader,
+	}
+}
+
 func (c *Config) AddPlugins(pluginObjects ...plugins.Plugin) {
 	for _, plugin := range pluginObjects {
 		if preRequestPlugin, ok := plugin.(PreRequest); ok {
@@ -17,9 +17,9 @@ package saturationdetector
 
 import (
 	"fmt"
-	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
 	commonconfig "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/common/config"
 	envutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/env"
 )
@@ -30,9 +30,7 @@ const (
 	DefaultKVCacheUtilThreshold = commonconfig.DefaultKVCacheThreshold
 	// DefaultMetricsStalenessThreshold defines how old metrics can be before they
 	// are considered stale.
-	// Given the pod metrics refresh interval is 50ms, a threshold slightly above
-	// that should be fine.
-	DefaultMetricsStalenessThreshold = 200 * time.Millisecond
+	DefaultMetricsStalenessThreshold = commonconfig.DefaultMetricsStalenessThreshold
 )
 
 // Environment variable names for SaturationDetector configuration
@@ -43,9 +41,13 @@ const (
 )
 
 // LoadConfigFromEnv loads SaturationDetector Config from environment variables.
+// Deprecated: the environment variables are replaced by the saturationDetector section of the EndpointPickerConfig,
+// see ApplyAPIConfig.
 func LoadConfigFromEnv() *Config {
 	// Use a default logger for initial configuration loading.
 	logger := log.Log.WithName("saturation-detector-config")
+	envutil.WarnDeprecated(logger, "the saturationDetector section of the EndpointPickerConfig",
+		EnvSdQueueDepthThreshold, EnvSdKVCacheUtilThreshold, EnvSdMetricsStalenessThreshold)
 
 	cfg := &Config{}
 
@@ -68,3 +70,22 @@ func LoadConfigFromEnv() *Config {
 	logger.Info("SaturationDetector configuration loaded from env", "config", fmt.Sprintf("%+v", cfg))
 	return cfg
 }
+
+// ApplyAPIConfig overrides the given Config with the fields that are set in the saturationDetector section of the
+// EndpointPickerConfig, which the configuration loader has validated.
+func ApplyAPIConfig(cfg *Config, apiConfig *configapi.SaturationDetectorConfig) *Config {
+	if apiConfig == nil {
+		return cfg
+	}
+	result := *cfg
+	if apiConfig.QueueDepthThreshold != nil {
+		result.QueueDepthThreshold = *apiConfig.QueueDepthThreshold
+	}
+	if apiConfig.KVCacheUtilThreshold != nil {
+		result.KVCacheUtilThreshold = apiConfig.KVCacheUtilThreshold.AsApproximateFloat64()
+	}
+	if apiConfig.MetricsStalenessThreshold != nil {
+		result.MetricsStalenessThreshold = apiConfig.MetricsStalenessThreshold.Duration
+	}
+	return &result
+}
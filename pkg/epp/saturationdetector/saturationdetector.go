@@ -32,6 +32,7 @@ package saturationdetector
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -77,7 +78,9 @@ type Datastore interface {
 // more beneficial.
 type Detector struct {
 	datastore Datastore
-	config    *Config
+	// config is swapped as a whole when the configuration is reloaded.
+	config atomic.Pointer[Config]
+	logger logr.Logger
 }
 
 // NewDetector creates a new SaturationDetector.
@@ -90,10 +93,21 @@ func NewDetector(config *Config, datastore Datastore, logger logr.Logger) *Detec
 		"kvCacheUtilThreshold", config.KVCacheUtilThreshold,
 		"metricsStalenessThreshold", config.MetricsStalenessThreshold.String())
 
-	return &Detector{
+	detector := &Detector{
 		datastore: datastore,
-		config:    config,
+		logger:    logger,
 	}
+	detector.config.Store(config)
+	return detector
+}
+
+// UpdateConfig atomically replaces the thresholds of the Detector, e.g. when the configuration is reloaded.
+func (d *Detector) UpdateConfig(config *Config) {
+	d.logger.WithName(loggerName).V(logutil.DEFAULT).Info("Updating SaturationDetector",
+		"queueDepthThreshold", config.QueueDepthThreshold,
+		"kvCacheUtilThreshold", config.KVCacheUtilThreshold,
+		"metricsStalenessThreshold", config.MetricsStalenessThreshold.String())
+	d.config.Store(config)
 }
 
 // IsSaturated checks if the system is currently considered saturated.
@@ -107,6 +121,7 @@ func NewDetector(config *Config, datastore Datastore, logger logr.Logger) *Detec
 // (no capacity).
 func (d *Detector) IsSaturated(ctx context.Context) bool {
 	logger := log.FromContext(ctx).WithName(loggerName)
+	config := d.config.Load()
 	allPodsMetrics := d.datastore.PodGetAll()
 	if len(allPodsMetrics) == 0 {
 		logger.V(logutil.VERBOSE).Info("No pods found in datastore; system is considered SATURATED (no capacity).")
@@ -129,28 +144,28 @@ func (d *Detector) IsSaturated(ctx context.Context) bool {
 		}
 
 		// Check for metric staleness
-		if time.Since(metrics.UpdateTime) > d.config.MetricsStalenessThreshold {
+		if metrics.IsStale(config.MetricsStalenessThreshold) {
 			logger.V(logutil.TRACE).Info("Pod metrics are stale, considered as not having good capacity",
-				"pod", podNn, "updateTime", metrics.UpdateTime, "stalenessThreshold", d.config.MetricsStalenessThreshold)
+				"pod", podNn, "updateTime", metrics.UpdateTime, "stalenessThreshold", config.MetricsStalenessThreshold)
 			continue
 		}
 
 		// Check queue depth
-		if metrics.WaitingQueueSize > d.config.QueueDepthThreshold {
+		if metrics.WaitingQueueSize > config.QueueDepthThreshold {
 			logger.V(logutil.TRACE).Info("Pod WaitingQueueSize is above threshold, considered as not having good capacity",
-				"pod", podNn, "waitingQueueSize", metrics.WaitingQueueSize, "threshold", d.config.QueueDepthThreshold)
+				"pod", podNn, "waitingQueueSize", metrics.WaitingQueueSize, "threshold", config.QueueDepthThreshold)
 			continue // WaitingQueueSize is above threshold, considered saturated.
 		}
 
 		// Check KV cache utilization
-		if metrics.KVCacheUsagePercent > d.config.KVCacheUtilThreshold {
+		if metrics.KVCacheUsagePercent > config.KVCacheUtilThreshold {
 			logger.V(logutil.TRACE).Info("Pod KVCacheUsagePercent is above threshold, considered as not having good capacity",
-				"pod", podNn, "kvCacheUsagePercent", metrics.KVCacheUsagePercent, "threshold", d.config.KVCacheUtilThreshold)
+				"pod", podNn, "kvCacheUsagePercent", metrics.KVCacheUsagePercent, "threshold", config.KVCacheUtilThreshold)
 			continue // KVCacheUsagePercent is above threshold, considered saturated.
 		}
 
 		logger.V(logutil.TRACE).Info("Found pod with good capacity", "pod", podNn, "waitingQueue", metrics.WaitingQueueSize,
-			"queueThreshold", d.config.QueueDepthThreshold, "kvCacheUtil", metrics.KVCacheUsagePercent, "kvCacheThreshold", d.config.KVCacheUtilThreshold)
+			"queueThreshold", config.QueueDepthThreshold, "kvCacheUtil", metrics.KVCacheUsagePercent, "kvCacheThreshold", config.KVCacheUtilThreshold)
 
 		return false // Found at least one pod with good capacity, so system is NOT saturated.
 	}
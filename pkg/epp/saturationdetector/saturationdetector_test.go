@@ -25,7 +25,11 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 )
@@ -114,14 +118,68 @@ func TestNewDetector(t *testing.T) {
 			if detector == nil {
 				t.Fatalf("NewDetector() returned nil detector for valid config")
 			}
-			if detector.config.QueueDepthThreshold != test.expectedQueueDepthThreshold {
-				t.Errorf("NewDetector() QueueDepthThreshold = %d, want %d", detector.config.QueueDepthThreshold, test.expectedQueueDepthThreshold)
+			if detector.config.Load().QueueDepthThreshold != test.expectedQueueDepthThreshold {
+				t.Errorf("NewDetector() QueueDepthThreshold = %d, want %d", detector.config.Load().QueueDepthThreshold, test.expectedQueueDepthThreshold)
 			}
-			if detector.config.KVCacheUtilThreshold != test.expectedKVCacheUtilThreshold {
-				t.Errorf("NewDetector() KVCacheUtilThreshold = %f, want %f", detector.config.KVCacheUtilThreshold, test.expectedKVCacheUtilThreshold)
+			if detector.config.Load().KVCacheUtilThreshold != test.expectedKVCacheUtilThreshold {
+				t.Errorf("NewDetector() KVCacheUtilThreshold = %f, want %f", detector.config.Load().KVCacheUtilThreshold, test.expectedKVCacheUtilThreshold)
 			}
-			if detector.config.MetricsStalenessThreshold != test.expectedStalenessThreshold {
-				t.Errorf("NewDetector() MetricsStalenessThreshold = %v, want %v", detector.config.MetricsStalenessThreshold, test.expectedStalenessThreshold)
+			if detector.config.Load().MetricsStalenessThreshold != test.expectedStalenessThreshold {
+				t.Errorf("NewDetector() MetricsStalenessThreshold = %v, want %v", detector.config.Load().MetricsStalenessThreshold, test.expectedStalenessThreshold)
+			}
+		})
+	}
+}
+
+func TestApplyAPIConfig(t *testing.T) {
+	envConfig := &Config{
+		QueueDepthThreshold:       DefaultQueueDepthThreshold,
+		KVCacheUtilThreshold:      DefaultKVCacheUtilThreshold,
+		MetricsStalenessThreshold: DefaultMetricsStalenessThreshold,
+	}
+	queueDepthThreshold := 10
+	kvCacheUtilThreshold := resource.MustParse("0.5")
+
+	tests := []struct {
+		name      string
+		apiConfig *configapi.SaturationDetectorConfig
+		want      *Config
+	}{
+		{
+			name: "no section",
+			want: envConfig,
+		},
+		{
+			name: "all fields set",
+			apiConfig: &configapi.SaturationDetectorConfig{
+				QueueDepthThreshold:       &queueDepthThreshold,
+				KVCacheUtilThreshold:      &kvCacheUtilThreshold,
+				MetricsStalenessThreshold: &metav1.Duration{Duration: time.Second},
+			},
+			want: &Config{
+				QueueDepthThreshold:       10,
+				KVCacheUtilThreshold:      0.5,
+				MetricsStalenessThreshold: time.Second,
+			},
+		},
+		{
+			name: "some fields set",
+			apiConfig: &configapi.SaturationDetectorConfig{
+				KVCacheUtilThreshold: &kvCacheUtilThreshold,
+			},
+			want: &Config{
+				QueueDepthThreshold:       DefaultQueueDepthThreshold,
+				KVCacheUtilThreshold:      0.5,
+				MetricsStalenessThreshold: DefaultMetricsStalenessThreshold,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ApplyAPIConfig(envConfig, test.apiConfig)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Unexpected config (-want +got): %s", diff)
 			}
 		})
 	}
@@ -25,6 +25,7 @@ import (
 
 const (
 	ProfilePickerType          = "ProfilePicker"
+	ProfileHandlerPluginType   = "ProfileHandler"
 	FilterPluginType           = "Filter"
 	ScorerPluginType           = "Scorer"
 	PickerPluginType           = "Picker"
@@ -49,6 +50,15 @@ type ProfileHandler interface {
 		profileResults map[string]*types.ProfileRunResult) (*types.SchedulingResult, error)
 }
 
+// ShadowProfileHandler is implemented by the profile handlers that run shadow profiles, to evaluate scheduling
+// algorithms without affecting routing. A shadow profile runs on the same candidate pods as the other profiles, on a
+// copy of the CycleState, and without its PostCycle plugins.
+type ShadowProfileHandler interface {
+	ProfileHandler
+	// IsShadowProfile returns true if the profile with the given name is a shadow profile.
+	IsShadowProfile(name string) bool
+}
+
 // Filter defines the interface for filtering a list of pods based on context.
 type Filter interface {
 	plugins.Plugin
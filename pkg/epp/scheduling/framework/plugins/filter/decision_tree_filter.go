@@ -18,15 +18,48 @@ package filter
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
+const (
+	DecisionTreeFilterType = "decision-tree"
+)
+
+type decisionTreeFilterParameters struct {
+	Current                string `json:"current" description:"The name of the filter applied first. Required."`
+	NextOnSuccess          string `json:"nextOnSuccess,omitempty" description:"The name of the filter applied to the pods kept by the current filter, if any."`
+	NextOnFailure          string `json:"nextOnFailure,omitempty" description:"The name of the filter applied to the input pods, if the current filter kept no pod."`
+	NextOnSuccessOrFailure string `json:"nextOnSuccessOrFailure,omitempty" description:"The name of the filter applied next, unless overridden by nextOnSuccess or nextOnFailure."`
+}
+
+// DecisionTreeFilterParameterSchema is the schema of the parameters of DecisionTreeFilter.
+var DecisionTreeFilterParameterSchema = plugins.NewParameterSchema(decisionTreeFilterParameters{})
+
 // compile-time type assertion
 var _ framework.Filter = &DecisionTreeFilter{}
+var _ plugins.ReferencingPlugin = &DecisionTreeFilter{}
+
+// DecisionTreeFilterFactory defines the factory function for a DecisionTreeFilter whose filters are other plugin
+// instances, referenced by name. The references are resolved by ResolveReferences.
+func DecisionTreeFilterFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := decisionTreeFilterParameters{}
+	if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+		return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", DecisionTreeFilterType, err)
+	}
+	if parameters.Current == "" {
+		return nil, errors.New("the current filter of the decision tree is not set")
+	}
+
+	return &DecisionTreeFilter{name: name, references: &parameters}, nil
+}
 
 // DecisionTreeFilter applies current fitler, and then recursively applies next filters
 // depending success or failure of the current filter.
@@ -45,24 +78,84 @@ type DecisionTreeFilter struct {
 	// However if that's not the case, nextOnSuccess and nextOnFailure will be used, instead of
 	// NextOnSuccessOrFailure, in the success and failure scenarios, respectively.
 	NextOnSuccessOrFailure framework.Filter
+
+	// name and references are only set when the filter is instantiated from the configuration.
+	name       string
+	references *decisionTreeFilterParameters
 }
 
-// Type returns the type of the filter.
+// Type returns the type of the filter. The type of a filter built in code is the type of its current filter.
 func (f *DecisionTreeFilter) Type() string {
 	if f == nil {
 		return "nil"
 	}
+	if f.references != nil {
+		return DecisionTreeFilterType
+	}
 	return f.Current.Type()
 }
 
-// Name returns the name of the filter.
+// Name returns the name of the filter. The name of a filter built in code is the name of its current filter.
 func (f *DecisionTreeFilter) Name() string {
 	if f == nil {
 		return ""
 	}
+	if f.references != nil {
+		return f.name
+	}
 	return f.Current.Name()
 }
 
+// References returns the names of the filters referenced by the configuration of the filter.
+func (f *DecisionTreeFilter) References() []string {
+	if f.references == nil {
+		return nil
+	}
+	references := []string{}
+	for _, reference := range []string{f.references.Current, f.references.NextOnSuccess, f.references.NextOnFailure,
+		f.references.NextOnSuccessOrFailure} {
+		if reference != "" {
+			references = append(references, reference)
+		}
+	}
+	return references
+}
+
+// ResolveReferences sets the filters of the decision tree to the referenced plugin instances.
+func (f *DecisionTreeFilter) ResolveReferences(handle plugins.Handle) error {
+	if f.references == nil {
+		return nil
+	}
+	var err error
+	if f.Current, err = resolveFilter(handle, f.references.Current); err != nil {
+		return err
+	}
+	if f.NextOnSuccess, err = resolveFilter(handle, f.references.NextOnSuccess); err != nil {
+		return err
+	}
+	if f.NextOnFailure, err = resolveFilter(handle, f.references.NextOnFailure); err != nil {
+		return err
+	}
+	f.NextOnSuccessOrFailure, err = resolveFilter(handle, f.references.NextOnSuccessOrFailure)
+	return err
+}
+
+// resolveFilter returns the filter with the given name, or nil if the name is empty.
+func resolveFilter(handle plugins.Handle, name string) (framework.Filter, error) {
+	if name == "" {
+		return nil, nil
+	}
+	thePlugin := handle.Plugins().Plugin(name)
+	if thePlugin == nil {
+		return nil, fmt.Errorf("the plugin '%s' referenced by the decision tree does not exist", name)
+	}
+	theFilter, ok := thePlugin.(framework.Filter)
+	if !ok {
+		return nil, fmt.Errorf("the plugin '%s' referenced by the decision tree is not a filter", name)
+	}
+	return theFilter, nil
+}
+
 // Filter filters out pods that doesn't meet the filter criteria.
 func (f *DecisionTreeFilter) Filter(ctx context.Context, cycleState *types.CycleState, request *types.LLMRequest, pods []types.Pod) []types.Pod {
 	loggerTrace := log.FromContext(ctx).V(logutil.TRACE)
@@ -19,13 +19,13 @@ package filter
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
-	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/config"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
 )
@@ -161,17 +161,8 @@ func TestLoRASoftAffinityDistribution(t *testing.T) {
 		tolerancePercent  = 5.0 // Allow 5% tolerance from expected distribution
 	)
 
-	// Save original config value to restore later
-	originalThreshold := config.Conf.LoraAffinityThreshold
-
 	// Set a specific test value for this test
 	testThreshold := 0.75 // 75%
-	config.Conf.LoraAffinityThreshold = testThreshold
-
-	// Ensure we restore the original threshold when test completes
-	defer func() {
-		config.Conf.LoraAffinityThreshold = originalThreshold
-	}()
 
 	// Create a test request and pods
 	req := &types.LLMRequest{
@@ -203,11 +194,11 @@ func TestLoRASoftAffinityDistribution(t *testing.T) {
 	availableCount := 0
 
 	// Use the test threshold value
-	expectedAffinityPercent := config.Conf.LoraAffinityThreshold * 100
+	expectedAffinityPercent := testThreshold * 100
 	expectedAvailabilityPercent := 100 - expectedAffinityPercent
 
 	// initialize LoraAffinityFilter
-	LoraAffinityFilter := NewLoraAffinityFilter(config.Conf.LoraAffinityThreshold)
+	LoraAffinityFilter := NewLoraAffinityFilter(testThreshold)
 
 	for range numIterations {
 		result := LoraAffinityFilter.Filter(context.Background(), types.NewCycleState(), req, pods)
@@ -237,8 +228,8 @@ func TestLoRASoftAffinityDistribution(t *testing.T) {
 	availableUpperBound := expectedAvailabilityPercent + tolerancePercent
 
 	t.Logf("Distribution results over %d iterations:", numIterations)
-	t.Logf("Expected affinity percent: %.2f%% (threshold: %.2f)", expectedAffinityPercent, config.Conf.LoraAffinityThreshold)
-	t.Logf("Expected availability percent: %.2f%% (threshold: %.2f)", expectedAvailabilityPercent, config.Conf.LoraAffinityThreshold)
+	t.Logf("Expected affinity percent: %.2f%% (threshold: %.2f)", expectedAffinityPercent, testThreshold)
+	t.Logf("Expected availability percent: %.2f%% (threshold: %.2f)", expectedAvailabilityPercent, testThreshold)
 	t.Logf("Actual affinity percent: %.2f%% (%d out of %d)", actualAffinityPercent, affinityCount, numIterations)
 	t.Logf("Actual available percent: %.2f%% (%d out of %d)", actualAvailablePercent, availableCount, numIterations)
 
@@ -251,3 +242,71 @@ func TestLoRASoftAffinityDistribution(t *testing.T) {
 			actualAvailablePercent, availableLowerBound, availableUpperBound)
 	}
 }
+
+func TestDecisionTreeFilterFactory(t *testing.T) {
+	if _, err := DecisionTreeFilterFactory("tree", []byte(`{"nextOnSuccess": "next"}`), nil); err == nil {
+		t.Error("DecisionTreeFilterFactory did not return an expected error for a missing current filter")
+	}
+
+	thePlugin, err := DecisionTreeFilterFactory("tree", []byte(`{"current": "first", "nextOnSuccessOrFailure": "next"}`), nil)
+	if err != nil {
+		t.Fatalf("DecisionTreeFilterFactory returned unexpected error: %v", err)
+	}
+	tree := thePlugin.(*DecisionTreeFilter)
+	if tree.Type() != DecisionTreeFilterType || tree.Name() != "tree" {
+		t.Errorf("Unexpected type %s and name %s", tree.Type(), tree.Name())
+	}
+	if diff := cmp.Diff([]string{"first", "next"}, tree.References()); diff != "" {
+		t.Errorf("Unexpected references (-want +got): %s", diff)
+	}
+}
+
+func TestMetricsStalenessFilter(t *testing.T) {
+	now := time.Now()
+	fresh := &types.PodMetrics{MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: 3, UpdateTime: now}}
+	stale := &types.PodMetrics{MetricsState: &backendmetrics.MetricsState{WaitingQueueSize: 1, UpdateTime: now.Add(-time.Second)}}
+	unknown := &types.PodMetrics{MetricsState: &backendmetrics.MetricsState{}}
+
+	tests := []struct {
+		name   string
+		input  []types.Pod
+		output []types.Pod
+	}{
+		{
+			name:   "stale and unknown pods are filtered out",
+			input:  []types.Pod{stale, fresh, unknown},
+			output: []types.Pod{fresh},
+		},
+		{
+			name:   "all pods are kept if none is fresh",
+			input:  []types.Pod{stale, unknown},
+			output: []types.Pod{stale, unknown},
+		},
+	}
+
+	filter := NewMetricsStalenessFilter(200 * time.Millisecond)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := filter.Filter(context.Background(), types.NewCycleState(), &types.LLMRequest{}, test.input)
+
+			if diff := cmp.Diff(test.output, got); diff != "" {
+				t.Errorf("Unexpected output (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestMetricsStalenessFilterFactory(t *testing.T) {
+	if _, err := MetricsStalenessFilterFactory("staleness", []byte(`{"threshold": "-1s"}`), nil); err == nil {
+		t.Error("MetricsStalenessFilterFactory did not return an expected error for a negative threshold")
+	}
+
+	thePlugin, err := MetricsStalenessFilterFactory("staleness", []byte(`{"threshold": "1s"}`), nil)
+	if err != nil {
+		t.Fatalf("MetricsStalenessFilterFactory returned unexpected error: %v", err)
+	}
+	filter := thePlugin.(*MetricsStalenessFilter)
+	if filter.Name() != "staleness" || filter.threshold != time.Second {
+		t.Errorf("Unexpected name %s and threshold %v", filter.Name(), filter.threshold)
+	}
+}
@@ -34,9 +34,12 @@ const (
 )
 
 type loraAffinityFilterParameters struct {
-	Threshold float64 `json:"threshold"`
+	Threshold float64 `json:"threshold" description:"The probability, between 0 and 1, of picking a pod with the LoRA adapter loaded over a pod that has capacity to load it."`
 }
 
+// LoraAffinityFilterParameterSchema is the schema of the parameters of LoraAffinityFilter.
+var LoraAffinityFilterParameterSchema = plugins.NewParameterSchema(loraAffinityFilterParameters{Threshold: config.DefaultLoraAffinityThreshold})
+
 // compile-time type validation
 var _ framework.Filter = &LoraAffinityFilter{}
 
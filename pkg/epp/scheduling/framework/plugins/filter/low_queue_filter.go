@@ -33,9 +33,12 @@ const (
 )
 
 type lowQueueFilterParameters struct {
-	Threshold int `json:"threshold"`
+	Threshold int `json:"threshold" description:"The waiting queue size below which a pod is kept."`
 }
 
+// LowQueueFilterParameterSchema is the schema of the parameters of LowQueueFilter.
+var LowQueueFilterParameterSchema = plugins.NewParameterSchema(lowQueueFilterParameters{Threshold: config.DefaultQueueingThresholdLoRA})
+
 // compile-time type validation
 var _ framework.Filter = &LowQueueFilter{}
 
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/common/config"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	MetricsStalenessFilterType = "metrics-staleness"
+)
+
+type metricsStalenessFilterParameters struct {
+	Threshold string `json:"threshold" description:"The age beyond which the metrics of a pod are stale, as a duration, e.g. 200ms."`
+}
+
+// MetricsStalenessFilterParameterSchema is the schema of the parameters of MetricsStalenessFilter.
+var MetricsStalenessFilterParameterSchema = plugins.NewParameterSchema(
+	metricsStalenessFilterParameters{Threshold: config.DefaultMetricsStalenessThreshold.String()})
+
+// compile-time type validation
+var _ framework.Filter = &MetricsStalenessFilter{}
+
+// MetricsStalenessFilterFactory defines the factory function for MetricsStalenessFilter.
+func MetricsStalenessFilterFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := metricsStalenessFilterParameters{}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' filter - %w", MetricsStalenessFilterType, err)
+		}
+	}
+	threshold, err := config.ParseMetricsStalenessThreshold(parameters.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' filter - %w", MetricsStalenessFilterType, err)
+	}
+	return NewMetricsStalenessFilter(threshold).WithName(name), nil
+}
+
+// NewMetricsStalenessFilter initializes a new MetricsStalenessFilter and returns its pointer.
+func NewMetricsStalenessFilter(threshold time.Duration) *MetricsStalenessFilter {
+	return &MetricsStalenessFilter{
+		name:      MetricsStalenessFilterType,
+		threshold: threshold,
+	}
+}
+
+// MetricsStalenessFilter filters out the pods whose metrics are stale or unknown, so that the next plugins don't route
+// on outdated load. If no pod has fresh metrics, e.g. because the metrics of the whole pool can't be scraped, all the
+// pods are kept rather than failing the request.
+type MetricsStalenessFilter struct {
+	name      string
+	threshold time.Duration
+}
+
+// Type returns the type of the filter.
+func (f *MetricsStalenessFilter) Type() string {
+	return MetricsStalenessFilterType
+}
+
+// Name returns the name of the filter.
+func (f *MetricsStalenessFilter) Name() string {
+	return f.name
+}
+
+// WithName sets the name of the filter.
+func (f *MetricsStalenessFilter) WithName(name string) *MetricsStalenessFilter {
+	f.name = name
+	return f
+}
+
+// Filter filters out pods that doesn't meet the filter criteria.
+func (f *MetricsStalenessFilter) Filter(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) []types.Pod {
+	filteredPods := []types.Pod{}
+	for _, pod := range pods {
+		if !pod.GetMetrics().IsStale(f.threshold) {
+			filteredPods = append(filteredPods, pod)
+		}
+	}
+	if len(filteredPods) == 0 {
+		return pods
+	}
+	return filteredPods
+}
@@ -58,14 +58,21 @@ const (
 type Config struct {
 	// The input prompt is broken into sizes of HashBlockSize to calculate block hashes . Requests
 	// with length shorter than the block size will be ignored.
-	HashBlockSize int `json:"hashBlockSize"`
+	HashBlockSize int `json:"hashBlockSize" description:"The size, in characters, of the blocks of the prompt that are hashed."`
 	// MaxPrefixBlocksToMatch is the maximum number of prefix blocks to match. Input beyond this limit will
 	// be ignored.
-	MaxPrefixBlocksToMatch int `json:"maxPrefixBlocksToMatch"`
+	MaxPrefixBlocksToMatch int `json:"maxPrefixBlocksToMatch" description:"The maximum number of prefix blocks to match."`
 	// Max capacity size of the LRU indexer in number of entries per server (pod).
-	LRUCapacityPerServer int `json:"lruCapacityPerServer"`
+	LRUCapacityPerServer int `json:"lruCapacityPerServer" description:"The capacity of the LRU indexer, in number of entries per pod."`
 }
 
+// PrefixCachePluginParameterSchema is the schema of the parameters of the prefix Plugin.
+var PrefixCachePluginParameterSchema = plugins.NewParameterSchema(Config{
+	HashBlockSize:          DefaultHashBlockSize,
+	MaxPrefixBlocksToMatch: DefaultMaxPrefixBlocks,
+	LRUCapacityPerServer:   DefaultLRUCapacityPerServer,
+})
+
 type Plugin struct {
 	Config
 	name    string
@@ -212,6 +219,23 @@ func (m *Plugin) PostCycle(ctx context.Context, cycleState *types.CycleState, re
 	metrics.RecordPrefixCacheMatch(matchLen*m.HashBlockSize, total*m.HashBlockSize)
 }
 
+// PrefixMatchRatio returns the ratio of the prompt prefix blocks cached by the pod, as computed by the prefix cache
+// plugin in the scheduling cycle. It returns false if the plugin didn't run in the cycle.
+func PrefixMatchRatio(cycleState *types.CycleState, pod types.Pod) (float64, bool) {
+	data, err := cycleState.Read(types.StateKey(PrefixCachePluginType))
+	if err != nil {
+		return 0, false
+	}
+	state, ok := data.(*schedulingContextState)
+	if !ok || pod == nil || pod.GetPod() == nil {
+		return 0, false
+	}
+	if len(state.PrefixHashes) == 0 {
+		return 0, true
+	}
+	return float64(state.PrefixCacheServers[ServerID(pod.GetPod().NamespacedName)]) / float64(len(state.PrefixHashes)), true
+}
+
 // matchLongestPrefix returns a map of servers and length of prefix that each server caches.
 func (m *Plugin) matchLongestPrefix(ctx context.Context, hashes []BlockHash) map[ServerID]int {
 	loggerTrace := log.FromContext(ctx).V(logutil.TRACE)
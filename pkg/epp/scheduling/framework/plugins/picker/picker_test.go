@@ -0,0 +1,264 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package picker
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const pickIterations = 10000
+
+func TestWeightedRandomPicker(t *testing.T) {
+	tests := []struct {
+		name        string
+		temperature float64
+		// the minimum and maximum fraction of the picks of each pod
+		wantMin map[string]float64
+		wantMax map[string]float64
+	}{
+		{
+			name:        "low temperature almost always picks the highest score",
+			temperature: 0.01,
+			wantMin:     map[string]float64{"pod3": 0.99},
+			wantMax:     map[string]float64{"pod1": 0.01, "pod2": 0.01},
+		},
+		{
+			name:        "high temperature spreads the picks",
+			temperature: 100,
+			wantMin:     map[string]float64{"pod1": 0.3, "pod2": 0.3, "pod3": 0.3},
+			wantMax:     map[string]float64{"pod1": 0.37, "pod2": 0.37, "pod3": 0.37},
+		},
+		{
+			name:        "unit temperature follows the softmax of the scores",
+			temperature: 1,
+			// softmax of 0, 0.5 and 1 is 0.186, 0.307 and 0.506
+			wantMin: map[string]float64{"pod1": 0.16, "pod2": 0.28, "pod3": 0.48},
+			wantMax: map[string]float64{"pod1": 0.21, "pod2": 0.33, "pod3": 0.53},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			picker, err := NewWeightedRandomPicker(test.temperature, 1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			fractions := pickFractions(t, picker, scoredPods(map[string]float64{"pod1": 0, "pod2": 0.5, "pod3": 1}))
+			checkFractions(t, fractions, test.wantMin, test.wantMax)
+		})
+	}
+}
+
+func TestTopKPicker(t *testing.T) {
+	picker, err := NewTopKPicker(2, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fractions := pickFractions(t, picker, scoredPods(map[string]float64{"pod1": 0.1, "pod2": 0.9, "pod3": 0.8, "pod4": 0.2}))
+	checkFractions(t, fractions,
+		map[string]float64{"pod2": 0.45, "pod3": 0.45},
+		map[string]float64{"pod1": 0, "pod2": 0.55, "pod3": 0.55, "pod4": 0})
+}
+
+func TestPowerOfTwoPicker(t *testing.T) {
+	picker, err := NewPowerOfTwoPicker(1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Of the 6 pairs of pods, pod4 wins 3, pod3 wins 2 and pod2 wins 1, pod1 never wins.
+	fractions := pickFractions(t, picker, scoredPods(map[string]float64{"pod1": 0.1, "pod2": 0.2, "pod3": 0.3, "pod4": 0.4}))
+	checkFractions(t, fractions,
+		map[string]float64{"pod2": 0.13, "pod3": 0.3, "pod4": 0.46},
+		map[string]float64{"pod1": 0, "pod2": 0.2, "pod3": 0.37, "pod4": 0.54})
+}
+
+func TestPickersReturnRankedPods(t *testing.T) {
+	weightedRandom, _ := NewWeightedRandomPicker(0.01, 3)
+	topK, _ := NewTopKPicker(2, 3)
+	powerOfTwo, _ := NewPowerOfTwoPicker(3)
+	pods := scoredPods(map[string]float64{"pod1": 0.1, "pod2": 0.9, "pod3": 0.5, "pod4": 0.2})
+
+	tests := []struct {
+		name      string
+		picker    framework.Picker
+		wantCount int
+		check     func(t *testing.T, ranked []string)
+	}{
+		{
+			name:      "weighted random ranks the pods by score at low temperature",
+			picker:    weightedRandom,
+			wantCount: 3,
+			check: func(t *testing.T, ranked []string) {
+				if ranked[0] != "pod2" || ranked[1] != "pod3" || ranked[2] != "pod4" {
+					t.Errorf("Unexpected ranked pods %v", ranked)
+				}
+			},
+		},
+		{
+			name:      "top-k returns at most k pods",
+			picker:    topK,
+			wantCount: 2,
+			check: func(t *testing.T, ranked []string) {
+				if !(ranked[0] == "pod2" && ranked[1] == "pod3") && !(ranked[0] == "pod3" && ranked[1] == "pod2") {
+					t.Errorf("Unexpected ranked pods %v", ranked)
+				}
+			},
+		},
+		{
+			name:      "power of two never picks the lowest score first",
+			picker:    powerOfTwo,
+			wantCount: 3,
+			check: func(t *testing.T, ranked []string) {
+				if ranked[0] == "pod1" {
+					t.Errorf("Unexpected ranked pods %v", ranked)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for range 100 {
+				result := test.picker.Pick(context.Background(), types.NewCycleState(), pods)
+				if len(result.RankedPods) != test.wantCount {
+					t.Fatalf("Unexpected number of ranked pods, got %d, want %d", len(result.RankedPods), test.wantCount)
+				}
+				if result.TargetPod != result.RankedPods[0] {
+					t.Fatalf("The target pod %s is not the first ranked pod %s", podName(result.TargetPod), podName(result.RankedPods[0]))
+				}
+				ranked := make([]string, 0, len(result.RankedPods))
+				seen := map[string]bool{}
+				for _, pod := range result.RankedPods {
+					if seen[podName(pod)] {
+						t.Fatalf("The pod %s is ranked twice", podName(pod))
+					}
+					seen[podName(pod)] = true
+					ranked = append(ranked, podName(pod))
+				}
+				test.check(t, ranked)
+			}
+		})
+	}
+}
+
+func TestPickersWithoutPods(t *testing.T) {
+	weightedRandom, _ := NewWeightedRandomPicker(DefaultTemperature, 3)
+	topK, _ := NewTopKPicker(2, 3)
+	powerOfTwo, _ := NewPowerOfTwoPicker(3)
+
+	for _, picker := range []framework.Picker{weightedRandom, topK, powerOfTwo} {
+		result := picker.Pick(context.Background(), types.NewCycleState(), nil)
+		if result.TargetPod != nil || len(result.RankedPods) != 0 {
+			t.Errorf("The %s picker picked pods among none: %+v", picker.Type(), result)
+		}
+	}
+}
+
+func TestPickerFactories(t *testing.T) {
+	tests := []struct {
+		name       string
+		factory    plugins.FactoryFunc
+		parameters string
+		wantErr    bool
+	}{
+		{name: "weighted random defaults", factory: WeightedRandomPickerFactory},
+		{name: "weighted random", factory: WeightedRandomPickerFactory, parameters: `{"temperature": 0.5, "maxNumOfEndpoints": 2}`},
+		{name: "weighted random zero temperature", factory: WeightedRandomPickerFactory, parameters: `{"temperature": 0}`, wantErr: true},
+		{name: "top-k defaults", factory: TopKPickerFactory},
+		{name: "top-k", factory: TopKPickerFactory, parameters: `{"k": 5, "maxNumOfEndpoints": 2}`},
+		{name: "top-k zero k", factory: TopKPickerFactory, parameters: `{"k": 0}`, wantErr: true},
+		{name: "power of two defaults", factory: PowerOfTwoPickerFactory},
+		{name: "power of two zero endpoints", factory: PowerOfTwoPickerFactory, parameters: `{"maxNumOfEndpoints": 0}`, wantErr: true},
+		{name: "invalid json", factory: TopKPickerFactory, parameters: `{"k": "five"}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var rawParameters json.RawMessage
+			if test.parameters != "" {
+				rawParameters = json.RawMessage(test.parameters)
+			}
+			plugin, err := test.factory("my-picker", rawParameters, nil)
+			if test.wantErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if plugin.Name() != "my-picker" {
+				t.Errorf("Unexpected name %s", plugin.Name())
+			}
+		})
+	}
+}
+
+func scoredPods(scores map[string]float64) []*types.ScoredPod {
+	pods := make([]*types.ScoredPod, 0, len(scores))
+	for name, score := range scores {
+		pod := &types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name}},
+			MetricsState: &backendmetrics.MetricsState{},
+		}
+		pods = append(pods, &types.ScoredPod{Pod: pod, Score: score})
+	}
+	return pods
+}
+
+func podName(pod types.Pod) string {
+	return pod.GetPod().NamespacedName.Name
+}
+
+// pickFractions returns the fraction of the picks of each pod.
+func pickFractions(t *testing.T, picker framework.Picker, pods []*types.ScoredPod) map[string]float64 {
+	t.Helper()
+	counts := map[string]int{}
+	for range pickIterations {
+		result := picker.Pick(context.Background(), types.NewCycleState(), pods)
+		counts[podName(result.TargetPod)]++
+	}
+	fractions := map[string]float64{}
+	for name, count := range counts {
+		fractions[name] = float64(count) / pickIterations
+	}
+	return fractions
+}
+
+func checkFractions(t *testing.T, fractions map[string]float64, wantMin map[string]float64, wantMax map[string]float64) {
+	t.Helper()
+	for name, want := range wantMin {
+		if fractions[name] < want {
+			t.Errorf("Pod %s was picked %.3f of the times, want at least %.3f", name, fractions[name], want)
+		}
+	}
+	for name, want := range wantMax {
+		if fractions[name] > want {
+			t.Errorf("Pod %s was picked %.3f of the times, want at most %.3f", name, fractions[name], want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package picker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	PowerOfTwoPickerType = "power-of-two"
+)
+
+type powerOfTwoPickerParameters struct {
+	MaxNumOfEndpoints int `json:"maxNumOfEndpoints" description:"The number of pods to return, in decreasing order of preference."`
+}
+
+// PowerOfTwoPickerParameterSchema is the schema of the parameters of PowerOfTwoPicker.
+var PowerOfTwoPickerParameterSchema = plugins.NewParameterSchema(powerOfTwoPickerParameters{MaxNumOfEndpoints: DefaultMaxNumOfEndpoints})
+
+// compile-time type validation
+var _ framework.Picker = &PowerOfTwoPicker{}
+
+// PowerOfTwoPickerFactory defines the factory function for PowerOfTwoPicker.
+func PowerOfTwoPickerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := powerOfTwoPickerParameters{MaxNumOfEndpoints: DefaultMaxNumOfEndpoints}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", PowerOfTwoPickerType, err)
+		}
+	}
+	picker, err := NewPowerOfTwoPicker(parameters.MaxNumOfEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	return picker.WithName(name), nil
+}
+
+// NewPowerOfTwoPicker initializes a new PowerOfTwoPicker and returns its pointer.
+func NewPowerOfTwoPicker(maxNumOfEndpoints int) (*PowerOfTwoPicker, error) {
+	if err := validateMaxNumOfEndpoints(PowerOfTwoPickerType, maxNumOfEndpoints); err != nil {
+		return nil, err
+	}
+	return &PowerOfTwoPicker{
+		name:              PowerOfTwoPickerType,
+		maxNumOfEndpoints: maxNumOfEndpoints,
+	}, nil
+}
+
+// PowerOfTwoPicker implements the power of two choices: it draws two distinct pods at random and picks the one with
+// the highest score, breaking ties randomly. The pod with the lowest score is never picked, while the load is spread
+// over the other pods.
+// When more than one pod is returned, the next pods are drawn the same way from the pods that were not picked yet.
+type PowerOfTwoPicker struct {
+	name              string
+	maxNumOfEndpoints int
+}
+
+// Type returns the type of the picker.
+func (p *PowerOfTwoPicker) Type() string {
+	return PowerOfTwoPickerType
+}
+
+// Name returns the name of the picker.
+func (p *PowerOfTwoPicker) Name() string {
+	return p.name
+}
+
+// WithName sets the picker's name
+func (p *PowerOfTwoPicker) WithName(name string) *PowerOfTwoPicker {
+	p.name = name
+	return p
+}
+
+// Pick selects the pod with the highest score of two random pods.
+func (p *PowerOfTwoPicker) Pick(ctx context.Context, _ *types.CycleState, scoredPods []*types.ScoredPod) *types.ProfileRunResult {
+	log.FromContext(ctx).V(logutil.DEBUG).Info(fmt.Sprintf("Selecting %d pods by the power of two choices from %d candidates: %+v",
+		p.maxNumOfEndpoints, len(scoredPods), scoredPods))
+
+	remaining := slices.Clone(scoredPods)
+	picked := make([]*types.ScoredPod, 0, min(p.maxNumOfEndpoints, len(remaining)))
+	for len(picked) < p.maxNumOfEndpoints && len(remaining) > 0 {
+		i := rand.Intn(len(remaining))
+		if len(remaining) > 1 {
+			j := rand.Intn(len(remaining) - 1)
+			if j >= i { // j is drawn from the other pods
+				j++
+			}
+			if remaining[j].Score > remaining[i].Score {
+				i = j
+			}
+		}
+		picked = append(picked, remaining[i])
+		remaining = slices.Delete(remaining, i, i+1)
+	}
+	return rankedResult(picked)
+}
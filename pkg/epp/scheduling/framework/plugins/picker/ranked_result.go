@@ -0,0 +1,49 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package picker
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	// DefaultMaxNumOfEndpoints is the default number of pods returned by the pickers that can return more than one pod.
+	DefaultMaxNumOfEndpoints = 1
+)
+
+// validateMaxNumOfEndpoints returns an error if the number of pods a picker returns is invalid.
+func validateMaxNumOfEndpoints(pickerType string, maxNumOfEndpoints int) error {
+	if maxNumOfEndpoints < 1 {
+		return fmt.Errorf("invalid maxNumOfEndpoints %d of the '%s' picker, must be at least 1", maxNumOfEndpoints, pickerType)
+	}
+	return nil
+}
+
+// rankedResult returns the result of a picker that picked the given pods, in decreasing order of preference. The
+// result is empty if no pod was picked.
+func rankedResult(pickedPods []*types.ScoredPod) *types.ProfileRunResult {
+	if len(pickedPods) == 0 {
+		return &types.ProfileRunResult{}
+	}
+	rankedPods := make([]types.Pod, len(pickedPods))
+	for i, pod := range pickedPods {
+		rankedPods[i] = pod
+	}
+	return &types.ProfileRunResult{TargetPod: rankedPods[0], RankedPods: rankedPods}
+}
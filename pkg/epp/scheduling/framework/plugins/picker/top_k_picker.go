@@ -0,0 +1,134 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package picker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	TopKPickerType = "top-k"
+
+	// DefaultTopK is the default number of pods with the highest scores the TopKPicker picks from.
+	DefaultTopK = 3
+)
+
+type topKPickerParameters struct {
+	K                 int `json:"k" description:"The number of pods with the highest scores to pick from."`
+	MaxNumOfEndpoints int `json:"maxNumOfEndpoints" description:"The number of pods to return, in decreasing order of preference. At most k pods are returned."`
+}
+
+// TopKPickerParameterSchema is the schema of the parameters of TopKPicker.
+var TopKPickerParameterSchema = plugins.NewParameterSchema(topKPickerParameters{
+	K:                 DefaultTopK,
+	MaxNumOfEndpoints: DefaultMaxNumOfEndpoints,
+})
+
+// compile-time type validation
+var _ framework.Picker = &TopKPicker{}
+
+// TopKPickerFactory defines the factory function for TopKPicker.
+func TopKPickerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := topKPickerParameters{K: DefaultTopK, MaxNumOfEndpoints: DefaultMaxNumOfEndpoints}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", TopKPickerType, err)
+		}
+	}
+	picker, err := NewTopKPicker(parameters.K, parameters.MaxNumOfEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	return picker.WithName(name), nil
+}
+
+// NewTopKPicker initializes a new TopKPicker and returns its pointer.
+func NewTopKPicker(k int, maxNumOfEndpoints int) (*TopKPicker, error) {
+	if k < 1 {
+		return nil, fmt.Errorf("invalid k %d of the '%s' picker, must be at least 1", k, TopKPickerType)
+	}
+	if err := validateMaxNumOfEndpoints(TopKPickerType, maxNumOfEndpoints); err != nil {
+		return nil, err
+	}
+	return &TopKPicker{
+		name:              TopKPickerType,
+		k:                 k,
+		maxNumOfEndpoints: maxNumOfEndpoints,
+	}, nil
+}
+
+// TopKPicker picks a pod uniformly at random among the k pods with the highest scores. Pods with the same score are
+// ordered randomly, so the ties at the k-th score are broken randomly.
+// When more than one pod is returned, the next pods are drawn the same way from the k pods that were not picked yet.
+type TopKPicker struct {
+	name              string
+	k                 int
+	maxNumOfEndpoints int
+}
+
+// Type returns the type of the picker.
+func (p *TopKPicker) Type() string {
+	return TopKPickerType
+}
+
+// Name returns the name of the picker.
+func (p *TopKPicker) Name() string {
+	return p.name
+}
+
+// WithName sets the picker's name
+func (p *TopKPicker) WithName(name string) *TopKPicker {
+	p.name = name
+	return p
+}
+
+// Pick selects pods uniformly at random among the k pods with the highest scores.
+func (p *TopKPicker) Pick(ctx context.Context, _ *types.CycleState, scoredPods []*types.ScoredPod) *types.ProfileRunResult {
+	log.FromContext(ctx).V(logutil.DEBUG).Info(fmt.Sprintf("Selecting %d random pods among the top %d of %d candidates: %+v",
+		p.maxNumOfEndpoints, p.k, len(scoredPods), scoredPods))
+
+	candidates := slices.Clone(scoredPods)
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	slices.SortStableFunc(candidates, func(a, b *types.ScoredPod) int { return compareScoresDesc(a.Score, b.Score) })
+	topK := candidates[:min(p.k, len(candidates))]
+
+	// the order of the top k pods doesn't depend on their scores
+	rand.Shuffle(len(topK), func(i, j int) { topK[i], topK[j] = topK[j], topK[i] })
+	return rankedResult(topK[:min(p.maxNumOfEndpoints, len(topK))])
+}
+
+// compareScoresDesc orders scores from the highest to the lowest.
+func compareScoresDesc(a, b float64) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	default:
+		return 0
+	}
+}
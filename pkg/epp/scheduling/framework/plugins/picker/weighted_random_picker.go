@@ -0,0 +1,156 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package picker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	WeightedRandomPickerType = "weighted-random"
+
+	// DefaultTemperature is the default temperature of the WeightedRandomPicker.
+	DefaultTemperature = 1.0
+)
+
+type weightedRandomPickerParameters struct {
+	Temperature       float64 `json:"temperature" description:"The temperature of the softmax over the scores. Lower values favor the pods with the highest scores, higher values spread the requests more evenly. Must be positive."`
+	MaxNumOfEndpoints int     `json:"maxNumOfEndpoints" description:"The number of pods to return, in decreasing order of preference."`
+}
+
+// WeightedRandomPickerParameterSchema is the schema of the parameters of WeightedRandomPicker.
+var WeightedRandomPickerParameterSchema = plugins.NewParameterSchema(weightedRandomPickerParameters{
+	Temperature:       DefaultTemperature,
+	MaxNumOfEndpoints: DefaultMaxNumOfEndpoints,
+})
+
+// compile-time type validation
+var _ framework.Picker = &WeightedRandomPicker{}
+
+// WeightedRandomPickerFactory defines the factory function for WeightedRandomPicker.
+func WeightedRandomPickerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := weightedRandomPickerParameters{Temperature: DefaultTemperature, MaxNumOfEndpoints: DefaultMaxNumOfEndpoints}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' picker - %w", WeightedRandomPickerType, err)
+		}
+	}
+	picker, err := NewWeightedRandomPicker(parameters.Temperature, parameters.MaxNumOfEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	return picker.WithName(name), nil
+}
+
+// NewWeightedRandomPicker initializes a new WeightedRandomPicker and returns its pointer.
+func NewWeightedRandomPicker(temperature float64, maxNumOfEndpoints int) (*WeightedRandomPicker, error) {
+	if temperature <= 0 {
+		return nil, fmt.Errorf("invalid temperature %v of the '%s' picker, must be positive", temperature, WeightedRandomPickerType)
+	}
+	if err := validateMaxNumOfEndpoints(WeightedRandomPickerType, maxNumOfEndpoints); err != nil {
+		return nil, err
+	}
+	return &WeightedRandomPicker{
+		name:              WeightedRandomPickerType,
+		temperature:       temperature,
+		maxNumOfEndpoints: maxNumOfEndpoints,
+	}, nil
+}
+
+// WeightedRandomPicker picks a pod at random, with a probability given by the softmax of the scores divided by the
+// temperature. Unlike MaxScorePicker, it spreads the requests of a burst across the pods with high scores, instead of
+// sending all of them to the same pod until the next metrics scrape.
+// When more than one pod is returned, the next pods are drawn the same way from the pods that were not picked yet.
+type WeightedRandomPicker struct {
+	name              string
+	temperature       float64
+	maxNumOfEndpoints int
+}
+
+// Type returns the type of the picker.
+func (p *WeightedRandomPicker) Type() string {
+	return WeightedRandomPickerType
+}
+
+// Name returns the name of the picker.
+func (p *WeightedRandomPicker) Name() string {
+	return p.name
+}
+
+// WithName sets the picker's name
+func (p *WeightedRandomPicker) WithName(name string) *WeightedRandomPicker {
+	p.name = name
+	return p
+}
+
+// Pick selects pods at random, weighted by the softmax of their scores.
+func (p *WeightedRandomPicker) Pick(ctx context.Context, _ *types.CycleState, scoredPods []*types.ScoredPod) *types.ProfileRunResult {
+	log.FromContext(ctx).V(logutil.DEBUG).Info(fmt.Sprintf("Selecting %d weighted random pods from %d candidates: %+v",
+		p.maxNumOfEndpoints, len(scoredPods), scoredPods))
+
+	remaining := slices.Clone(scoredPods)
+	weights := p.softmaxWeights(remaining)
+	picked := make([]*types.ScoredPod, 0, min(p.maxNumOfEndpoints, len(remaining)))
+	for len(picked) < p.maxNumOfEndpoints && len(remaining) > 0 {
+		i := weightedIndex(weights)
+		picked = append(picked, remaining[i])
+		remaining = slices.Delete(remaining, i, i+1)
+		weights = slices.Delete(weights, i, i+1)
+	}
+	return rankedResult(picked)
+}
+
+// softmaxWeights returns the unnormalized softmax weights of the pods. The maximum score is subtracted from the
+// scores before the exponentiation so that low temperatures don't overflow.
+func (p *WeightedRandomPicker) softmaxWeights(scoredPods []*types.ScoredPod) []float64 {
+	maxScore := math.Inf(-1)
+	for _, pod := range scoredPods {
+		maxScore = math.Max(maxScore, pod.Score)
+	}
+	weights := make([]float64, len(scoredPods))
+	for i, pod := range scoredPods {
+		weights[i] = math.Exp((pod.Score - maxScore) / p.temperature)
+	}
+	return weights
+}
+
+// weightedIndex returns a random index, drawn with a probability proportional to its weight.
+func weightedIndex(weights []float64) int {
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	target := rand.Float64() * total
+	for i, weight := range weights {
+		if target < weight {
+			return i
+		}
+		target -= weight
+	}
+	return len(weights) - 1 // rounding errors
+}
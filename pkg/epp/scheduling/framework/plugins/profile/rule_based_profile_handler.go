@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	RuleBasedProfileHandlerType = "rule-based-profile"
+
+	// DefaultProfileName is the name of the profile picked when no rule matches the request.
+	DefaultProfileName = "default"
+)
+
+type ruleBasedProfileHandlerParameters struct {
+	DefaultProfile string        `json:"defaultProfile" description:"The profile picked when no rule matches the request."`
+	Rules          []ProfileRule `json:"rules,omitempty" description:"The rules evaluated in order, the first matching rule picks the profile."`
+}
+
+// ProfileRule picks a profile for the requests matching all of its conditions. A rule has at least one condition.
+type ProfileRule struct {
+	// Profile is the name of the profile picked for the matching requests.
+	Profile string `json:"profile" description:"The profile picked for the matching requests."`
+	// Models matches the requests whose resolved target model is one of the models.
+	Models []string `json:"models,omitempty" description:"Matches the requests whose resolved target model is one of the models."`
+	// Headers matches the requests having all the headers with the given values. Header names are case-insensitive.
+	Headers map[string]string `json:"headers,omitempty" description:"Matches the requests having all the headers with the given values."`
+}
+
+// RuleBasedProfileHandlerParameterSchema is the schema of the parameters of RuleBasedProfileHandler.
+var RuleBasedProfileHandlerParameterSchema = plugins.NewParameterSchema(ruleBasedProfileHandlerParameters{DefaultProfile: DefaultProfileName})
+
+// compile-time type assertion
+var _ framework.ProfileHandler = &RuleBasedProfileHandler{}
+
+// RuleBasedProfileHandlerFactory defines the factory function for RuleBasedProfileHandler.
+func RuleBasedProfileHandlerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := ruleBasedProfileHandlerParameters{DefaultProfile: DefaultProfileName}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' profile handler - %w", RuleBasedProfileHandlerType, err)
+		}
+	}
+	handler, err := NewRuleBasedProfileHandler(parameters.DefaultProfile, parameters.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return handler.WithName(name), nil
+}
+
+// NewRuleBasedProfileHandler initializes a new RuleBasedProfileHandler and returns its pointer.
+func NewRuleBasedProfileHandler(defaultProfile string, rules []ProfileRule) (*RuleBasedProfileHandler, error) {
+	if defaultProfile == "" {
+		return nil, errors.New("the default profile is not set")
+	}
+	normalizedRules := make([]ProfileRule, 0, len(rules))
+	for idx, rule := range rules {
+		if rule.Profile == "" {
+			return nil, fmt.Errorf("the profile of rule %d is not set", idx)
+		}
+		if len(rule.Models) == 0 && len(rule.Headers) == 0 {
+			return nil, fmt.Errorf("rule %d of profile '%s' has no condition", idx, rule.Profile)
+		}
+		headers := make(map[string]string, len(rule.Headers))
+		for key, value := range rule.Headers {
+			headers[strings.ToLower(key)] = value
+		}
+		normalizedRules = append(normalizedRules, ProfileRule{Profile: rule.Profile, Models: rule.Models, Headers: headers})
+	}
+
+	return &RuleBasedProfileHandler{
+		name:           RuleBasedProfileHandlerType,
+		defaultProfile: defaultProfile,
+		rules:          normalizedRules,
+	}, nil
+}
+
+// RuleBasedProfileHandler runs a single profile per request, picked from the request attributes. The profile is, in
+// order of precedence:
+//  1. the scheduling profile set by the InferenceModel of the request,
+//  2. the profile of the first rule matching the resolved target model or the headers of the request,
+//  3. the default profile.
+//
+// A profile that doesn't exist is skipped.
+type RuleBasedProfileHandler struct {
+	name           string
+	defaultProfile string
+	rules          []ProfileRule
+}
+
+// Type returns the type of the Profile Handler.
+func (h *RuleBasedProfileHandler) Type() string {
+	return RuleBasedProfileHandlerType
+}
+
+// Name returns the name of the profile handler.
+func (h *RuleBasedProfileHandler) Name() string {
+	return h.name
+}
+
+// WithName sets the name of the profile handler.
+func (h *RuleBasedProfileHandler) WithName(name string) *RuleBasedProfileHandler {
+	h.name = name
+	return h
+}
+
+// Pick selects the SchedulingProfiles to run from the list of candidate profiles, while taking into consideration the request properties and the
+// previously executed cycles along with their results.
+func (h *RuleBasedProfileHandler) Pick(ctx context.Context, _ *types.CycleState, request *types.LLMRequest,
+	profiles map[string]*framework.SchedulerProfile, profileResults map[string]*types.ProfileRunResult) map[string]*framework.SchedulerProfile {
+	if len(profileResults) > 0 { // the picked profile has been executed already in previous call
+		return map[string]*framework.SchedulerProfile{}
+	}
+
+	logger := log.FromContext(ctx).V(logutil.DEBUG)
+	for _, name := range h.candidateProfiles(request) {
+		if profile, ok := profiles[name]; ok {
+			logger.Info("Picked the scheduling profile", "profile", name)
+			return map[string]*framework.SchedulerProfile{name: profile}
+		}
+		logger.Info("Skipping a scheduling profile that does not exist", "profile", name)
+	}
+	return map[string]*framework.SchedulerProfile{}
+}
+
+// candidateProfiles returns the names of the profiles matching the request, in order of precedence.
+func (h *RuleBasedProfileHandler) candidateProfiles(request *types.LLMRequest) []string {
+	candidates := []string{}
+	if request.SchedulingProfile != "" {
+		candidates = append(candidates, request.SchedulingProfile)
+	}
+	for _, rule := range h.rules {
+		if rule.matches(request) {
+			candidates = append(candidates, rule.Profile)
+		}
+	}
+	return append(candidates, h.defaultProfile)
+}
+
+func (r *ProfileRule) matches(request *types.LLMRequest) bool {
+	if len(r.Models) > 0 && !slices.Contains(r.Models, request.TargetModel) {
+		return false
+	}
+	for key, value := range r.Headers {
+		if request.Headers[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ProcessResults handles the outcome of the profile runs after all profiles ran.
+// It may aggregate results, log test profile outputs, or apply custom logic. It specifies in the SchedulingResult the
+// key of the primary profile that should be used to get the request selected destination.
+// When a profile run fails, its result in the profileResults map is nil.
+func (h *RuleBasedProfileHandler) ProcessResults(_ context.Context, _ *types.CycleState, _ *types.LLMRequest,
+	profileResults map[string]*types.ProfileRunResult) (*types.SchedulingResult, error) {
+	if len(profileResults) != 1 {
+		return nil, errors.New("rule based profile handler runs a single profile per request, failed to process multiple profiles")
+	}
+
+	var pickedProfileName string
+	for profileName := range profileResults {
+		pickedProfileName = profileName
+	}
+
+	if profileResults[pickedProfileName] == nil { // there was an error while running the profile
+		return nil, fmt.Errorf("failed to run scheduler profile '%s'", pickedProfileName)
+	}
+
+	return &types.SchedulingResult{
+		ProfileResults:     profileResults,
+		PrimaryProfileName: pickedProfileName,
+	}, nil
+}
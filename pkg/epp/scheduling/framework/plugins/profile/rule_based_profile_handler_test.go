@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestRuleBasedProfileHandlerFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		wantErr    bool
+	}{
+		{
+			name: "no parameters",
+		},
+		{
+			name:       "rules",
+			parameters: `{"defaultProfile": "batch", "rules": [{"profile": "chat", "models": ["chat-model"]}]}`,
+		},
+		{
+			name:       "empty default profile",
+			parameters: `{"defaultProfile": ""}`,
+			wantErr:    true,
+		},
+		{
+			name:       "rule without profile",
+			parameters: `{"rules": [{"models": ["chat-model"]}]}`,
+			wantErr:    true,
+		},
+		{
+			name:       "rule without condition",
+			parameters: `{"rules": [{"profile": "chat"}]}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := RuleBasedProfileHandlerFactory("handler", []byte(test.parameters), nil)
+			if (err != nil) != test.wantErr {
+				t.Errorf("RuleBasedProfileHandlerFactory returned error %v, want error %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleBasedProfileHandlerPick(t *testing.T) {
+	handler, err := NewRuleBasedProfileHandler(DefaultProfileName, []ProfileRule{
+		{Profile: "chat", Models: []string{"chat-model"}},
+		{Profile: "batch", Headers: map[string]string{"X-Workload": "batch"}},
+		{Profile: "missing", Models: []string{"other-model"}},
+	})
+	if err != nil {
+		t.Fatalf("NewRuleBasedProfileHandler returned unexpected error: %v", err)
+	}
+	profiles := map[string]*framework.SchedulerProfile{
+		DefaultProfileName: framework.NewSchedulerProfile(),
+		"chat":             framework.NewSchedulerProfile(),
+		"batch":            framework.NewSchedulerProfile(),
+	}
+
+	tests := []struct {
+		name    string
+		request *types.LLMRequest
+		want    string
+	}{
+		{
+			name:    "default",
+			request: &types.LLMRequest{TargetModel: "base-model"},
+			want:    DefaultProfileName,
+		},
+		{
+			name:    "model rule",
+			request: &types.LLMRequest{TargetModel: "chat-model", Headers: map[string]string{"x-workload": "batch"}},
+			want:    "chat",
+		},
+		{
+			name:    "header rule",
+			request: &types.LLMRequest{TargetModel: "base-model", Headers: map[string]string{"x-workload": "batch"}},
+			want:    "batch",
+		},
+		{
+			name:    "inference model profile",
+			request: &types.LLMRequest{TargetModel: "chat-model", SchedulingProfile: "batch"},
+			want:    "batch",
+		},
+		{
+			name:    "missing inference model profile",
+			request: &types.LLMRequest{TargetModel: "chat-model", SchedulingProfile: "missing"},
+			want:    "chat",
+		},
+		{
+			name:    "missing rule profile",
+			request: &types.LLMRequest{TargetModel: "other-model"},
+			want:    DefaultProfileName,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := handler.Pick(context.Background(), types.NewCycleState(), test.request, profiles, map[string]*types.ProfileRunResult{})
+			if diff := cmp.Diff([]string{test.want}, slices.Collect(maps.Keys(got))); diff != "" {
+				t.Errorf("Unexpected picked profiles (-want +got): %s", diff)
+			}
+
+			results := map[string]*types.ProfileRunResult{test.want: {}}
+			if got := handler.Pick(context.Background(), types.NewCycleState(), test.request, profiles, results); len(got) != 0 {
+				t.Errorf("Pick returned profiles after the picked profile ran: %v", got)
+			}
+			result, err := handler.ProcessResults(context.Background(), types.NewCycleState(), test.request, results)
+			if err != nil {
+				t.Fatalf("ProcessResults returned unexpected error: %v", err)
+			}
+			if result.PrimaryProfileName != test.want {
+				t.Errorf("Unexpected primary profile %s, want %s", result.PrimaryProfileName, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	ShadowProfileHandlerType = "shadow-profile"
+)
+
+type shadowProfileHandlerParameters struct {
+	PrimaryProfile string   `json:"primaryProfile" description:"The profile that routes the requests."`
+	ShadowProfiles []string `json:"shadowProfiles" description:"The profiles run in shadow, whose picks are only compared with the primary profile. Required."`
+	SamplingRate   float64  `json:"samplingRate" description:"The fraction of the requests the shadow profiles run for, between 0 and 1."`
+}
+
+// ShadowProfileHandlerParameterSchema is the schema of the parameters of ShadowProfileHandler.
+var ShadowProfileHandlerParameterSchema = plugins.NewParameterSchema(shadowProfileHandlerParameters{
+	PrimaryProfile: DefaultProfileName,
+	SamplingRate:   1,
+})
+
+// compile-time type assertion
+var _ framework.ShadowProfileHandler = &ShadowProfileHandler{}
+
+// ShadowProfileHandlerFactory defines the factory function for ShadowProfileHandler.
+func ShadowProfileHandlerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := shadowProfileHandlerParameters{PrimaryProfile: DefaultProfileName, SamplingRate: 1}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' profile handler - %w", ShadowProfileHandlerType, err)
+		}
+	}
+	handler, err := NewShadowProfileHandler(parameters.PrimaryProfile, parameters.ShadowProfiles, parameters.SamplingRate)
+	if err != nil {
+		return nil, err
+	}
+	return handler.WithName(name), nil
+}
+
+// NewShadowProfileHandler initializes a new ShadowProfileHandler and returns its pointer.
+func NewShadowProfileHandler(primaryProfile string, shadowProfiles []string, samplingRate float64) (*ShadowProfileHandler, error) {
+	if primaryProfile == "" {
+		return nil, errors.New("the primary profile is not set")
+	}
+	if len(shadowProfiles) == 0 {
+		return nil, errors.New("no shadow profile is set")
+	}
+	if slices.Contains(shadowProfiles, primaryProfile) {
+		return nil, fmt.Errorf("the primary profile '%s' can not be a shadow profile", primaryProfile)
+	}
+	if samplingRate < 0 || samplingRate > 1 {
+		return nil, fmt.Errorf("invalid sampling rate %v, must be between 0 and 1", samplingRate)
+	}
+
+	return &ShadowProfileHandler{
+		name:           ShadowProfileHandlerType,
+		primaryProfile: primaryProfile,
+		shadowProfiles: shadowProfiles,
+		samplingRate:   samplingRate,
+	}, nil
+}
+
+// ShadowProfileHandler evaluates scheduling algorithms in shadow. The primary profile routes every request. For a
+// sample of the requests, the shadow profiles run in the same cycle, on the same candidate pods, and their picks are
+// compared with the one of the primary profile. The agreement and the predicted cost of the picked pods (queue size,
+// KV-cache utilization and cached prompt prefix) are recorded in metrics.
+//
+// The shadow profiles never affect routing: they run on a copy of the CycleState, without their PostCycle plugins,
+// and their results are not returned in the SchedulingResult.
+type ShadowProfileHandler struct {
+	name           string
+	primaryProfile string
+	shadowProfiles []string
+	samplingRate   float64
+}
+
+// Type returns the type of the Profile Handler.
+func (h *ShadowProfileHandler) Type() string {
+	return ShadowProfileHandlerType
+}
+
+// Name returns the name of the profile handler.
+func (h *ShadowProfileHandler) Name() string {
+	return h.name
+}
+
+// WithName sets the name of the profile handler.
+func (h *ShadowProfileHandler) WithName(name string) *ShadowProfileHandler {
+	h.name = name
+	return h
+}
+
+// IsShadowProfile returns true if the profile with the given name is a shadow profile.
+func (h *ShadowProfileHandler) IsShadowProfile(name string) bool {
+	return slices.Contains(h.shadowProfiles, name)
+}
+
+// Pick selects the SchedulingProfiles to run from the list of candidate profiles, while taking into consideration the request properties and the
+// previously executed cycles along with their results.
+func (h *ShadowProfileHandler) Pick(ctx context.Context, _ *types.CycleState, _ *types.LLMRequest,
+	profiles map[string]*framework.SchedulerProfile, profileResults map[string]*types.ProfileRunResult) map[string]*framework.SchedulerProfile {
+	if len(profileResults) > 0 { // the profiles have been executed already in previous call
+		return map[string]*framework.SchedulerProfile{}
+	}
+
+	primary, ok := profiles[h.primaryProfile]
+	if !ok {
+		log.FromContext(ctx).Error(nil, "The primary scheduling profile does not exist", "profile", h.primaryProfile)
+		return map[string]*framework.SchedulerProfile{}
+	}
+	picked := map[string]*framework.SchedulerProfile{h.primaryProfile: primary}
+	if h.samplingRate == 0 || rand.Float64() >= h.samplingRate {
+		return picked
+	}
+	for _, name := range h.shadowProfiles {
+		if profile, ok := profiles[name]; ok {
+			picked[name] = profile
+		}
+	}
+	return picked
+}
+
+// ProcessResults handles the outcome of the profile runs after all profiles ran.
+// It may aggregate results, log test profile outputs, or apply custom logic. It specifies in the SchedulingResult the
+// key of the primary profile that should be used to get the request selected destination.
+// When a profile run fails, its result in the profileResults map is nil.
+func (h *ShadowProfileHandler) ProcessResults(ctx context.Context, cycleState *types.CycleState, _ *types.LLMRequest,
+	profileResults map[string]*types.ProfileRunResult) (*types.SchedulingResult, error) {
+	primaryResult, ok := profileResults[h.primaryProfile]
+	if !ok || primaryResult == nil { // there was an error while running the profile
+		return nil, fmt.Errorf("failed to run scheduler profile '%s'", h.primaryProfile)
+	}
+
+	if len(profileResults) > 1 {
+		logger := log.FromContext(ctx).V(logutil.DEBUG)
+		primaryPod := types.PodName(primaryResult.TargetPod)
+		recordPickedPodCost(cycleState, h.primaryProfile, primaryResult)
+		for _, name := range h.shadowProfiles {
+			shadowResult, ran := profileResults[name]
+			if !ran {
+				continue
+			}
+			failed := shadowResult == nil || shadowResult.TargetPod == nil
+			agreed := !failed && types.PodName(shadowResult.TargetPod) == primaryPod
+			metrics.RecordShadowProfileAgreement(h.primaryProfile, name, agreed, failed)
+			if failed {
+				logger.Info("Shadow scheduling profile failed to pick a pod", "profile", name)
+				continue
+			}
+			logger.Info("Shadow scheduling profile result", "profile", name, "pod", types.PodName(shadowResult.TargetPod),
+				"primaryPod", primaryPod, "agreed", agreed)
+			recordPickedPodCost(cycleState, name, shadowResult)
+		}
+	}
+
+	return &types.SchedulingResult{
+		ProfileResults:     map[string]*types.ProfileRunResult{h.primaryProfile: primaryResult},
+		PrimaryProfileName: h.primaryProfile,
+	}, nil
+}
+
+// recordPickedPodCost records the predicted cost of the pod picked by a profile. The cached prompt prefix is only
+// known if the prefix cache plugin ran in the primary profile.
+func recordPickedPodCost(cycleState *types.CycleState, profileName string, result *types.ProfileRunResult) {
+	if result.TargetPod == nil || result.TargetPod.GetMetrics() == nil {
+		return
+	}
+	podMetrics := result.TargetPod.GetMetrics()
+	metrics.RecordShadowEvaluationPod(profileName, podMetrics.WaitingQueueSize, podMetrics.KVCacheUsagePercent)
+	if ratio, ok := prefix.PrefixMatchRatio(cycleState, result.TargetPod); ok {
+		metrics.RecordShadowEvaluationPrefixMatch(profileName, ratio)
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestShadowProfileHandlerFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		wantErr    bool
+	}{
+		{
+			name:       "valid",
+			parameters: `{"shadowProfiles": ["candidate"], "samplingRate": 0.1}`,
+		},
+		{
+			name:    "no shadow profile",
+			wantErr: true,
+		},
+		{
+			name:       "primary profile as shadow profile",
+			parameters: `{"primaryProfile": "candidate", "shadowProfiles": ["candidate"]}`,
+			wantErr:    true,
+		},
+		{
+			name:       "invalid sampling rate",
+			parameters: `{"shadowProfiles": ["candidate"], "samplingRate": 1.5}`,
+			wantErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ShadowProfileHandlerFactory("handler", []byte(test.parameters), nil)
+			if (err != nil) != test.wantErr {
+				t.Errorf("ShadowProfileHandlerFactory returned error %v, want error %t", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestShadowProfileHandler(t *testing.T) {
+	profiles := map[string]*framework.SchedulerProfile{
+		DefaultProfileName: framework.NewSchedulerProfile(),
+		"candidate":        framework.NewSchedulerProfile(),
+	}
+	request := &types.LLMRequest{TargetModel: "model"}
+
+	for samplingRate, want := range map[float64][]string{0: {DefaultProfileName}, 1: {"candidate", DefaultProfileName}} {
+		handler, err := NewShadowProfileHandler(DefaultProfileName, []string{"candidate"}, samplingRate)
+		if err != nil {
+			t.Fatalf("NewShadowProfileHandler returned unexpected error: %v", err)
+		}
+		got := handler.Pick(context.Background(), types.NewCycleState(), request, profiles, map[string]*types.ProfileRunResult{})
+		if diff := cmp.Diff(want, slices.Sorted(maps.Keys(got))); diff != "" {
+			t.Errorf("Unexpected picked profiles with sampling rate %v (-want +got): %s", samplingRate, diff)
+		}
+	}
+
+	handler, err := NewShadowProfileHandler(DefaultProfileName, []string{"candidate"}, 1)
+	if err != nil {
+		t.Fatalf("NewShadowProfileHandler returned unexpected error: %v", err)
+	}
+	if !handler.IsShadowProfile("candidate") || handler.IsShadowProfile(DefaultProfileName) {
+		t.Error("Unexpected shadow profiles")
+	}
+	primaryResult := &types.ProfileRunResult{TargetPod: newPod("pod1")}
+	results := map[string]*types.ProfileRunResult{
+		DefaultProfileName: primaryResult,
+		"candidate":        {TargetPod: newPod("pod2")},
+	}
+	result, err := handler.ProcessResults(context.Background(), types.NewCycleState(), request, results)
+	if err != nil {
+		t.Fatalf("ProcessResults returned unexpected error: %v", err)
+	}
+	if result.PrimaryProfileName != DefaultProfileName || len(result.ProfileResults) != 1 ||
+		result.ProfileResults[DefaultProfileName] != primaryResult {
+		t.Errorf("Unexpected scheduling result %+v", result)
+	}
+
+	results[DefaultProfileName] = nil
+	if _, err := handler.ProcessResults(context.Background(), types.NewCycleState(), request, results); err == nil {
+		t.Error("ProcessResults did not return an expected error for a failed primary profile")
+	}
+}
+
+func newPod(name string) types.Pod {
+	return &types.PodMetrics{
+		Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name, Namespace: "default"}},
+		MetricsState: &backendmetrics.MetricsState{},
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorer
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cespare/xxhash/v2"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	ConsistentHashScorerType = "consistent-hash"
+
+	// DefaultLoadFactor is the default bound of the load of a pod, relative to the average load of the pods.
+	DefaultLoadFactor = 1.25
+	// DefaultVirtualNodes is the default number of points of each pod on the hash ring.
+	DefaultVirtualNodes = 100
+)
+
+type consistentHashScorerParameters struct {
+	KeyHeader    string  `json:"keyHeader,omitempty" description:"The request header whose value is the routing key. Exactly one of keyHeader and keyBodyField must be set."`
+	KeyBodyField string  `json:"keyBodyField,omitempty" description:"The request body field whose value is the routing key, nested fields are separated by dots. Exactly one of keyHeader and keyBodyField must be set."`
+	LoadFactor   float64 `json:"loadFactor" description:"The maximum load of a pod relative to the average load of the pods, at least 1. Lower values spread the keys of hot pods sooner."`
+	VirtualNodes int     `json:"virtualNodes" description:"The number of points of each pod on the hash ring. More points spread the keys more evenly."`
+}
+
+// ConsistentHashScorerParameterSchema is the schema of the parameters of ConsistentHashScorer.
+var ConsistentHashScorerParameterSchema = plugins.NewParameterSchema(consistentHashScorerParameters{
+	LoadFactor:   DefaultLoadFactor,
+	VirtualNodes: DefaultVirtualNodes,
+})
+
+// compile-time type assertion
+var _ framework.Scorer = &ConsistentHashScorer{}
+
+// ConsistentHashScorerFactory defines the factory function for ConsistentHashScorer.
+func ConsistentHashScorerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := consistentHashScorerParameters{LoadFactor: DefaultLoadFactor, VirtualNodes: DefaultVirtualNodes}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", ConsistentHashScorerType, err)
+		}
+	}
+	scorer, err := NewConsistentHashScorer(parameters.KeyHeader, parameters.KeyBodyField, parameters.LoadFactor, parameters.VirtualNodes)
+	if err != nil {
+		return nil, err
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewConsistentHashScorer initializes a new ConsistentHashScorer and returns its pointer.
+func NewConsistentHashScorer(keyHeader string, keyBodyField string, loadFactor float64, virtualNodes int) (*ConsistentHashScorer, error) {
+	if (keyHeader == "") == (keyBodyField == "") {
+		return nil, errors.New("exactly one of the key header and the key body field must be set")
+	}
+	if loadFactor < 1 {
+		return nil, fmt.Errorf("invalid load factor %v, must be at least 1", loadFactor)
+	}
+	if virtualNodes < 1 {
+		return nil, fmt.Errorf("invalid number of virtual nodes %d, must be at least 1", virtualNodes)
+	}
+
+	var keyBodyPath []string
+	if keyBodyField != "" {
+		keyBodyPath = strings.Split(keyBodyField, ".")
+	}
+	return &ConsistentHashScorer{
+		name:         ConsistentHashScorerType,
+		keyHeader:    strings.ToLower(keyHeader),
+		keyBodyPath:  keyBodyPath,
+		loadFactor:   loadFactor,
+		virtualNodes: virtualNodes,
+	}, nil
+}
+
+// ConsistentHashScorer implements consistent hashing with bounded loads. Requests with the same routing key, like a
+// document or user ID, are sent to the same pod for cache locality, and when pods are added or removed, only the keys
+// of about 1/n of the pods move. Unlike the prefix cache plugin, it keeps no state about the
+// requests.
+//
+// Each pod has several points on a hash ring, and the key of the request is hashed onto the ring. Walking the ring
+// from the key, the first pod whose load is below the bound gets a score of 1, the other pods get 0. The load of a
+// pod is its running and waiting queue size, and the bound is the load factor times the average load of the pods
+// including the request, rounded up. Requests without a key get a neutral score of 1 on all pods. The ring is
+// rebuilt only when the set of candidate pods changes.
+type ConsistentHashScorer struct {
+	name         string
+	keyHeader    string
+	keyBodyPath  []string
+	loadFactor   float64
+	virtualNodes int
+	// ring is the hash ring of the pods of the last request, which are usually the same from one request to the next.
+	ring atomic.Pointer[hashRing]
+}
+
+// Type returns the type of the scorer.
+func (s *ConsistentHashScorer) Type() string {
+	return ConsistentHashScorerType
+}
+
+// Name returns the name of the scorer.
+func (s *ConsistentHashScorer) Name() string {
+	return s.name
+}
+
+// WithName sets the name of the scorer.
+func (s *ConsistentHashScorer) WithName(name string) *ConsistentHashScorer {
+	s.name = name
+	return s
+}
+
+// Score returns the scoring result for the given list of pods based on context.
+func (s *ConsistentHashScorer) Score(ctx context.Context, _ *types.CycleState, request *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scores := make(map[types.Pod]float64, len(pods))
+	key, ok := s.routingKey(request)
+	if !ok {
+		log.FromContext(ctx).V(logutil.DEBUG).Info("The request has no routing key, scoring all the pods neutrally")
+		for _, pod := range pods {
+			scores[pod] = 1
+		}
+		return scores
+	}
+
+	for _, pod := range pods {
+		scores[pod] = 0
+	}
+	if target := s.pickPod(key, pods); target != nil {
+		scores[target] = 1
+	}
+	return scores
+}
+
+// routingKey returns the routing key of the request, if it has one.
+func (s *ConsistentHashScorer) routingKey(request *types.LLMRequest) (string, bool) {
+	if s.keyHeader != "" {
+		key, ok := request.Headers[s.keyHeader]
+		return key, ok && key != ""
+	}
+
+	var value any = request.Body
+	for _, field := range s.keyBodyPath {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[field]; !ok {
+			return "", false
+		}
+	}
+	switch key := value.(type) {
+	case nil, map[string]any, []any:
+		return "", false
+	case string:
+		return key, key != ""
+	default:
+		return fmt.Sprint(key), true
+	}
+}
+
+// ringPoint is a point of a pod on the hash ring.
+type ringPoint struct {
+	hash    uint64
+	podName string
+}
+
+// hashRing is the hash ring of a set of pods.
+type hashRing struct {
+	// podSet is the sorted names of the pods, joined by commas.
+	podSet string
+	points []ringPoint
+}
+
+// pickPod returns the first pod on the ring, from the key, whose load is below the bound.
+func (s *ConsistentHashScorer) pickPod(key string, pods []types.Pod) types.Pod {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	totalLoad := 0
+	podsByName := make(map[string]types.Pod, len(pods))
+	for _, pod := range pods {
+		totalLoad += podLoad(pod)
+		podsByName[pod.GetPod().NamespacedName.String()] = pod
+	}
+	ring := s.hashRing(podsByName)
+	// the bound is above the average load including the request, so at least one pod is below it
+	bound := int(math.Ceil(s.loadFactor * float64(totalLoad+1) / float64(len(pods))))
+
+	keyHash := xxhash.Sum64String(key)
+	start, _ := slices.BinarySearchFunc(ring.points, keyHash, func(point ringPoint, hash uint64) int { return cmp.Compare(point.hash, hash) })
+	for i := range ring.points {
+		pod := podsByName[ring.points[(start+i)%len(ring.points)].podName]
+		if podLoad(pod)+1 <= bound {
+			return pod
+		}
+	}
+	return nil
+}
+
+// hashRing returns the hash ring of the pods. The ring of the last set of pods is kept, and is only rebuilt when the
+// set of pods changes.
+func (s *ConsistentHashScorer) hashRing(podsByName map[string]types.Pod) *hashRing {
+	podSet := strings.Join(slices.Sorted(maps.Keys(podsByName)), ",")
+	if ring := s.ring.Load(); ring != nil && ring.podSet == podSet {
+		return ring
+	}
+
+	ring := &hashRing{podSet: podSet, points: make([]ringPoint, 0, len(podsByName)*s.virtualNodes)}
+	for podName := range podsByName {
+		for i := range s.virtualNodes {
+			ring.points = append(ring.points, ringPoint{hash: xxhash.Sum64String(podName + "#" + strconv.Itoa(i)), podName: podName})
+		}
+	}
+	slices.SortFunc(ring.points, func(a, b ringPoint) int { return cmp.Compare(a.hash, b.hash) })
+	s.ring.Store(ring)
+	return ring
+}
+
+// podLoad returns the number of requests of the pod, running or waiting.
+func podLoad(pod types.Pod) int {
+	metrics := pod.GetMetrics()
+	if metrics == nil {
+		return 0
+	}
+	return metrics.RunningQueueSize + metrics.WaitingQueueSize
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestConsistentHashScorerRoutingKey(t *testing.T) {
+	pods := consistentHashPods(3)
+	headerScorer, err := NewConsistentHashScorer("X-User-Id", "", DefaultLoadFactor, DefaultVirtualNodes)
+	assert.NoError(t, err)
+	bodyScorer, err := NewConsistentHashScorer("", "metadata.user", DefaultLoadFactor, DefaultVirtualNodes)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		scorer      *ConsistentHashScorer
+		request     *types.LLMRequest
+		wantNeutral bool
+	}{
+		{
+			name:    "header key",
+			scorer:  headerScorer,
+			request: &types.LLMRequest{Headers: map[string]string{"x-user-id": "user-1"}},
+		},
+		{
+			name:        "missing header",
+			scorer:      headerScorer,
+			request:     &types.LLMRequest{Headers: map[string]string{}},
+			wantNeutral: true,
+		},
+		{
+			name:    "nested body field",
+			scorer:  bodyScorer,
+			request: &types.LLMRequest{Body: map[string]any{"metadata": map[string]any{"user": "user-1"}}},
+		},
+		{
+			name:    "numeric body field",
+			scorer:  bodyScorer,
+			request: &types.LLMRequest{Body: map[string]any{"metadata": map[string]any{"user": 42.0}}},
+		},
+		{
+			name:        "missing body field",
+			scorer:      bodyScorer,
+			request:     &types.LLMRequest{Body: map[string]any{"metadata": "user-1"}},
+			wantNeutral: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scores := test.scorer.Score(context.Background(), types.NewCycleState(), test.request, pods)
+
+			total := 0.0
+			for _, pod := range pods {
+				total += scores[pod]
+			}
+			if test.wantNeutral {
+				assert.Equal(t, float64(len(pods)), total, "all pods should get a neutral score")
+			} else {
+				assert.Equal(t, 1.0, total, "a single pod should get a score of 1")
+			}
+		})
+	}
+}
+
+func TestConsistentHashScorerIsConsistent(t *testing.T) {
+	scorer, err := NewConsistentHashScorer("x-user-id", "", DefaultLoadFactor, DefaultVirtualNodes)
+	assert.NoError(t, err)
+	pods := consistentHashPods(5)
+
+	for key := range 100 {
+		want := targetPod(scorer, fmt.Sprint(key), pods)
+		// the order of the candidate pods doesn't matter
+		reversed := []types.Pod{pods[4], pods[3], pods[2], pods[1], pods[0]}
+		assert.Equal(t, want, targetPod(scorer, fmt.Sprint(key), reversed), "key %d", key)
+	}
+}
+
+func TestConsistentHashScorerScaleUp(t *testing.T) {
+	scorer, err := NewConsistentHashScorer("x-user-id", "", DefaultLoadFactor, DefaultVirtualNodes)
+	assert.NoError(t, err)
+	const keys = 10000
+	pods := consistentHashPods(11)
+	before, after := pods[:10], pods
+
+	podsBefore := make([]string, keys)
+	for key := range keys {
+		podsBefore[key] = targetPod(scorer, fmt.Sprint(key), before)
+	}
+	moved := 0
+	for key := range keys {
+		podAfter := targetPod(scorer, fmt.Sprint(key), after)
+		if podsBefore[key] == podAfter {
+			continue
+		}
+		moved++
+		assert.Equal(t, "pod10", podAfter, "key %d should only move to the new pod", key)
+	}
+
+	// about 1/11 of the keys move to the new pod
+	fraction := float64(moved) / keys
+	assert.Greater(t, fraction, 0.05)
+	assert.Less(t, fraction, 0.14)
+}
+
+func TestConsistentHashScorerBoundedLoad(t *testing.T) {
+	scorer, err := NewConsistentHashScorer("x-user-id", "", DefaultLoadFactor, DefaultVirtualNodes)
+	assert.NoError(t, err)
+	pods := consistentHashPods(3)
+
+	for key := range 100 {
+		target := targetPod(scorer, fmt.Sprint(key), pods)
+
+		// The bound is ceil(1.25 * (4+1) / 3) = 3, the target pod stays below it.
+		loaded := consistentHashPods(3)
+		for _, pod := range loaded {
+			if podName(pod) == target {
+				pod.GetMetrics().RunningQueueSize = 1
+				pod.GetMetrics().WaitingQueueSize = 1
+			}
+		}
+		loaded[0].GetMetrics().RunningQueueSize += 2
+		if podName(loaded[0]) != target {
+			assert.Equal(t, target, targetPod(scorer, fmt.Sprint(key), loaded), "key %d should stay on its pod below the bound", key)
+		}
+
+		// The bound is ceil(1.25 * (10+1) / 3) = 5, the target pod is above it.
+		overloaded := consistentHashPods(3)
+		for _, pod := range overloaded {
+			if podName(pod) == target {
+				pod.GetMetrics().RunningQueueSize = 4
+				pod.GetMetrics().WaitingQueueSize = 6
+			}
+		}
+		assert.NotEqual(t, target, targetPod(scorer, fmt.Sprint(key), overloaded), "key %d should move off its overloaded pod", key)
+	}
+}
+
+func TestConsistentHashScorerRingCache(t *testing.T) {
+	scorer, err := NewConsistentHashScorer("x-user-id", "", DefaultLoadFactor, DefaultVirtualNodes)
+	assert.NoError(t, err)
+	pods := consistentHashPods(4)
+
+	targetPod(scorer, "key", pods)
+	ring := scorer.ring.Load()
+	assert.Len(t, ring.points, 4*DefaultVirtualNodes)
+
+	// the same pods, in another order and with new metrics, reuse the ring
+	reordered := consistentHashPods(4)
+	reordered[0], reordered[3] = reordered[3], reordered[0]
+	targetPod(scorer, "key", reordered)
+	assert.Same(t, ring, scorer.ring.Load())
+
+	// another set of pods rebuilds it
+	targetPod(scorer, "key", pods[:3])
+	assert.NotSame(t, ring, scorer.ring.Load())
+	assert.Len(t, scorer.ring.Load().points, 3*DefaultVirtualNodes)
+}
+
+func TestConsistentHashScorerFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		wantErr    bool
+	}{
+		{name: "header", parameters: `{"keyHeader": "x-user-id"}`},
+		{name: "body field", parameters: `{"keyBodyField": "user", "loadFactor": 1.5, "virtualNodes": 50}`},
+		{name: "no key", parameters: `{}`, wantErr: true},
+		{name: "both keys", parameters: `{"keyHeader": "x-user-id", "keyBodyField": "user"}`, wantErr: true},
+		{name: "load factor below 1", parameters: `{"keyHeader": "x-user-id", "loadFactor": 0.5}`, wantErr: true},
+		{name: "no virtual nodes", parameters: `{"keyHeader": "x-user-id", "virtualNodes": 0}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ConsistentHashScorerFactory("consistent-hash", json.RawMessage(test.parameters), nil)
+			assert.Equal(t, test.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func consistentHashPods(count int) []types.Pod {
+	pods := make([]types.Pod, 0, count)
+	for i := range count {
+		pods = append(pods, &types.PodMetrics{
+			Pod:          &backend.Pod{NamespacedName: k8stypes.NamespacedName{Namespace: "default", Name: fmt.Sprintf("pod%d", i)}},
+			MetricsState: &backendmetrics.MetricsState{},
+		})
+	}
+	return pods
+}
+
+func podName(pod types.Pod) string {
+	return pod.GetPod().NamespacedName.Name
+}
+
+// targetPod returns the name of the pod the scorer gives a score of 1 to, for the given key.
+func targetPod(scorer *ConsistentHashScorer, key string, pods []types.Pod) string {
+	request := &types.LLMRequest{Headers: map[string]string{"x-user-id": key}}
+	for pod, score := range scorer.Score(context.Background(), types.NewCycleState(), request, pods) {
+		if score == 1 {
+			return podName(pod)
+		}
+	}
+	return ""
+}
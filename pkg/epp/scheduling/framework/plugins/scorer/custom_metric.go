@@ -0,0 +1,147 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	CustomMetricScorerType = "custom-metric"
+)
+
+type customMetricScorerParameters struct {
+	Metric         string `json:"metric" description:"The name of the custom metric, as declared in the customMetrics of the configuration."`
+	HigherIsBetter bool   `json:"higherIsBetter" description:"Gives the higher scores to the pods with the higher values, rather than the lower ones."`
+}
+
+// CustomMetricScorerParameterSchema is the schema of the parameters of CustomMetricScorer.
+var CustomMetricScorerParameterSchema = plugins.NewParameterSchema(customMetricScorerParameters{})
+
+// compile-time type assertions
+var (
+	_ framework.Scorer            = &CustomMetricScorer{}
+	_ plugins.CustomMetricsPlugin = &CustomMetricScorer{}
+)
+
+// CustomMetricScorerFactory defines the factory function for CustomMetricScorer. The metric must be one of the custom
+// metrics of the handle.
+func CustomMetricScorerFactory(name string, rawParameters json.RawMessage, handle plugins.Handle) (plugins.Plugin, error) {
+	parameters := customMetricScorerParameters{}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", CustomMetricScorerType, err)
+		}
+	}
+	scorer, err := NewCustomMetricScorer(parameters.Metric, parameters.HigherIsBetter)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(handle.CustomMetrics(), parameters.Metric) {
+		return nil, fmt.Errorf("the metric %q of the '%s' scorer is not declared in the customMetrics of the configuration",
+			parameters.Metric, CustomMetricScorerType)
+	}
+	return scorer.WithName(name), nil
+}
+
+// NewCustomMetricScorer initializes a new CustomMetricScorer and returns its pointer.
+func NewCustomMetricScorer(metric string, higherIsBetter bool) (*CustomMetricScorer, error) {
+	if metric == "" {
+		return nil, errors.New("the custom metric must be set")
+	}
+	return &CustomMetricScorer{
+		name:           CustomMetricScorerType,
+		metric:         metric,
+		higherIsBetter: higherIsBetter,
+	}, nil
+}
+
+// CustomMetricScorer scores the pods by one of their custom metrics, scraped as declared in the customMetrics of
+// the configuration. The scores are normalized between the lowest and highest values of the pods, the lower values
+// get the higher scores unless higherIsBetter is set. The pods without the metric get a score of 0.
+type CustomMetricScorer struct {
+	name           string
+	metric         string
+	higherIsBetter bool
+}
+
+// Type returns the type of the scorer.
+func (s *CustomMetricScorer) Type() string {
+	return CustomMetricScorerType
+}
+
+// Name returns the name of the scorer.
+func (s *CustomMetricScorer) Name() string {
+	return s.name
+}
+
+// WithName sets the name of the scorer.
+func (s *CustomMetricScorer) WithName(name string) *CustomMetricScorer {
+	s.name = name
+	return s
+}
+
+// CustomMetrics returns the custom metric the scorer scores the pods by.
+func (s *CustomMetricScorer) CustomMetrics() []string {
+	return []string{s.metric}
+}
+
+// Score returns the scoring result for the given list of pods based on context.
+func (s *CustomMetricScorer) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	minValue := math.Inf(1)
+	maxValue := math.Inf(-1)
+	values := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		metrics := pod.GetMetrics()
+		if metrics == nil {
+			continue
+		}
+		value, ok := metrics.Custom[s.metric]
+		if !ok || math.IsNaN(value) {
+			continue
+		}
+		values[pod] = value
+		minValue = math.Min(minValue, value)
+		maxValue = math.Max(maxValue, value)
+	}
+
+	scores := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		value, ok := values[pod]
+		switch {
+		case !ok:
+			scores[pod] = 0
+		case maxValue == minValue:
+			// If all pods have the same value, return a neutral score
+			scores[pod] = 1
+		case s.higherIsBetter:
+			scores[pod] = (value - minValue) / (maxValue - minValue)
+		default:
+			scores[pod] = (maxValue - value) / (maxValue - minValue)
+		}
+	}
+	return scores
+}
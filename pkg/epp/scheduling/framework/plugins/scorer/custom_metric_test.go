@@ -0,0 +1,116 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	"sigs.k8s.io/gateway-api-inference-extension/test/utils"
+)
+
+func TestCustomMetricScorer(t *testing.T) {
+	tests := []struct {
+		name              string
+		higherIsBetter    bool
+		custom            []map[string]float64
+		expectedScoresPod map[int]float64 // Map of pod index to expected score
+	}{
+		{
+			name: "lower is better",
+			custom: []map[string]float64{
+				{"ttft_p90": 0.8},
+				{"ttft_p90": 0.5},
+				{"ttft_p90": 0.2},
+			},
+			expectedScoresPod: map[int]float64{0: 0.0, 1: 0.5, 2: 1.0},
+		},
+		{
+			name:           "higher is better",
+			higherIsBetter: true,
+			custom: []map[string]float64{
+				{"ttft_p90": 0.8},
+				{"ttft_p90": 0.5},
+				{"ttft_p90": 0.2},
+			},
+			expectedScoresPod: map[int]float64{0: 1.0, 1: 0.5, 2: 0.0},
+		},
+		{
+			name: "same values",
+			custom: []map[string]float64{
+				{"ttft_p90": 0.5},
+				{"ttft_p90": 0.5},
+			},
+			expectedScoresPod: map[int]float64{0: 1.0, 1: 1.0},
+		},
+		{
+			name: "pods without the metric",
+			custom: []map[string]float64{
+				{"ttft_p90": 0.8},
+				{"other": 0.1},
+				nil,
+				{"ttft_p90": 0.2},
+			},
+			expectedScoresPod: map[int]float64{0: 0.0, 1: 0.0, 2: 0.0, 3: 1.0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scorer, err := NewCustomMetricScorer("ttft_p90", test.higherIsBetter)
+			assert.NoError(t, err)
+			pods := make([]types.Pod, 0, len(test.custom))
+			for _, custom := range test.custom {
+				pods = append(pods, &types.PodMetrics{Pod: &backend.Pod{}, MetricsState: &backendmetrics.MetricsState{Custom: custom}})
+			}
+
+			scores := scorer.Score(context.Background(), types.NewCycleState(), &types.LLMRequest{}, pods)
+
+			for i, pod := range pods {
+				expectedScore := test.expectedScoresPod[i]
+				assert.InDelta(t, expectedScore, scores[pod], 0.0001, "Pod %d should have score %f", i, expectedScore)
+			}
+		})
+	}
+}
+
+func TestCustomMetricScorerFactory(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters string
+		wantErr    bool
+	}{
+		{name: "lower is better", parameters: `{"metric": "ttft_p90"}`},
+		{name: "higher is better", parameters: `{"metric": "throughput", "higherIsBetter": true}`},
+		{name: "no metric", parameters: `{}`, wantErr: true},
+		{name: "invalid json", parameters: `{"metric": 1}`, wantErr: true},
+		{name: "undeclared metric", parameters: `{"metric": "tpot_p90"}`, wantErr: true},
+	}
+	handle := utils.NewTestHandle("ttft_p90", "throughput")
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := CustomMetricScorerFactory("custom-metric", json.RawMessage(test.parameters), handle)
+			assert.Equal(t, test.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
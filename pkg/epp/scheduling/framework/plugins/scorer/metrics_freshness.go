@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/common/config"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+const (
+	MetricsFreshnessScorerType = "metrics-freshness"
+)
+
+type metricsFreshnessScorerParameters struct {
+	Threshold string `json:"threshold" description:"The age at which the metrics of a pod get a score of 0, as a duration, e.g. 200ms."`
+}
+
+// MetricsFreshnessScorerParameterSchema is the schema of the parameters of MetricsFreshnessScorer.
+var MetricsFreshnessScorerParameterSchema = plugins.NewParameterSchema(
+	metricsFreshnessScorerParameters{Threshold: config.DefaultMetricsStalenessThreshold.String()})
+
+// compile-time type assertion
+var _ framework.Scorer = &MetricsFreshnessScorer{}
+
+// MetricsFreshnessScorerFactory defines the factory function for MetricsFreshnessScorer.
+func MetricsFreshnessScorerFactory(name string, rawParameters json.RawMessage, _ plugins.Handle) (plugins.Plugin, error) {
+	parameters := metricsFreshnessScorerParameters{}
+	if len(rawParameters) > 0 {
+		if err := json.Unmarshal(rawParameters, &parameters); err != nil {
+			return nil, fmt.Errorf("failed to parse the parameters of the '%s' scorer - %w", MetricsFreshnessScorerType, err)
+		}
+	}
+	threshold, err := config.ParseMetricsStalenessThreshold(parameters.Threshold)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters of the '%s' scorer - %w", MetricsFreshnessScorerType, err)
+	}
+	return NewMetricsFreshnessScorer(threshold).WithName(name), nil
+}
+
+// NewMetricsFreshnessScorer initializes a new MetricsFreshnessScorer and returns its pointer.
+func NewMetricsFreshnessScorer(threshold time.Duration) *MetricsFreshnessScorer {
+	return &MetricsFreshnessScorer{
+		name:      MetricsFreshnessScorerType,
+		threshold: threshold,
+	}
+}
+
+// MetricsFreshnessScorer scores list of candidate pods based on the age of their metrics.
+// The score decreases linearly from 1 for metrics that were just updated, to 0 for metrics as old as the threshold.
+// Pods whose metrics are unknown get a score of 0.
+type MetricsFreshnessScorer struct {
+	name      string
+	threshold time.Duration
+}
+
+// Type returns the type of the scorer.
+func (s *MetricsFreshnessScorer) Type() string {
+	return MetricsFreshnessScorerType
+}
+
+// Name returns the name of the scorer.
+func (s *MetricsFreshnessScorer) Name() string {
+	return s.name
+}
+
+// WithName sets the name of the scorer.
+func (s *MetricsFreshnessScorer) WithName(name string) *MetricsFreshnessScorer {
+	s.name = name
+	return s
+}
+
+// Score returns the scoring result for the given list of pods based on context.
+func (s *MetricsFreshnessScorer) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	now := time.Now()
+	scores := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		metrics := pod.GetMetrics()
+		if metrics.IsUnknown() {
+			scores[pod] = 0
+			continue
+		}
+		scores[pod] = max(0, min(1, 1-float64(now.Sub(metrics.UpdateTime))/float64(s.threshold)))
+	}
+	return scores
+}
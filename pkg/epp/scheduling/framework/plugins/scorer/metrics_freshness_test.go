@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+func TestMetricsFreshnessScorer(t *testing.T) {
+	now := time.Now()
+	pods := []types.Pod{
+		&types.PodMetrics{Pod: &backend.Pod{}, MetricsState: &backendmetrics.MetricsState{UpdateTime: now.Add(time.Second)}},
+		&types.PodMetrics{Pod: &backend.Pod{}, MetricsState: &backendmetrics.MetricsState{UpdateTime: now.Add(-25 * time.Second)}},
+		&types.PodMetrics{Pod: &backend.Pod{}, MetricsState: &backendmetrics.MetricsState{UpdateTime: now.Add(-200 * time.Second)}},
+		&types.PodMetrics{Pod: &backend.Pod{}, MetricsState: &backendmetrics.MetricsState{}},
+	}
+	expectedScores := []float64{
+		1.0,  // Metrics updated after the scoring starts are capped to 1
+		0.75, // A quarter of the threshold old
+		0.0,  // Older than the threshold
+		0.0,  // Unknown metrics
+	}
+
+	scorer := NewMetricsFreshnessScorer(100 * time.Second)
+	scores := scorer.Score(context.Background(), types.NewCycleState(), &types.LLMRequest{}, pods)
+
+	for i, pod := range pods {
+		assert.InDelta(t, expectedScores[i], scores[pod], 0.001, "Pod %d should have score %f", i, expectedScores[i])
+	}
+}
+
+func TestMetricsFreshnessScorerFactory(t *testing.T) {
+	_, err := MetricsFreshnessScorerFactory("freshness", []byte(`{"threshold": "soon"}`), nil)
+	assert.Error(t, err, "an invalid threshold should be rejected")
+
+	thePlugin, err := MetricsFreshnessScorerFactory("freshness", nil, nil)
+	assert.NoError(t, err)
+	scorer := thePlugin.(*MetricsFreshnessScorer)
+	assert.Equal(t, "freshness", scorer.Name())
+	assert.Equal(t, 200*time.Millisecond, scorer.threshold)
+}
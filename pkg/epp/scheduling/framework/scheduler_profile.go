@@ -22,9 +22,11 @@ import (
 	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	errutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/error"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
@@ -45,6 +47,8 @@ type SchedulerProfile struct {
 	scorers          []*WeightedScorer
 	picker           Picker
 	postCyclePlugins []PostCycle
+	normalization    configapi.ScoreNormalization
+	strategy         configapi.ScoreCombinationStrategy
 }
 
 // WithFilters sets the given filter plugins as the Filter plugins.
@@ -61,6 +65,14 @@ func (p *SchedulerProfile) WithScorers(scorers ...*WeightedScorer) *SchedulerPro
 	return p
 }
 
+// WithScoreCombination sets how the scores of the Scorer plugins are normalized and combined into the score of each
+// pod. By default, the scores aren't normalized and the weighted scores are summed.
+func (p *SchedulerProfile) WithScoreCombination(normalization configapi.ScoreNormalization, strategy configapi.ScoreCombinationStrategy) *SchedulerProfile {
+	p.normalization = normalization
+	p.strategy = strategy
+	return p
+}
+
 // WithPicker sets the given picker plugins as the Picker plugin.
 // if the SchedulerProfile has Picker plugin, this call replaces the existing plugin with the given one.
 func (p *SchedulerProfile) WithPicker(picker Picker) *SchedulerProfile {
@@ -106,32 +118,72 @@ func (p *SchedulerProfile) AddPlugins(pluginObjects ...plugins.Plugin) error {
 
 // RunCycle runs a SchedulerProfile cycle. In other words, it invokes all the SchedulerProfile plugins in this
 // order - Filters, Scorers, Picker, PostCyclePlugins. After completing all, it returns the result.
+// If a DecisionTrace is stored in the CycleState, the decisions of the plugins are recorded in its active profile.
 func (p *SchedulerProfile) Run(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState, candidatePods []types.Pod) (*types.ProfileRunResult, error) {
-	pods := p.runFilterPlugins(ctx, request, cycleState, candidatePods)
+	return p.run(ctx, request, cycleState, candidatePods, true)
+}
+
+// RunShadow runs a SchedulerProfile cycle that must not affect routing: the plugins run on a copy of the CycleState,
+// and the PostCycle plugins, which update the state of the plugins with the result, are skipped. The decision trace
+// stored in the CycleState, if any, is still recorded.
+func (p *SchedulerProfile) RunShadow(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState,
+	candidatePods []types.Pod) (*types.ProfileRunResult, error) {
+	shadowCycleState := cycleState.Clone()
+	if decisionTrace := types.ReadDecisionTrace(cycleState); decisionTrace != nil {
+		shadowCycleState.Write(types.DecisionTraceStateKey, decisionTrace)
+	}
+	return p.run(ctx, request, shadowCycleState, candidatePods, false)
+}
+
+func (p *SchedulerProfile) run(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState, candidatePods []types.Pod,
+	runPostCycle bool) (*types.ProfileRunResult, error) {
+	var profileTrace *types.ProfileTrace
+	if decisionTrace := types.ReadDecisionTrace(cycleState); decisionTrace != nil {
+		profileTrace = decisionTrace.ActiveProfile()
+	}
+	if profileTrace != nil {
+		profileTrace.CandidatePods = types.PodNames(candidatePods)
+	}
+
+	pods := p.runFilterPlugins(ctx, request, cycleState, candidatePods, profileTrace)
 	if len(pods) == 0 {
 		return nil, errutil.Error{Code: errutil.Internal, Msg: "no pods available for the given request"}
 	}
 	// if we got here, there is at least one pod to score
-	weightedScorePerPod := p.runScorerPlugins(ctx, request, cycleState, pods)
+	weightedScorePerPod := p.runScorerPlugins(ctx, request, cycleState, pods, profileTrace)
 
-	result := p.runPickerPlugin(ctx, cycleState, weightedScorePerPod)
+	result := p.runPickerPlugin(ctx, cycleState, weightedScorePerPod, profileTrace)
 
-	p.runPostCyclePlugins(ctx, cycleState, result)
+	if runPostCycle {
+		p.runPostCyclePlugins(ctx, cycleState, result)
+	}
 
 	return result, nil
 }
 
-func (p *SchedulerProfile) runFilterPlugins(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState, pods []types.Pod) []types.Pod {
+func (p *SchedulerProfile) runFilterPlugins(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState, pods []types.Pod,
+	profileTrace *types.ProfileTrace) []types.Pod {
 	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
 	filteredPods := pods
 	loggerDebug.Info("Before running filter plugins", "pods", filteredPods)
 
 	for _, filter := range p.filters {
 		loggerDebug.Info("Running filter plugin", "plugin", filter.Type())
+		spanCtx, span := tracing.StartPluginSpan(ctx, FilterPluginType, filter)
 		before := time.Now()
-		filteredPods = filter.Filter(ctx, cycleState, request, filteredPods)
+		podsBefore := filteredPods
+		filteredPods = filter.Filter(spanCtx, cycleState, request, filteredPods)
 		metrics.RecordSchedulerPluginProcessingLatency(FilterPluginType, filter.Type(), time.Since(before))
+		span.SetAttributes(tracing.FilteredPodsKey.Int(len(filteredPods)))
+		span.End()
 		loggerDebug.Info("Filter plugin result", "plugin", filter.Type(), "pods", filteredPods)
+		if profileTrace != nil {
+			profileTrace.Filters = append(profileTrace.Filters, &types.FilterTrace{
+				Type:        filter.Type(),
+				Name:        filter.Name(),
+				RemovedPods: removedPods(podsBefore, filteredPods),
+			})
+		}
 		if len(filteredPods) == 0 {
 			break
 		}
@@ -141,31 +193,73 @@ func (p *SchedulerProfile) runFilterPlugins(ctx context.Context, request *types.
 	return filteredPods
 }
 
-func (p *SchedulerProfile) runScorerPlugins(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState, pods []types.Pod) map[types.Pod]float64 {
+// removedPods returns the names of the pods that were filtered out.
+func removedPods(before []types.Pod, after []types.Pod) []string {
+	kept := make(map[string]struct{}, len(after))
+	for _, pod := range after {
+		kept[types.PodName(pod)] = struct{}{}
+	}
+	removed := []string{}
+	for _, pod := range before {
+		if _, ok := kept[types.PodName(pod)]; !ok {
+			removed = append(removed, types.PodName(pod))
+		}
+	}
+	return removed
+}
+
+func (p *SchedulerProfile) runScorerPlugins(ctx context.Context, request *types.LLMRequest, cycleState *types.CycleState, pods []types.Pod,
+	profileTrace *types.ProfileTrace) map[types.Pod]float64 {
 	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
 	loggerDebug.Info("Before running scorer plugins", "pods", pods)
 
-	weightedScorePerPod := make(map[types.Pod]float64, len(pods))
-	for _, pod := range pods {
-		weightedScorePerPod[pod] = float64(0) // initialize weighted score per pod with 0 value
-	}
-	// Iterate through each scorer in the chain and accumulate the weighted scores.
+	// Iterate through each scorer in the chain, normalize and weight its scores, then combine the weighted scores.
+	weightedScores := make([]map[types.Pod]float64, 0, len(p.scorers))
 	for _, scorer := range p.scorers {
 		loggerDebug.Info("Running scorer", "scorer", scorer.Type())
+		spanCtx, span := tracing.StartPluginSpan(ctx, ScorerPluginType, scorer)
 		before := time.Now()
-		scores := scorer.Score(ctx, cycleState, request, pods)
+		scores := scorer.Score(spanCtx, cycleState, request, pods)
 		metrics.RecordSchedulerPluginProcessingLatency(ScorerPluginType, scorer.Type(), time.Since(before))
-		for pod, score := range scores { // weight is relative to the sum of weights
-			weightedScorePerPod[pod] += score * float64(scorer.Weight())
+		span.End()
+		normalizedScores := normalizeScores(p.normalization, pods, scores)
+		scorerWeightedScores := make(map[types.Pod]float64, len(pods))
+		for pod, score := range normalizedScores {
+			scorerWeightedScores[pod] = weightScore(p.strategy, score, scorer.Weight())
+		}
+		weightedScores = append(weightedScores, scorerWeightedScores)
+		if profileTrace != nil {
+			scorerTrace := &types.ScorerTrace{
+				Type:           scorer.Type(),
+				Name:           scorer.Name(),
+				Weight:         scorer.Weight(),
+				Scores:         make(map[string]float64, len(scores)),
+				WeightedScores: make(map[string]float64, len(scorerWeightedScores)),
+			}
+			for pod, score := range scores {
+				scorerTrace.Scores[types.PodName(pod)] = score
+			}
+			if p.normalization != "" && p.normalization != configapi.NoScoreNormalization {
+				scorerTrace.NormalizedScores = make(map[string]float64, len(normalizedScores))
+				for pod, score := range normalizedScores {
+					scorerTrace.NormalizedScores[types.PodName(pod)] = score
+				}
+			}
+			for pod, score := range scorerWeightedScores {
+				scorerTrace.WeightedScores[types.PodName(pod)] = score
+			}
+			profileTrace.Scorers = append(profileTrace.Scorers, scorerTrace)
 		}
 		loggerDebug.Info("After running scorer", "scorer", scorer.Type())
 	}
+	weightedScorePerPod := combineScores(p.strategy, pods, weightedScores)
 	loggerDebug.Info("After running scorer plugins")
 
 	return weightedScorePerPod
 }
 
-func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, cycleState *types.CycleState, weightedScorePerPod map[types.Pod]float64) *types.ProfileRunResult {
+func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, cycleState *types.CycleState, weightedScorePerPod map[types.Pod]float64,
+	profileTrace *types.ProfileTrace) *types.ProfileRunResult {
 	loggerDebug := log.FromContext(ctx).V(logutil.DEBUG)
 	scoredPods := make([]*types.ScoredPod, len(weightedScorePerPod))
 	i := 0
@@ -175,19 +269,41 @@ func (p *SchedulerProfile) runPickerPlugin(ctx context.Context, cycleState *type
 	}
 
 	loggerDebug.Info("Before running picker plugin", "pods weighted score", fmt.Sprint(weightedScorePerPod))
+	spanCtx, span := tracing.StartPluginSpan(ctx, PickerPluginType, p.picker)
 	before := time.Now()
-	result := p.picker.Pick(ctx, cycleState, scoredPods)
+	result := p.picker.Pick(spanCtx, cycleState, scoredPods)
 	metrics.RecordSchedulerPluginProcessingLatency(PickerPluginType, p.picker.Type(), time.Since(before))
+	span.End()
 	loggerDebug.Info("After running picker plugin", "result", result)
 
+	if profileTrace != nil {
+		pickerTrace := &types.PickerTrace{
+			Type:   p.picker.Type(),
+			Name:   p.picker.Name(),
+			Scores: make(map[string]float64, len(weightedScorePerPod)),
+		}
+		for pod, score := range weightedScorePerPod {
+			pickerTrace.Scores[types.PodName(pod)] = score
+		}
+		if result != nil {
+			pickerTrace.TargetPod = types.PodName(result.TargetPod)
+			if len(result.RankedPods) > 1 {
+				pickerTrace.RankedPods = types.PodNames(result.RankedPods)
+			}
+		}
+		profileTrace.Picker = pickerTrace
+	}
+
 	return result
 }
 
 func (p *SchedulerProfile) runPostCyclePlugins(ctx context.Context, cycleState *types.CycleState, result *types.ProfileRunResult) {
 	for _, plugin := range p.postCyclePlugins {
 		log.FromContext(ctx).V(logutil.DEBUG).Info("Running post-cycle plugin", "plugin", plugin.Type())
+		spanCtx, span := tracing.StartPluginSpan(ctx, PostCyclePluginType, plugin)
 		before := time.Now()
-		plugin.PostCycle(ctx, cycleState, result)
+		plugin.PostCycle(spanCtx, cycleState, result)
 		metrics.RecordSchedulerPluginProcessingLatency(PostCyclePluginType, plugin.Type(), time.Since(before))
+		span.End()
 	}
 }
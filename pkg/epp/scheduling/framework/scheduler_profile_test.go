@@ -266,3 +266,81 @@ func findPods(pods []types.Pod, names ...k8stypes.NamespacedName) []types.Pod {
 	}
 	return res
 }
+
+func TestSchedulePluginsDecisionTrace(t *testing.T) {
+	tp1 := &testPlugin{
+		TypeRes:   "test1",
+		ScoreRes:  0.3,
+		FilterRes: []k8stypes.NamespacedName{{Name: "pod1"}, {Name: "pod2"}},
+	}
+	tp2 := &testPlugin{
+		TypeRes:   "test2",
+		ScoreRes:  0.8,
+		FilterRes: []k8stypes.NamespacedName{{Name: "pod1"}, {Name: "pod2"}},
+	}
+	pickerPlugin := &testPlugin{
+		TypeRes: "picker",
+		PickRes: k8stypes.NamespacedName{Name: "pod2"},
+	}
+	profile := NewSchedulerProfile().
+		WithFilters(tp1, tp2).
+		WithScorers(NewWeightedScorer(tp1, 2), NewWeightedScorer(tp2, 1)).
+		WithPicker(pickerPlugin)
+	input := []backendmetrics.PodMetrics{
+		&backendmetrics.FakePodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod1"}}},
+		&backendmetrics.FakePodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod2"}}},
+		&backendmetrics.FakePodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod3"}}},
+	}
+
+	cycleState := types.NewCycleState()
+	decisionTrace := types.NewDecisionTrace()
+	cycleState.Write(types.DecisionTraceStateKey, decisionTrace)
+	decisionTrace.StartProfile("default")
+	request := &types.LLMRequest{TargetModel: "test-model", RequestId: uuid.NewString()}
+	if _, err := profile.Run(context.Background(), request, cycleState, types.ToSchedulerPodMetrics(input)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := &types.DecisionTrace{
+		Profiles: []*types.ProfileTrace{
+			{
+				Name:          "default",
+				CandidatePods: []string{"/pod1", "/pod2", "/pod3"},
+				Filters: []*types.FilterTrace{
+					{Type: "test1", Name: "test-plugin", RemovedPods: []string{"/pod3"}},
+					{Type: "test2", Name: "test-plugin", RemovedPods: []string{}},
+				},
+				Scorers: []*types.ScorerTrace{
+					{
+						Type:           "test1",
+						Name:           "test-plugin",
+						Weight:         2,
+						Scores:         map[string]float64{"/pod1": 0.3, "/pod2": 0.3},
+						WeightedScores: map[string]float64{"/pod1": 0.6, "/pod2": 0.6},
+					},
+					{
+						Type:           "test2",
+						Name:           "test-plugin",
+						Weight:         1,
+						Scores:         map[string]float64{"/pod1": 0.8, "/pod2": 0.8},
+						WeightedScores: map[string]float64{"/pod1": 0.8, "/pod2": 0.8},
+					},
+				},
+				Picker: &types.PickerTrace{
+					Type:      "picker",
+					Name:      "test-plugin",
+					Scores:    map[string]float64{"/pod1": 1.4, "/pod2": 1.4},
+					TargetPod: "/pod2",
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, decisionTrace); diff != "" {
+		t.Errorf("Unexpected decision trace (-want +got): %s", diff)
+	}
+
+	// Without a decision trace in the cycle state, nothing is recorded.
+	if _, err := profile.Run(context.Background(), request, types.NewCycleState(), types.ToSchedulerPodMetrics(input)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
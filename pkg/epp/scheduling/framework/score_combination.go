@@ -0,0 +1,133 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+// ValidateScoreCombination returns an error if the normalization or the strategy is unknown.
+// An empty normalization or strategy stands for the default one.
+func ValidateScoreCombination(normalization configapi.ScoreNormalization, strategy configapi.ScoreCombinationStrategy) error {
+	switch normalization {
+	case "", configapi.NoScoreNormalization, configapi.MinMaxScoreNormalization, configapi.RankScoreNormalization:
+	default:
+		return fmt.Errorf("unknown score normalization '%s'", normalization)
+	}
+	switch strategy {
+	case "", configapi.SumScoreCombination, configapi.ProductScoreCombination, configapi.LexicographicScoreCombination:
+	default:
+		return fmt.Errorf("unknown score combination strategy '%s'", strategy)
+	}
+	return nil
+}
+
+// normalizeScores returns the normalized scores of a scorer. A pod that wasn't scored by the scorer has a score of 0.
+func normalizeScores(normalization configapi.ScoreNormalization, pods []types.Pod, scores map[types.Pod]float64) map[types.Pod]float64 {
+	normalized := make(map[types.Pod]float64, len(pods))
+	switch normalization {
+	case configapi.MinMaxScoreNormalization:
+		minScore, maxScore := math.Inf(1), math.Inf(-1)
+		for _, pod := range pods {
+			minScore = math.Min(minScore, scores[pod])
+			maxScore = math.Max(maxScore, scores[pod])
+		}
+		for _, pod := range pods {
+			if maxScore == minScore {
+				normalized[pod] = 1
+			} else {
+				normalized[pod] = (scores[pod] - minScore) / (maxScore - minScore)
+			}
+		}
+	case configapi.RankScoreNormalization:
+		for _, pod := range pods {
+			normalized[pod] = rank(pods, func(other types.Pod) bool { return scores[other] < scores[pod] })
+		}
+	default:
+		for _, pod := range pods {
+			normalized[pod] = scores[pod]
+		}
+	}
+	return normalized
+}
+
+// rank returns the fraction of the other pods that are lower than a pod, by the given comparison.
+// A single pod has a rank of 1.
+func rank(pods []types.Pod, isLower func(types.Pod) bool) float64 {
+	if len(pods) <= 1 {
+		return 1
+	}
+	lower := 0
+	for _, other := range pods {
+		if isLower(other) {
+			lower++
+		}
+	}
+	return float64(lower) / float64(len(pods)-1)
+}
+
+// weightScore returns the contribution of a normalized score to the combined score of a pod.
+func weightScore(strategy configapi.ScoreCombinationStrategy, score float64, weight float64) float64 {
+	switch strategy {
+	case configapi.ProductScoreCombination:
+		return math.Pow(score, weight)
+	case configapi.LexicographicScoreCombination:
+		return score
+	default:
+		return score * weight
+	}
+}
+
+// combineScores combines the weighted scores of the scorers, given in the order of the scorers, into the score of
+// each pod.
+func combineScores(strategy configapi.ScoreCombinationStrategy, pods []types.Pod, weightedScores []map[types.Pod]float64) map[types.Pod]float64 {
+	combined := make(map[types.Pod]float64, len(pods))
+	switch strategy {
+	case configapi.ProductScoreCombination:
+		for _, pod := range pods {
+			combined[pod] = 1
+			for _, scores := range weightedScores {
+				combined[pod] *= scores[pod]
+			}
+		}
+	case configapi.LexicographicScoreCombination:
+		vectors := make(map[types.Pod][]float64, len(pods))
+		for _, pod := range pods {
+			vector := make([]float64, len(weightedScores))
+			for idx, scores := range weightedScores {
+				vector[idx] = scores[pod]
+			}
+			vectors[pod] = vector
+		}
+		for _, pod := range pods {
+			combined[pod] = rank(pods, func(other types.Pod) bool { return slices.Compare(vectors[other], vectors[pod]) < 0 })
+		}
+	default:
+		for _, pod := range pods {
+			combined[pod] = 0
+			for _, scores := range weightedScores {
+				combined[pod] += scores[pod]
+			}
+		}
+	}
+	return combined
+}
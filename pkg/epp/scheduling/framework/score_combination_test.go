@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+)
+
+// TestScoreCombination runs two scorers that disagree, the first one favoring pod1 and the second one pod3, and
+// checks how the normalization and the combination strategy of the profile change the ranking of the pods.
+func TestScoreCombination(t *testing.T) {
+	first := &podScorer{name: "first", scores: map[string]float64{"pod1": 0.9, "pod2": 0.6, "pod3": 0.1}}
+	second := &podScorer{name: "second", scores: map[string]float64{"pod1": 0.1, "pod2": 0.5, "pod3": 0.55}}
+
+	tests := []struct {
+		name          string
+		normalization configapi.ScoreNormalization
+		strategy      configapi.ScoreCombinationStrategy
+		weights       [2]float64
+		wantScores    map[string]float64
+		wantRanking   []string
+	}{
+		{
+			name:        "default sums the raw weighted scores",
+			weights:     [2]float64{1, 1},
+			wantScores:  map[string]float64{"/pod1": 1.0, "/pod2": 1.1, "/pod3": 0.65},
+			wantRanking: []string{"/pod2", "/pod1", "/pod3"},
+		},
+		{
+			name:        "fractional weights",
+			weights:     [2]float64{1, 2.5},
+			wantScores:  map[string]float64{"/pod1": 1.15, "/pod2": 1.85, "/pod3": 1.475},
+			wantRanking: []string{"/pod2", "/pod3", "/pod1"},
+		},
+		{
+			name:          "min-max normalization",
+			normalization: configapi.MinMaxScoreNormalization,
+			strategy:      configapi.SumScoreCombination,
+			weights:       [2]float64{0.5, 1.5},
+			wantScores:    map[string]float64{"/pod1": 0.5, "/pod2": 0.3125 + 1.5*0.4/0.45, "/pod3": 1.5},
+			wantRanking:   []string{"/pod2", "/pod3", "/pod1"},
+		},
+		{
+			name:          "rank normalization",
+			normalization: configapi.RankScoreNormalization,
+			strategy:      configapi.SumScoreCombination,
+			weights:       [2]float64{1, 2},
+			wantScores:    map[string]float64{"/pod1": 1, "/pod2": 1.5, "/pod3": 2},
+			wantRanking:   []string{"/pod3", "/pod2", "/pod1"},
+		},
+		{
+			name:          "product",
+			normalization: configapi.NoScoreNormalization,
+			strategy:      configapi.ProductScoreCombination,
+			weights:       [2]float64{2, 1},
+			wantScores:    map[string]float64{"/pod1": 0.081, "/pod2": 0.18, "/pod3": 0.0055},
+			wantRanking:   []string{"/pod2", "/pod1", "/pod3"},
+		},
+		{
+			name:          "lexicographic ignores the weights",
+			normalization: configapi.NoScoreNormalization,
+			strategy:      configapi.LexicographicScoreCombination,
+			weights:       [2]float64{1, 100},
+			wantScores:    map[string]float64{"/pod1": 1, "/pod2": 0.5, "/pod3": 0},
+			wantRanking:   []string{"/pod1", "/pod2", "/pod3"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := NewSchedulerProfile().
+				WithScorers(NewWeightedScorer(first, test.weights[0]), NewWeightedScorer(second, test.weights[1])).
+				WithScoreCombination(test.normalization, test.strategy)
+
+			got := profile.runScorerPlugins(context.Background(), &types.LLMRequest{}, types.NewCycleState(), testPods("pod1", "pod2", "pod3"), nil)
+
+			gotScores := map[string]float64{}
+			for pod, score := range got {
+				gotScores[types.PodName(pod)] = score
+			}
+			if diff := cmp.Diff(test.wantScores, gotScores, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected scores (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(test.wantRanking, ranking(gotScores)); diff != "" {
+				t.Errorf("Unexpected ranking (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestScoreCombinationTies(t *testing.T) {
+	first := &podScorer{name: "first", scores: map[string]float64{"pod1": 0.5, "pod2": 0.5, "pod3": 0.5}}
+	second := &podScorer{name: "second", scores: map[string]float64{"pod1": 0.2, "pod2": 0.7, "pod3": 0.2}}
+	pods := testPods("pod1", "pod2", "pod3")
+
+	tests := []struct {
+		name          string
+		normalization configapi.ScoreNormalization
+		strategy      configapi.ScoreCombinationStrategy
+		wantScores    map[string]float64
+	}{
+		{
+			name:          "min-max gives 1 to equal scores",
+			normalization: configapi.MinMaxScoreNormalization,
+			strategy:      configapi.SumScoreCombination,
+			wantScores:    map[string]float64{"/pod1": 1, "/pod2": 2, "/pod3": 1},
+		},
+		{
+			name:          "rank gives the same rank to equal scores",
+			normalization: configapi.RankScoreNormalization,
+			strategy:      configapi.SumScoreCombination,
+			wantScores:    map[string]float64{"/pod1": 0, "/pod2": 1, "/pod3": 0},
+		},
+		{
+			name:          "lexicographic breaks ties with the next scorer",
+			normalization: configapi.NoScoreNormalization,
+			strategy:      configapi.LexicographicScoreCombination,
+			wantScores:    map[string]float64{"/pod1": 0, "/pod2": 1, "/pod3": 0},
+		},
+		{
+			name:          "product with a zero score",
+			normalization: configapi.MinMaxScoreNormalization,
+			strategy:      configapi.ProductScoreCombination,
+			wantScores:    map[string]float64{"/pod1": 0, "/pod2": 1, "/pod3": 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile := NewSchedulerProfile().
+				WithScorers(NewWeightedScorer(first, 1), NewWeightedScorer(second, 1)).
+				WithScoreCombination(test.normalization, test.strategy)
+
+			got := profile.runScorerPlugins(context.Background(), &types.LLMRequest{}, types.NewCycleState(), pods, nil)
+
+			gotScores := map[string]float64{}
+			for pod, score := range got {
+				gotScores[types.PodName(pod)] = score
+			}
+			if diff := cmp.Diff(test.wantScores, gotScores, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected scores (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateScoreCombination(t *testing.T) {
+	if err := ValidateScoreCombination("", ""); err != nil {
+		t.Errorf("Unexpected error for the default score combination: %v", err)
+	}
+	if err := ValidateScoreCombination(configapi.RankScoreNormalization, configapi.LexicographicScoreCombination); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := ValidateScoreCombination("ZScore", configapi.SumScoreCombination); err == nil {
+		t.Error("Expected an error for an unknown normalization")
+	}
+	if err := ValidateScoreCombination(configapi.NoScoreNormalization, "Max"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+// podScorer scores the pods by name.
+type podScorer struct {
+	name   string
+	scores map[string]float64
+}
+
+func (s *podScorer) Type() string { return "pod-scorer" }
+
+func (s *podScorer) Name() string { return s.name }
+
+func (s *podScorer) Score(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) map[types.Pod]float64 {
+	scores := make(map[types.Pod]float64, len(pods))
+	for _, pod := range pods {
+		scores[pod] = s.scores[pod.GetPod().NamespacedName.Name]
+	}
+	return scores
+}
+
+func testPods(names ...string) []types.Pod {
+	podMetrics := make([]backendmetrics.PodMetrics, 0, len(names))
+	for _, name := range names {
+		podMetrics = append(podMetrics, &backendmetrics.FakePodMetrics{Pod: &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: name}}})
+	}
+	return types.ToSchedulerPodMetrics(podMetrics)
+}
+
+// ranking returns the pod names ordered by decreasing score.
+func ranking(scores map[string]float64) []string {
+	names := make([]string, 0, len(scores))
+	for name := range scores {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return scores[names[i]] > scores[names[j]] })
+	return names
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/metrics"
@@ -31,6 +32,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
 )
 
@@ -43,12 +45,12 @@ func NewScheduler() *Scheduler {
 	// When the scheduler is initialized with NewScheduler function, thw below config will be used as default.
 	// it's possible to call NewSchedulerWithConfig to pass a different scheduler config.
 	// For build time plugins changes, it's recommended to call in main.go to NewSchedulerWithConfig.
-	loraAffinityFilter := filter.NewLoraAffinityFilter(config.Conf.LoraAffinityThreshold)
+	loraAffinityFilter := filter.NewLoraAffinityFilter(config.DefaultLoraAffinityThreshold)
 	leastQueueFilter := filter.NewLeastQueueFilter()
 	leastKvCacheFilter := filter.NewLeastKVCacheFilter()
 
 	lowLatencyFilter := &filter.DecisionTreeFilter{
-		Current: filter.NewLowQueueFilter(config.Conf.QueueingThresholdLoRA),
+		Current: filter.NewLowQueueFilter(config.DefaultQueueingThresholdLoRA),
 		NextOnSuccess: &filter.DecisionTreeFilter{
 			Current: loraAffinityFilter,
 			NextOnSuccessOrFailure: &filter.DecisionTreeFilter{
@@ -87,8 +89,16 @@ func NewSchedulerWithConfig(config *SchedulerConfig) *Scheduler {
 }
 
 type Scheduler struct {
-	profileHandler framework.ProfileHandler
-	profiles       map[string]*framework.SchedulerProfile
+	profileHandler      framework.ProfileHandler
+	profiles            map[string]*framework.SchedulerProfile
+	recordDecisionTrace bool
+}
+
+// WithDecisionTrace sets whether the scheduler records the DecisionTrace of every request. The trace is logged and
+// returned in the SchedulingResult.
+func (s *Scheduler) WithDecisionTrace(enabled bool) *Scheduler {
+	s.recordDecisionTrace = enabled
+	return s
 }
 
 // Schedule finds the target pod based on metrics and the requested lora adapter.
@@ -96,6 +106,7 @@ func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, can
 	logger := log.FromContext(ctx).WithValues("request", request)
 	loggerDebug := logger.V(logutil.DEBUG)
 
+	ctx, span := tracing.Tracer().Start(ctx, "Schedule", trace.WithAttributes(tracing.CandidatePodsKey.Int(len(candidatePods))))
 	scheduleStart := time.Now()
 	defer func() {
 		metrics.RecordSchedulerE2ELatency(time.Since(scheduleStart))
@@ -103,20 +114,45 @@ func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, can
 
 	profileRunResults := map[string]*types.ProfileRunResult{}
 	cycleState := types.NewCycleState()
+	var decisionTrace *types.DecisionTrace
+	if s.recordDecisionTrace {
+		decisionTrace = types.NewDecisionTrace()
+		cycleState.Write(types.DecisionTraceStateKey, decisionTrace)
+		defer func() {
+			logger.Info("Scheduling decision", "trace", decisionTrace)
+		}()
+	}
 
 	for { // get the next set of profiles to run iteratively based on the request and the previous execution results
+		pickCtx, pickSpan := tracing.StartPluginSpan(ctx, framework.ProfilePickerType, s.profileHandler)
 		before := time.Now()
-		profiles := s.profileHandler.Pick(ctx, cycleState, request, s.profiles, profileRunResults)
+		profiles := s.profileHandler.Pick(pickCtx, cycleState, request, s.profiles, profileRunResults)
 		metrics.RecordSchedulerPluginProcessingLatency(framework.ProfilePickerType, s.profileHandler.Type(), time.Since(before))
+		pickSpan.End()
 		if len(profiles) == 0 { // profile picker didn't pick any profile to run
 			break
 		}
 
 		for name, profile := range profiles {
 			// run the selected profiles and collect results (current code runs all profiles)
-			profileRunResult, err := profile.Run(ctx, request, cycleState, candidatePods)
+			shadow := s.isShadowProfile(name)
+			var profileTrace *types.ProfileTrace
+			if decisionTrace != nil {
+				profileTrace = decisionTrace.StartProfile(name)
+				profileTrace.Shadow = shadow
+			}
+			profileCtx, profileSpan := tracing.Tracer().Start(ctx, "SchedulingProfile", trace.WithAttributes(tracing.ProfileKey.String(name)))
+			run := profile.Run
+			if shadow {
+				run = profile.RunShadow
+			}
+			profileRunResult, err := run(profileCtx, request, cycleState, candidatePods)
+			tracing.EndSpan(profileSpan, err)
 			if err != nil {
 				loggerDebug.Info("failed to run scheduler profile", "profile", name, "error", err.Error())
+				if profileTrace != nil {
+					profileTrace.Error = err.Error()
+				}
 			}
 
 			profileRunResults[name] = profileRunResult // if profile failed to run, the run result is nil
@@ -124,12 +160,26 @@ func (s *Scheduler) Schedule(ctx context.Context, request *types.LLMRequest, can
 	}
 
 	if len(profileRunResults) == 0 {
-		return nil, fmt.Errorf("failed to run any SchedulingProfile for the request - %s", request)
+		err := fmt.Errorf("failed to run any SchedulingProfile for the request - %s", request)
+		tracing.EndSpan(span, err)
+		return nil, err
 	}
 
+	processCtx, processSpan := tracing.StartPluginSpan(ctx, framework.ProcessProfilesResultsType, s.profileHandler)
 	before := time.Now()
-	result, err := s.profileHandler.ProcessResults(ctx, cycleState, request, profileRunResults)
+	result, err := s.profileHandler.ProcessResults(processCtx, cycleState, request, profileRunResults)
 	metrics.RecordSchedulerPluginProcessingLatency(framework.ProcessProfilesResultsType, s.profileHandler.Type(), time.Since(before))
+	processSpan.End()
+	if result != nil {
+		result.DecisionTrace = decisionTrace
+	}
 
+	tracing.EndSpan(span, err)
 	return result, err
 }
+
+// isShadowProfile returns true if the profile handler runs the profile with the given name as a shadow profile.
+func (s *Scheduler) isShadowProfile(name string) bool {
+	shadowProfileHandler, ok := s.profileHandler.(framework.ShadowProfileHandler)
+	return ok && shadowProfileHandler.IsShadowProfile(name)
+}
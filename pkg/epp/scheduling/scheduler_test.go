@@ -22,10 +22,19 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
 	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics" // Import config for thresholds
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/filter"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/tracing"
 )
 
 // Tests the default scheduler configuration and expected behavior.
@@ -131,3 +140,161 @@ func TestSchedule(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	ctx := tracing.Extract(context.Background(), map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	pods := []backendmetrics.PodMetrics{
+		&backendmetrics.FakePodMetrics{
+			Pod:     &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod1"}},
+			Metrics: &backendmetrics.MetricsState{},
+		},
+	}
+	if _, err := NewScheduler().Schedule(ctx, &types.LLMRequest{TargetModel: "model", RequestId: uuid.NewString()},
+		types.ToSchedulerPodMetrics(pods)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := map[string]bool{}
+	for _, span := range exporter.GetSpans() {
+		got[span.Name] = true
+		if span.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+			t.Errorf("Expected span %s to continue the trace of the request, got trace ID %s", span.Name, span.SpanContext.TraceID())
+		}
+	}
+	want := map[string]bool{
+		"Schedule":                              true,
+		"SchedulingProfile":                     true,
+		"ProfilePicker/single-profile":          true,
+		"Filter/" + filter.LowQueueFilterType:   true,
+		"Picker/random":                         true,
+		"ProcessProfilesResults/single-profile": true,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected spans (-want +got): %s", diff)
+	}
+}
+
+func TestScheduleDecisionTrace(t *testing.T) {
+	pods := []backendmetrics.PodMetrics{
+		&backendmetrics.FakePodMetrics{
+			Pod:     &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod1", Namespace: "default"}},
+			Metrics: &backendmetrics.MetricsState{},
+		},
+	}
+	request := &types.LLMRequest{TargetModel: "model", RequestId: uuid.NewString()}
+
+	got, err := NewScheduler().Schedule(context.Background(), request, types.ToSchedulerPodMetrics(pods))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.DecisionTrace != nil {
+		t.Errorf("Expected no decision trace by default, got %+v", got.DecisionTrace)
+	}
+
+	got, err = NewScheduler().WithDecisionTrace(true).Schedule(context.Background(), request, types.ToSchedulerPodMetrics(pods))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.DecisionTrace == nil || len(got.DecisionTrace.Profiles) != 1 {
+		t.Fatalf("Expected a decision trace of a single profile, got %+v", got.DecisionTrace)
+	}
+	profileTrace := got.DecisionTrace.Profiles[0]
+	if profileTrace.Name != "default" || profileTrace.Picker == nil || profileTrace.Picker.TargetPod != "default/pod1" {
+		t.Errorf("Unexpected profile trace %+v", profileTrace)
+	}
+}
+
+func TestScheduleShadowProfiles(t *testing.T) {
+	pods := []backendmetrics.PodMetrics{
+		&backendmetrics.FakePodMetrics{
+			Pod:     &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod1", Namespace: "default"}},
+			Metrics: &backendmetrics.MetricsState{},
+		},
+		&backendmetrics.FakePodMetrics{
+			Pod:     &backend.Pod{NamespacedName: k8stypes.NamespacedName{Name: "pod2", Namespace: "default"}},
+			Metrics: &backendmetrics.MetricsState{},
+		},
+	}
+	primaryPostCycle := &countingPostCycle{}
+	shadowPostCycle := &countingPostCycle{}
+	primary := framework.NewSchedulerProfile().
+		WithFilters(&keepPodFilter{pod: "default/pod1"}).
+		WithPicker(picker.NewRandomPicker()).
+		WithPostCyclePlugins(primaryPostCycle)
+	shadow := framework.NewSchedulerProfile().
+		WithFilters(&keepPodFilter{pod: "default/pod2"}).
+		WithPicker(picker.NewRandomPicker()).
+		WithPostCyclePlugins(shadowPostCycle)
+	profileHandler, err := profile.NewShadowProfileHandler("primary", []string{"shadow"}, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	scheduler := NewSchedulerWithConfig(NewSchedulerConfig(profileHandler,
+		map[string]*framework.SchedulerProfile{"primary": primary, "shadow": shadow})).WithDecisionTrace(true)
+
+	request := &types.LLMRequest{TargetModel: "model", RequestId: uuid.NewString()}
+	got, err := scheduler.Schedule(context.Background(), request, types.ToSchedulerPodMetrics(pods))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.PrimaryProfileName != "primary" || len(got.ProfileResults) != 1 ||
+		types.PodName(got.ProfileResults["primary"].TargetPod) != "default/pod1" {
+		t.Errorf("Unexpected scheduling result %+v", got)
+	}
+	if primaryPostCycle.calls != 1 || shadowPostCycle.calls != 0 {
+		t.Errorf("Unexpected PostCycle calls, primary: %d, shadow: %d", primaryPostCycle.calls, shadowPostCycle.calls)
+	}
+	shadowTraces := map[string]bool{}
+	for _, profileTrace := range got.DecisionTrace.Profiles {
+		shadowTraces[profileTrace.Name] = profileTrace.Shadow
+	}
+	if diff := cmp.Diff(map[string]bool{"primary": false, "shadow": true}, shadowTraces); diff != "" {
+		t.Errorf("Unexpected decision trace profiles (-want +got): %s", diff)
+	}
+}
+
+// keepPodFilter keeps a single pod.
+type keepPodFilter struct {
+	pod string
+}
+
+func (f *keepPodFilter) Type() string {
+	return "keep-pod"
+}
+
+func (f *keepPodFilter) Name() string {
+	return f.pod
+}
+
+func (f *keepPodFilter) Filter(_ context.Context, _ *types.CycleState, _ *types.LLMRequest, pods []types.Pod) []types.Pod {
+	kept := []types.Pod{}
+	for _, pod := range pods {
+		if types.PodName(pod) == f.pod {
+			kept = append(kept, pod)
+		}
+	}
+	return kept
+}
+
+// countingPostCycle counts the PostCycle calls.
+type countingPostCycle struct {
+	calls int
+}
+
+func (p *countingPostCycle) Type() string {
+	return "counting-post-cycle"
+}
+
+func (p *countingPostCycle) Name() string {
+	return "counting-post-cycle"
+}
+
+func (p *countingPostCycle) PostCycle(_ context.Context, _ *types.CycleState, _ *types.ProfileRunResult) {
+	p.calls++
+}
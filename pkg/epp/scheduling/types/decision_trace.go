@@ -0,0 +1,170 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"maps"
+	"slices"
+)
+
+const (
+	// DecisionTraceStateKey is the key of the DecisionTrace in the CycleState. The trace is only recorded when the
+	// scheduler is configured to record it.
+	DecisionTraceStateKey = StateKey("scheduling-decision-trace")
+)
+
+// NewDecisionTrace returns an empty DecisionTrace.
+func NewDecisionTrace() *DecisionTrace {
+	return &DecisionTrace{Profiles: []*ProfileTrace{}}
+}
+
+// DecisionTrace records how the scheduler reached its decision for a request, in order to debug unexpected routing
+// decisions. It lists the scheduling profiles in the order they were run.
+type DecisionTrace struct {
+	Profiles []*ProfileTrace `json:"profiles"`
+}
+
+// ProfileTrace records the run of a single scheduling profile.
+type ProfileTrace struct {
+	Name string `json:"name"`
+	// Shadow is true if the profile ran as a shadow profile, whose result does not affect routing.
+	Shadow bool `json:"shadow,omitempty"`
+	// CandidatePods are the pods the profile started with.
+	CandidatePods []string       `json:"candidatePods"`
+	Filters       []*FilterTrace `json:"filters,omitempty"`
+	Scorers       []*ScorerTrace `json:"scorers,omitempty"`
+	Picker        *PickerTrace   `json:"picker,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
+
+// FilterTrace records the pods removed by a filter plugin.
+type FilterTrace struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	RemovedPods []string `json:"removedPods"`
+}
+
+// ScorerTrace records the scores given by a scorer plugin.
+type ScorerTrace struct {
+	Type   string  `json:"type"`
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	// Scores are the raw scores of the scorer, between 0 and 1.
+	Scores map[string]float64 `json:"scores"`
+	// NormalizedScores are the scores after the normalization of the profile, if any.
+	NormalizedScores map[string]float64 `json:"normalizedScores,omitempty"`
+	// WeightedScores are the contributions of the scorer to the combined scores, the normalized scores multiplied by
+	// the weight of the scorer by default.
+	WeightedScores map[string]float64 `json:"weightedScores"`
+}
+
+// PickerTrace records the choice of the picker plugin.
+type PickerTrace struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Scores are the total weighted scores the picker chose from.
+	Scores    map[string]float64 `json:"scores"`
+	TargetPod string             `json:"targetPod,omitempty"`
+	// RankedPods are the pods picked, in decreasing order of preference, if the picker returned more than one pod.
+	RankedPods []string `json:"rankedPods,omitempty"`
+}
+
+// StartProfile adds the trace of a profile to be run, and makes it the active profile.
+func (t *DecisionTrace) StartProfile(name string) *ProfileTrace {
+	profile := &ProfileTrace{Name: name}
+	t.Profiles = append(t.Profiles, profile)
+	return profile
+}
+
+// ActiveProfile returns the trace of the profile being run, i.e. the last started profile. It returns nil if no
+// profile was started. Profiles are run sequentially by the scheduler.
+func (t *DecisionTrace) ActiveProfile() *ProfileTrace {
+	if len(t.Profiles) == 0 {
+		return nil
+	}
+	return t.Profiles[len(t.Profiles)-1]
+}
+
+// Clone returns a deep copy of the DecisionTrace.
+func (t *DecisionTrace) Clone() StateData {
+	clone := &DecisionTrace{Profiles: make([]*ProfileTrace, 0, len(t.Profiles))}
+	for _, profile := range t.Profiles {
+		profileClone := &ProfileTrace{
+			Name:          profile.Name,
+			CandidatePods: slices.Clone(profile.CandidatePods),
+			Error:         profile.Error,
+		}
+		for _, filter := range profile.Filters {
+			profileClone.Filters = append(profileClone.Filters, &FilterTrace{
+				Type:        filter.Type,
+				Name:        filter.Name,
+				RemovedPods: slices.Clone(filter.RemovedPods),
+			})
+		}
+		for _, scorer := range profile.Scorers {
+			profileClone.Scorers = append(profileClone.Scorers, &ScorerTrace{
+				Type:             scorer.Type,
+				Name:             scorer.Name,
+				Weight:           scorer.Weight,
+				Scores:           maps.Clone(scorer.Scores),
+				NormalizedScores: maps.Clone(scorer.NormalizedScores),
+				WeightedScores:   maps.Clone(scorer.WeightedScores),
+			})
+		}
+		if profile.Picker != nil {
+			profileClone.Picker = &PickerTrace{
+				Type:       profile.Picker.Type,
+				Name:       profile.Picker.Name,
+				Scores:     maps.Clone(profile.Picker.Scores),
+				TargetPod:  profile.Picker.TargetPod,
+				RankedPods: slices.Clone(profile.Picker.RankedPods),
+			}
+		}
+		clone.Profiles = append(clone.Profiles, profileClone)
+	}
+	return clone
+}
+
+// ReadDecisionTrace returns the DecisionTrace stored in the CycleState, or nil if the decision trace isn't recorded.
+func ReadDecisionTrace(cycleState *CycleState) *DecisionTrace {
+	data, err := cycleState.Read(DecisionTraceStateKey)
+	if err != nil {
+		return nil
+	}
+	decisionTrace, ok := data.(*DecisionTrace)
+	if !ok {
+		return nil
+	}
+	return decisionTrace
+}
+
+// PodNames returns the names of the given pods, as recorded in the DecisionTrace.
+func PodNames(pods []Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, PodName(pod))
+	}
+	return names
+}
+
+// PodName returns the name of the pod, as recorded in the DecisionTrace.
+func PodName(pod Pod) string {
+	if pod == nil || pod.GetPod() == nil {
+		return ""
+	}
+	return pod.GetPod().NamespacedName.String()
+}
@@ -33,6 +33,10 @@ type LLMRequest struct {
 	Prompt string
 	// Headers is a map of the request headers.
 	Headers map[string]string
+	// Body is the parsed request body.
+	Body map[string]any
+	// SchedulingProfile is the scheduling profile set by the InferenceModel of the request, if any.
+	SchedulingProfile string
 }
 
 func (r *LLMRequest) String() string {
@@ -81,10 +85,15 @@ func ToSchedulerPodMetrics(pods []backendmetrics.PodMetrics) []Pod {
 // ProfileRunResult captures the profile run result.
 type ProfileRunResult struct {
 	TargetPod Pod
+	// RankedPods are the pods picked by pickers that return more than one pod, in decreasing order of preference.
+	// TargetPod is the first of them, the others are the fallback endpoints of the request.
+	RankedPods []Pod
 }
 
 // SchedulingResult captures the result of the scheduling cycle.
 type SchedulingResult struct {
 	ProfileResults     map[string]*ProfileRunResult
 	PrimaryProfileName string
+	// DecisionTrace is the trace of the scheduling decision, only set when the scheduler records it.
+	DecisionTrace *DecisionTrace
 }
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	configapi "sigs.k8s.io/gateway-api-inference-extension/api/config/v1alpha1"
 	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
 )
 
@@ -39,6 +40,7 @@ var scheme = runtime.NewScheme()
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(v1alpha2.Install(scheme))
+	utilruntime.Must(configapi.Install(scheme))
 }
 
 // defaultManagerOptions returns the default options used to create the manager.
@@ -66,6 +68,11 @@ func defaultManagerOptions(namespacedName types.NamespacedName, metricsServerOpt
 						namespacedName.Namespace: {},
 					},
 				},
+				&configapi.EndpointPickerConfig{}: {
+					Namespaces: map[string]cache.Config{
+						namespacedName.Namespace: {},
+					},
+				},
 			},
 		},
 		Metrics: metricsServerOptions,
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net/http"
 
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/controller"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/datastore"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/handlers"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/loadreport"
 	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/requestcontrol"
 )
 
@@ -54,6 +56,9 @@ type ExtProcServerRunner struct {
 	RefreshPrometheusMetricsInterval         time.Duration
 	Director                                 *requestcontrol.Director
 	SaturationDetector                       requestcontrol.SaturationDetector
+	// ConfigApplier applies the EndpointPickerConfig referenced by the InferencePool. If nil, the EndpointPickerConfig
+	// resources are not watched.
+	ConfigApplier controller.ConfigApplier
 
 	// This should only be used in tests. We won't need this once we do not inject metrics in the tests.
 	// TODO:(https://github.com/kubernetes-sigs/gateway-api-inference-extension/issues/432) Cleanup
@@ -71,6 +76,9 @@ const (
 	DefaultRefreshPrometheusMetricsInterval         = 5 * time.Second                  // default for --refreshPrometheusMetricsInterval
 	DefaultSecureServing                            = true                             // default for --secureServing
 	DefaultHealthChecking                           = false                            // default for --healthChecking
+	DefaultHTTPProxyPort                            = 0                                // default for --httpProxyPort, disabled
+	DefaultHTTPProxyMaxRequestBodyBytes             = 32 << 20                         // default for --httpProxyMaxRequestBodyBytes
+	DefaultLoadReportPort                           = 0                                // default for --loadReportPort, disabled
 )
 
 // NewDefaultExtProcServerRunner creates a runner with default values.
@@ -115,6 +123,17 @@ func (r *ExtProcServerRunner) SetupWithManager(ctx context.Context, mgr ctrl.Man
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed setting up PodReconciler: %v", err)
 	}
+
+	if r.ConfigApplier != nil {
+		if err := (&controller.EndpointPickerConfigReconciler{
+			Client:             mgr.GetClient(),
+			Record:             mgr.GetEventRecorderFor("EndpointPickerConfig"),
+			Applier:            r.ConfigApplier,
+			PoolNamespacedName: r.PoolNamespacedName,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("failed setting up EndpointPickerConfigReconciler: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -172,3 +191,27 @@ func (r *ExtProcServerRunner) AsRunnable(logger logr.Logger) manager.Runnable {
 		return runnable.GRPCServer("ext-proc", srv, r.GrpcPort).Start(ctx)
 	}))
 }
+
+// AsHTTPProxyRunnable returns a Runnable that can be used to start the HTTP reverse-proxy server on the given port.
+// The proxy shares the Director with the ext-proc server, so both paths run the same admission and scheduling flow.
+// If backendTLS is true, the requests are proxied to the model servers over HTTPS, without verifying their
+// certificates if insecureSkipVerify is true. The requests whose body is larger than maxRequestBodyBytes are rejected.
+// The runnable implements LeaderElectionRunnable with leader election disabled.
+func (r *ExtProcServerRunner) AsHTTPProxyRunnable(port int, backendTLS bool, insecureSkipVerify bool,
+	maxRequestBodyBytes int64) manager.Runnable {
+	var transport http.RoundTripper
+	if backendTLS && insecureSkipVerify {
+		insecureTransport := http.DefaultTransport.(*http.Transport).Clone()
+		insecureTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- explicitly configured by the operator
+		transport = insecureTransport
+	}
+	proxyServer := handlers.NewHTTPProxyServer(r.Datastore, r.Director, transport, backendTLS, maxRequestBodyBytes)
+	return runnable.NoLeaderElection(runnable.HTTPServer("http-proxy", proxyServer, port))
+}
+
+// AsLoadReportRunnable returns a Runnable that can be used to start the server the model servers push their load
+// reports to on the given port. If authToken isn't empty, the load reports must carry it as a bearer token.
+// The runnable implements LeaderElectionRunnable with leader election disabled.
+func (r *ExtProcServerRunner) AsLoadReportRunnable(port int, authToken string) manager.Runnable {
+	return runnable.NoLeaderElection(runnable.HTTPServer("load-report", loadreport.NewServer(r.Datastore, authToken), port))
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"container/list"
+	"time"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+)
+
+// LatencyModel estimates the duration of a single engine step. The defaults are taken from the profiling of
+// vLLM that backs the python simulations in tools/simulations.
+type LatencyModel struct {
+	// PrefillBase is the fixed cost of a prefill step.
+	PrefillBase time.Duration
+	// PrefillPerToken is the cost of each uncached prompt token in a prefill step.
+	PrefillPerToken time.Duration
+	// PrefillMin is the minimal duration of a prefill step.
+	PrefillMin time.Duration
+	// DecodeBase is the fixed cost of a decode step.
+	DecodeBase time.Duration
+	// DecodePerSequence is the cost of each sequence in the batch of a decode step.
+	DecodePerSequence time.Duration
+	// DecodePerToken is the cost of each token in the context of the sequences of a decode step.
+	DecodePerToken time.Duration
+}
+
+func (m LatencyModel) prefill(tokens int) time.Duration {
+	return max(m.PrefillMin, m.PrefillBase+time.Duration(tokens)*m.PrefillPerToken)
+}
+
+func (m LatencyModel) decode(sequences, contextTokens int) time.Duration {
+	return m.DecodeBase + time.Duration(sequences)*m.DecodePerSequence + time.Duration(contextTokens)*m.DecodePerToken
+}
+
+// PodConfig configures the simulated model server pods.
+type PodConfig struct {
+	// MaxNumSeqs is the maximum number of sequences in a batch.
+	MaxNumSeqs int
+	// MaxNumBatchedTokens is the budget of prompt tokens of a prefill step. A prompt larger than the budget
+	// is prefilled alone.
+	MaxNumBatchedTokens int
+	// BlockSize is the number of tokens in a KV-cache block.
+	BlockSize int
+	// NumGPUBlocks is the number of KV-cache blocks.
+	NumGPUBlocks int
+	// MaxLoRAs is the maximum number of distinct LoRA adapters in a batch.
+	MaxLoRAs int
+	// Latency is the model of the engine step durations.
+	Latency LatencyModel
+}
+
+// simRequest is a request of the trace as it goes through the simulation.
+type simRequest struct {
+	id     int
+	record TraceRecord
+	// blocks is the number of KV-cache blocks reserved for the request.
+	blocks int
+	// cachedTokens is the number of prompt tokens served from the prefix cache of the pod.
+	cachedTokens int
+	generated    int
+
+	arrival    time.Duration
+	admitted   time.Duration
+	firstToken time.Duration
+	completed  time.Duration
+}
+
+func (r *simRequest) prefixKey() string {
+	return r.record.Model + "/" + r.record.PrefixID
+}
+
+// simPod is a discrete-event model of a vLLM-like server with continuous batching. Waiting requests are admitted
+// in FCFS order as long as there are free batch slots and KV-cache blocks; the blocks of a request (prompt and
+// output tokens) are reserved on admission, so requests are never preempted. Engine steps either prefill the
+// admitted requests or decode one token of every running request.
+type simPod struct {
+	pod       *backend.Pod
+	config    PodConfig
+	baseModel string
+
+	waiting    []*simRequest
+	prefilling []*simRequest
+	running    []*simRequest
+	usedBlocks int
+	// step holds the requests processed by the ongoing engine step, nil when the pod is idle.
+	step      []*simRequest
+	isPrefill bool
+
+	prefixCache *prefixCache
+	metrics     *backendmetrics.MetricsState
+	stats       PodReport
+}
+
+func newSimPod(pod *backend.Pod, config PodConfig, baseModel string) *simPod {
+	return &simPod{
+		pod:         pod,
+		config:      config,
+		baseModel:   baseModel,
+		prefixCache: newPrefixCache(config.NumGPUBlocks * config.BlockSize),
+		metrics: &backendmetrics.MetricsState{
+			ActiveModels:            map[string]int{},
+			WaitingModels:           map[string]int{},
+			MaxActiveModels:         config.MaxLoRAs,
+			KvCacheMaxTokenCapacity: config.NumGPUBlocks * config.BlockSize,
+			KvCacheBlockSize:        config.BlockSize,
+			KvCacheNumGPUBlocks:     config.NumGPUBlocks,
+		},
+		stats: PodReport{Name: pod.NamespacedName.Name},
+	}
+}
+
+// fits returns true if the request can ever be admitted by the pod.
+func (p *simPod) fits(record TraceRecord) bool {
+	return p.blocksFor(record) <= p.config.NumGPUBlocks
+}
+
+func (p *simPod) blocksFor(record TraceRecord) int {
+	return (record.PromptTokens + record.OutputTokens + p.config.BlockSize - 1) / p.config.BlockSize
+}
+
+func (p *simPod) enqueue(req *simRequest, now time.Duration) {
+	req.blocks = p.blocksFor(req.record)
+	p.waiting = append(p.waiting, req)
+	p.stats.Requests++
+	p.stats.PromptTokens += req.record.PromptTokens
+	p.stats.OutputTokens += req.record.OutputTokens
+	p.admit(now)
+	p.stats.MaxWaitingQueueSize = max(p.stats.MaxWaitingQueueSize, len(p.waiting))
+}
+
+func (p *simPod) admit(now time.Duration) {
+	remaining := make([]*simRequest, 0, len(p.waiting))
+	full := false
+	for _, req := range p.waiting {
+		batchSize := len(p.prefilling) + len(p.running) + len(p.step)
+		if full || batchSize >= p.config.MaxNumSeqs || p.usedBlocks+req.blocks > p.config.NumGPUBlocks {
+			// FCFS, requests behind a request that doesn't fit must wait as well.
+			full = true
+			remaining = append(remaining, req)
+			continue
+		}
+		if !p.adapterFits(req.record.Model) {
+			remaining = append(remaining, req)
+			continue
+		}
+		p.usedBlocks += req.blocks
+		req.admitted = now
+		if req.record.PrefixID != "" {
+			req.cachedTokens = p.prefixCache.lookup(req.prefixKey(), req.record.PrefixTokens)
+		}
+		p.stats.CachedPromptTokens += req.cachedTokens
+		p.prefilling = append(p.prefilling, req)
+	}
+	p.waiting = remaining
+}
+
+func (p *simPod) isAdapter(model string) bool {
+	return p.baseModel != "" && model != p.baseModel
+}
+
+func (p *simPod) adapterFits(model string) bool {
+	if !p.isAdapter(model) || p.config.MaxLoRAs <= 0 {
+		return true
+	}
+	active := p.activeAdapters()
+	if _, ok := active[model]; ok {
+		return true
+	}
+	return len(active) < p.config.MaxLoRAs
+}
+
+func (p *simPod) activeAdapters() map[string]int {
+	adapters := map[string]int{}
+	for _, batch := range [][]*simRequest{p.prefilling, p.running, p.step} {
+		for _, req := range batch {
+			if p.isAdapter(req.record.Model) {
+				adapters[req.record.Model] = 0
+			}
+		}
+	}
+	return adapters
+}
+
+func (p *simPod) busy() bool {
+	return p.step != nil
+}
+
+func (p *simPod) hasWork() bool {
+	return len(p.waiting) > 0 || len(p.prefilling) > 0 || len(p.running) > 0
+}
+
+// startStep starts the next engine step and returns its duration. Prefill is prioritized over decode, as in vLLM.
+// It returns false if the pod is busy or has nothing to run.
+func (p *simPod) startStep() (time.Duration, bool) {
+	if p.busy() {
+		return 0, false
+	}
+	if len(p.prefilling) > 0 {
+		tokens := 0
+		count := 0
+		for _, req := range p.prefilling {
+			uncached := req.record.PromptTokens - req.cachedTokens
+			if count > 0 && tokens+uncached > p.config.MaxNumBatchedTokens {
+				break
+			}
+			tokens += uncached
+			count++
+		}
+		p.step = p.prefilling[:count:count]
+		p.prefilling = p.prefilling[count:]
+		p.isPrefill = true
+		return p.config.Latency.prefill(tokens), true
+	}
+	if len(p.running) > 0 {
+		contextTokens := 0
+		for _, req := range p.running {
+			contextTokens += req.record.PromptTokens + req.generated
+		}
+		p.step = p.running
+		p.running = nil
+		p.isPrefill = false
+		return p.config.Latency.decode(len(p.step), contextTokens), true
+	}
+	return 0, false
+}
+
+// completeStep ends the ongoing engine step, every request of the step generated a token. It returns the
+// completed requests.
+func (p *simPod) completeStep(now time.Duration) []*simRequest {
+	completed := []*simRequest{}
+	for _, req := range p.step {
+		if p.isPrefill {
+			req.firstToken = now
+			if req.record.PrefixID != "" {
+				p.prefixCache.add(req.prefixKey(), req.record.PrefixTokens)
+			}
+		}
+		req.generated++
+		if req.generated >= req.record.OutputTokens {
+			req.completed = now
+			p.usedBlocks -= req.blocks
+			completed = append(completed, req)
+			continue
+		}
+		p.running = append(p.running, req)
+	}
+	p.step = nil
+	p.admit(now)
+	return completed
+}
+
+// refreshMetrics updates the metrics exposed to the scheduler, like a scrape of the model server would.
+func (p *simPod) refreshMetrics(updateTime time.Time) {
+	waitingModels := map[string]int{}
+	for _, req := range p.waiting {
+		if p.isAdapter(req.record.Model) {
+			waitingModels[req.record.Model] = 0
+		}
+	}
+	running := len(p.prefilling) + len(p.running) + len(p.step)
+	p.metrics = &backendmetrics.MetricsState{
+		ActiveModels:            p.activeAdapters(),
+		WaitingModels:           waitingModels,
+		MaxActiveModels:         p.config.MaxLoRAs,
+		RunningQueueSize:        running,
+		WaitingQueueSize:        len(p.waiting),
+		KVCacheUsagePercent:     float64(p.usedBlocks) / float64(p.config.NumGPUBlocks),
+		KvCacheMaxTokenCapacity: p.config.NumGPUBlocks * p.config.BlockSize,
+		KvCacheBlockSize:        p.config.BlockSize,
+		KvCacheNumGPUBlocks:     p.config.NumGPUBlocks,
+		UpdateTime:              updateTime,
+	}
+}
+
+// prefixCache is an LRU cache of prompt prefixes, sized in tokens, approximating the automatic prefix caching of
+// the model server.
+type prefixCache struct {
+	capacity int
+	size     int
+	entries  map[string]*list.Element
+	lru      *list.List
+}
+
+type prefixEntry struct {
+	key    string
+	tokens int
+}
+
+func newPrefixCache(capacity int) *prefixCache {
+	return &prefixCache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// lookup returns the number of the given prefix tokens that are cached.
+func (c *prefixCache) lookup(key string, tokens int) int {
+	element, ok := c.entries[key]
+	if !ok {
+		return 0
+	}
+	c.lru.MoveToFront(element)
+	return min(tokens, element.Value.(*prefixEntry).tokens)
+}
+
+func (c *prefixCache) add(key string, tokens int) {
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*prefixEntry)
+		if tokens > entry.tokens {
+			c.size += tokens - entry.tokens
+			entry.tokens = tokens
+		}
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[key] = c.lru.PushFront(&prefixEntry{key: key, tokens: tokens})
+		c.size += tokens
+	}
+	for c.size > c.capacity && c.lru.Len() > 0 {
+		oldest := c.lru.Back()
+		entry := oldest.Value.(*prefixEntry)
+		c.lru.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.size -= entry.tokens
+	}
+}
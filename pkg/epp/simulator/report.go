@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Report summarizes a simulation run.
+type Report struct {
+	// Requests is the number of requests of the trace that were scheduled.
+	Requests int `json:"requests"`
+	// Failed is the number of requests of the trace that couldn't be scheduled.
+	Failed int `json:"failed"`
+	// Duration is the simulated time from the start of the trace until the last request completed.
+	Duration time.Duration `json:"duration"`
+	// TTFT is the distribution of the time to first token.
+	TTFT Distribution `json:"ttft"`
+	// TPOT is the distribution of the time per output token, after the first token.
+	TPOT Distribution `json:"tpot"`
+	// E2ELatency is the distribution of the request latency.
+	E2ELatency Distribution `json:"e2eLatency"`
+	// QueueTime is the distribution of the time spent in the waiting queue of the model server.
+	QueueTime Distribution `json:"queueTime"`
+	// PrefixHitRate is the fraction of the prompt tokens that were served from the prefix cache of the pods.
+	PrefixHitRate float64 `json:"prefixHitRate"`
+	// LoadImbalance is the ratio between the max and the mean number of requests per pod, 1 is a perfect balance.
+	LoadImbalance float64 `json:"loadImbalance"`
+	// Pods breaks down the load per pod.
+	Pods []PodReport `json:"pods"`
+}
+
+// Distribution summarizes a latency distribution.
+type Distribution struct {
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// PodReport summarizes the load of a single pod.
+type PodReport struct {
+	Name                string `json:"name"`
+	Requests            int    `json:"requests"`
+	PromptTokens        int    `json:"promptTokens"`
+	CachedPromptTokens  int    `json:"cachedPromptTokens"`
+	OutputTokens        int    `json:"outputTokens"`
+	MaxWaitingQueueSize int    `json:"maxWaitingQueueSize"`
+}
+
+func newReport(requests []*simRequest, failed int, pods []*simPod) *Report {
+	report := &Report{
+		Requests: len(requests),
+		Failed:   failed,
+		Pods:     make([]PodReport, 0, len(pods)),
+	}
+
+	ttft := make([]time.Duration, 0, len(requests))
+	tpot := make([]time.Duration, 0, len(requests))
+	e2e := make([]time.Duration, 0, len(requests))
+	queue := make([]time.Duration, 0, len(requests))
+	for _, req := range requests {
+		ttft = append(ttft, req.firstToken-req.arrival)
+		if req.record.OutputTokens > 1 {
+			tpot = append(tpot, (req.completed-req.firstToken)/time.Duration(req.record.OutputTokens-1))
+		}
+		e2e = append(e2e, req.completed-req.arrival)
+		queue = append(queue, req.admitted-req.arrival)
+		report.Duration = max(report.Duration, req.completed)
+	}
+	report.TTFT = newDistribution(ttft)
+	report.TPOT = newDistribution(tpot)
+	report.E2ELatency = newDistribution(e2e)
+	report.QueueTime = newDistribution(queue)
+
+	promptTokens, cachedTokens, maxRequests := 0, 0, 0
+	for _, pod := range pods {
+		report.Pods = append(report.Pods, pod.stats)
+		promptTokens += pod.stats.PromptTokens
+		cachedTokens += pod.stats.CachedPromptTokens
+		maxRequests = max(maxRequests, pod.stats.Requests)
+	}
+	if promptTokens > 0 {
+		report.PrefixHitRate = float64(cachedTokens) / float64(promptTokens)
+	}
+	if len(requests) > 0 {
+		report.LoadImbalance = float64(maxRequests) / (float64(len(requests)) / float64(len(pods)))
+	}
+	return report
+}
+
+func newDistribution(values []time.Duration) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	slices.Sort(values)
+	var sum time.Duration
+	for _, value := range values {
+		sum += value
+	}
+	return Distribution{
+		Mean: sum / time.Duration(len(values)),
+		P50:  percentile(values, 50),
+		P90:  percentile(values, 90),
+		P99:  percentile(values, 99),
+		Max:  values[len(values)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile of the given sorted values.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// WriteText writes a human readable version of the report.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Requests:\t%d (%d failed)\n", r.Requests, r.Failed)
+	fmt.Fprintf(tw, "Duration:\t%s\n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(tw, "Prefix hit rate:\t%.2f%%\n", 100*r.PrefixHitRate)
+	fmt.Fprintf(tw, "Load imbalance (max/mean):\t%.2f\n", r.LoadImbalance)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Latency\tMean\tP50\tP90\tP99\tMax")
+	for _, row := range []struct {
+		name         string
+		distribution Distribution
+	}{
+		{"TTFT", r.TTFT},
+		{"TPOT", r.TPOT},
+		{"E2E", r.E2ELatency},
+		{"Queue", r.QueueTime},
+	} {
+		d := row.distribution
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", row.name, round(d.Mean), round(d.P50), round(d.P90), round(d.P99), round(d.Max))
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Pod\tRequests\tPrompt tokens\tCached tokens\tOutput tokens\tMax waiting")
+	for _, pod := range r.Pods {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%d\n", pod.Name, pod.Requests, pod.PromptTokens, pod.CachedPromptTokens, pod.OutputTokens, pod.MaxWaitingQueueSize)
+	}
+	return tw.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(100 * time.Microsecond)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays a recorded request trace against a simulated pool of model servers, using the
+// real scheduler and plugins of the EPP. It's used to compare scheduler configurations offline.
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/types"
+	logutil "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/util/logging"
+)
+
+const (
+	// charsPerToken is used to synthesize prompts of a given number of tokens, it matches the estimation of
+	// the prefix cache plugin.
+	charsPerToken = 4
+	podNamespace  = "simulation"
+)
+
+// Scheduler defines the interface required by the simulator, it's implemented by scheduling.Scheduler.
+type Scheduler interface {
+	Schedule(ctx context.Context, request *types.LLMRequest, candidatePods []types.Pod) (result *types.SchedulingResult, err error)
+}
+
+// Config configures the simulated pool.
+type Config struct {
+	// NumPods is the number of model server pods in the pool.
+	NumPods int
+	// Pod configures every pod of the pool.
+	Pod PodConfig
+	// BaseModel is the model served by the pods, requests for any other model are considered as LoRA adapter
+	// requests. If empty, the LoRA adapter limits are not simulated.
+	BaseModel string
+	// MetricsRefreshInterval is the interval at which the metrics of the pods are refreshed, as the EPP scrapes
+	// them periodically. If zero, the scheduler always sees up to date metrics.
+	MetricsRefreshInterval time.Duration
+}
+
+// DefaultConfig returns a pool of vLLM servers serving a 7B model on a single GPU.
+func DefaultConfig() Config {
+	return Config{
+		NumPods: 3,
+		Pod: PodConfig{
+			MaxNumSeqs:          256,
+			MaxNumBatchedTokens: 512,
+			BlockSize:           16,
+			NumGPUBlocks:        2810,
+			MaxLoRAs:            4,
+			Latency: LatencyModel{
+				PrefillBase:       19690 * time.Microsecond,
+				PrefillPerToken:   67693 * time.Nanosecond,
+				PrefillMin:        40 * time.Millisecond,
+				DecodeBase:        14 * time.Millisecond,
+				DecodePerSequence: 102649 * time.Nanosecond,
+				DecodePerToken:    535 * time.Nanosecond,
+			},
+		},
+		MetricsRefreshInterval: 50 * time.Millisecond,
+	}
+}
+
+func (c Config) validate() error {
+	if c.NumPods <= 0 {
+		return errors.New("the number of pods must be positive")
+	}
+	if c.Pod.MaxNumSeqs <= 0 || c.Pod.MaxNumBatchedTokens <= 0 || c.Pod.BlockSize <= 0 || c.Pod.NumGPUBlocks <= 0 {
+		return errors.New("the max number of sequences, the max number of batched tokens, the block size and the number of GPU blocks must be positive")
+	}
+	if c.MetricsRefreshInterval < 0 {
+		return errors.New("the metrics refresh interval must not be negative")
+	}
+	return nil
+}
+
+// Simulator replays traces against a simulated pool.
+type Simulator struct {
+	scheduler Scheduler
+	config    Config
+}
+
+// New returns a simulator that schedules the requests with the given scheduler. Note that the state of the
+// scheduler plugins (e.g. the prefix cache indexer) is kept between runs, a new scheduler should be used for
+// each run.
+func New(scheduler Scheduler, config Config) (*Simulator, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid simulator configuration: %w", err)
+	}
+	return &Simulator{scheduler: scheduler, config: config}, nil
+}
+
+// Run replays the given trace, sorted by timestamp, and returns a report of the run.
+func (s *Simulator) Run(ctx context.Context, trace []TraceRecord) (*Report, error) {
+	logger := log.FromContext(ctx)
+	start := time.Now()
+
+	pods := make([]*simPod, 0, s.config.NumPods)
+	podsByName := make(map[k8stypes.NamespacedName]*simPod, s.config.NumPods)
+	for i := 0; i < s.config.NumPods; i++ {
+		pod := newSimPod(&backend.Pod{
+			NamespacedName: k8stypes.NamespacedName{Namespace: podNamespace, Name: fmt.Sprintf("pod-%d", i)},
+			Address:        fmt.Sprintf("10.0.0.%d", i+1),
+		}, s.config.Pod, s.config.BaseModel)
+		pod.refreshMetrics(start)
+		pods = append(pods, pod)
+		podsByName[pod.pod.NamespacedName] = pod
+	}
+
+	events := &eventQueue{}
+	for i, record := range trace {
+		events.push(event{at: record.arrival(), kind: arrivalEvent, request: &simRequest{id: i, record: record, arrival: record.arrival()}})
+	}
+	if s.config.MetricsRefreshInterval > 0 {
+		events.push(event{at: s.config.MetricsRefreshInterval, kind: refreshEvent})
+	}
+
+	requests := make([]*simRequest, 0, len(trace))
+	failed := 0
+	pendingArrivals := len(trace)
+	startStep := func(pod *simPod, now time.Duration) {
+		if duration, ok := pod.startStep(); ok {
+			events.push(event{at: now + duration, kind: stepEvent, pod: pod})
+		}
+	}
+
+	for events.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		ev := events.pop()
+		now := ev.at
+		switch ev.kind {
+		case arrivalEvent:
+			pendingArrivals--
+			if s.config.MetricsRefreshInterval == 0 {
+				for _, pod := range pods {
+					pod.refreshMetrics(start.Add(now))
+				}
+			}
+			target, err := s.schedule(ctx, ev.request, pods, podsByName)
+			if err != nil {
+				logger.V(logutil.DEBUG).Info("Failed to schedule request", "request", ev.request.id, "error", err)
+				failed++
+				continue
+			}
+			target.enqueue(ev.request, now)
+			requests = append(requests, ev.request)
+			startStep(target, now)
+		case stepEvent:
+			ev.pod.completeStep(now)
+			startStep(ev.pod, now)
+		case refreshEvent:
+			active := pendingArrivals > 0
+			for _, pod := range pods {
+				pod.refreshMetrics(start.Add(now))
+				active = active || pod.busy() || pod.hasWork()
+			}
+			if active {
+				events.push(event{at: now + s.config.MetricsRefreshInterval, kind: refreshEvent})
+			}
+		}
+	}
+
+	return newReport(requests, failed, pods), nil
+}
+
+func (s *Simulator) schedule(ctx context.Context, req *simRequest, pods []*simPod, podsByName map[k8stypes.NamespacedName]*simPod) (*simPod, error) {
+	candidates := make([]types.Pod, 0, len(pods))
+	for _, pod := range pods {
+		candidates = append(candidates, &types.PodMetrics{Pod: pod.pod.Clone(), MetricsState: pod.metrics.Clone()})
+	}
+
+	result, err := s.scheduler.Schedule(ctx, &types.LLMRequest{
+		RequestId:   strconv.Itoa(req.id),
+		TargetModel: req.record.Model,
+		Prompt:      synthesizePrompt(req),
+		Headers:     map[string]string{},
+	}, candidates)
+	if err != nil {
+		return nil, err
+	}
+	profileResult, ok := result.ProfileResults[result.PrimaryProfileName]
+	if !ok || profileResult == nil || profileResult.TargetPod == nil {
+		return nil, fmt.Errorf("no target pod in the result of the primary profile %q", result.PrimaryProfileName)
+	}
+	target, ok := podsByName[profileResult.TargetPod.GetPod().NamespacedName]
+	if !ok {
+		return nil, fmt.Errorf("unknown target pod %s", profileResult.TargetPod.GetPod().NamespacedName)
+	}
+	if !target.fits(req.record) {
+		return nil, fmt.Errorf("request of %d tokens doesn't fit in the KV cache", req.record.PromptTokens+req.record.OutputTokens)
+	}
+	return target, nil
+}
+
+// synthesizePrompt returns a prompt of the size of the recorded prompt, requests with the same prefix ID share
+// the prefix of their prompts, the rest of the prompt is unique.
+func synthesizePrompt(req *simRequest) string {
+	builder := strings.Builder{}
+	builder.Grow(req.record.PromptTokens * charsPerToken)
+	fill(&builder, "prefix-"+req.record.PrefixID, req.record.PrefixTokens*charsPerToken)
+	fill(&builder, "request-"+strconv.Itoa(req.id), (req.record.PromptTokens-req.record.PrefixTokens)*charsPerToken)
+	return builder.String()
+}
+
+func fill(builder *strings.Builder, seed string, size int) {
+	for i := 0; size > 0; i++ {
+		chunk := seed + "-" + strconv.Itoa(i) + " "
+		if len(chunk) > size {
+			chunk = chunk[:size]
+		}
+		builder.WriteString(chunk)
+		size -= len(chunk)
+	}
+}
+
+type eventKind int
+
+// The order of the event kinds is the order in which simultaneous events are processed.
+const (
+	stepEvent eventKind = iota
+	refreshEvent
+	arrivalEvent
+)
+
+type event struct {
+	at      time.Duration
+	kind    eventKind
+	seq     int
+	request *simRequest
+	pod     *simPod
+}
+
+// eventQueue is a priority queue of events ordered by time, kind and insertion order.
+type eventQueue struct {
+	events []event
+	seq    int
+}
+
+// compile-time type assertion
+var _ heap.Interface = &eventQueue{}
+
+func (q *eventQueue) Len() int { return len(q.events) }
+
+func (q *eventQueue) Less(i, j int) bool {
+	a, b := q.events[i], q.events[j]
+	if a.at != b.at {
+		return a.at < b.at
+	}
+	if a.kind != b.kind {
+		return a.kind < b.kind
+	}
+	return a.seq < b.seq
+}
+
+func (q *eventQueue) Swap(i, j int) { q.events[i], q.events[j] = q.events[j], q.events[i] }
+
+func (q *eventQueue) Push(x any) { q.events = append(q.events, x.(event)) }
+
+func (q *eventQueue) Pop() any {
+	last := q.events[len(q.events)-1]
+	q.events = q.events[:len(q.events)-1]
+	return last
+}
+
+func (q *eventQueue) push(ev event) {
+	ev.seq = q.seq
+	q.seq++
+	heap.Push(q, ev)
+}
+
+func (q *eventQueue) pop() event {
+	return heap.Pop(q).(event)
+}
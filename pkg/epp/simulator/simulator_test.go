@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/multi/prefix"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/picker"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/profile"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/scheduling/framework/plugins/scorer"
+)
+
+func TestLoadTrace(t *testing.T) {
+	tests := []struct {
+		name    string
+		trace   string
+		want    []TraceRecord
+		wantErr bool
+	}{
+		{
+			name: "records are sorted by timestamp",
+			trace: `# recorded trace
+{"timestamp": 1.5, "model": "m", "promptTokens": 10, "outputTokens": 5}
+
+{"timestamp": 0.5, "model": "m", "promptTokens": 20, "outputTokens": 1, "prefixId": "p", "prefixTokens": 10}
+`,
+			want: []TraceRecord{
+				{Timestamp: 0.5, Model: "m", PromptTokens: 20, OutputTokens: 1, PrefixID: "p", PrefixTokens: 10},
+				{Timestamp: 1.5, Model: "m", PromptTokens: 10, OutputTokens: 5},
+			},
+		},
+		{
+			name:    "invalid json",
+			trace:   `{"timestamp": `,
+			wantErr: true,
+		},
+		{
+			name:    "missing model",
+			trace:   `{"timestamp": 0, "promptTokens": 10, "outputTokens": 5}`,
+			wantErr: true,
+		},
+		{
+			name:    "prefix larger than the prompt",
+			trace:   `{"timestamp": 0, "model": "m", "promptTokens": 10, "outputTokens": 5, "prefixId": "p", "prefixTokens": 11}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := LoadTrace(strings.NewReader(test.trace))
+			if test.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error, wantErr %v, got %v", test.wantErr, err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" && !test.wantErr {
+				t.Errorf("Unexpected trace (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestSynthesizePrompt(t *testing.T) {
+	first := synthesizePrompt(&simRequest{id: 1, record: TraceRecord{PromptTokens: 100, PrefixID: "system", PrefixTokens: 60}})
+	second := synthesizePrompt(&simRequest{id: 2, record: TraceRecord{PromptTokens: 80, PrefixID: "system", PrefixTokens: 60}})
+	if len(first) != 100*charsPerToken || len(second) != 80*charsPerToken {
+		t.Fatalf("Unexpected prompt lengths %d and %d", len(first), len(second))
+	}
+	if first[:60*charsPerToken] != second[:60*charsPerToken] {
+		t.Errorf("Expected the prompts to share a prefix of %d characters", 60*charsPerToken)
+	}
+	if first[60*charsPerToken:80*charsPerToken] == second[60*charsPerToken:] {
+		t.Errorf("Expected the prompts to differ after the prefix")
+	}
+}
+
+func newTestScheduler(picker framework.Picker, scorers ...*framework.WeightedScorer) *scheduling.Scheduler {
+	schedulerProfile := framework.NewSchedulerProfile().WithScorers(scorers...).WithPicker(picker)
+	for _, s := range scorers {
+		if postCycle, ok := s.Scorer.(framework.PostCycle); ok {
+			schedulerProfile.WithPostCyclePlugins(postCycle)
+		}
+	}
+	return scheduling.NewSchedulerWithConfig(scheduling.NewSchedulerConfig(profile.NewSingleProfileHandler(),
+		map[string]*framework.SchedulerProfile{"default": schedulerProfile}))
+}
+
+// newPrefixTrace returns a trace of requests sharing one of the given number of prefixes.
+func newPrefixTrace(requests, prefixes int, interval time.Duration) []TraceRecord {
+	trace := make([]TraceRecord, 0, requests)
+	for i := 0; i < requests; i++ {
+		trace = append(trace, TraceRecord{
+			Timestamp:    (time.Duration(i) * interval).Seconds(),
+			Model:        "model",
+			PromptTokens: 512,
+			OutputTokens: 16,
+			PrefixID:     fmt.Sprintf("prefix-%d", i%prefixes),
+			PrefixTokens: 400,
+		})
+	}
+	return trace
+}
+
+func TestRun(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPods = 4
+	sim, err := New(newTestScheduler(picker.NewMaxScorePicker(), framework.NewWeightedScorer(scorer.NewQueueScorer(), 1)), config)
+	if err != nil {
+		t.Fatalf("Failed to create simulator: %v", err)
+	}
+	trace := newPrefixTrace(400, 400, 5*time.Millisecond)
+	report, err := sim.Run(context.Background(), trace)
+	if err != nil {
+		t.Fatalf("Failed to run simulation: %v", err)
+	}
+
+	if report.Requests != len(trace) || report.Failed != 0 {
+		t.Fatalf("Expected %d requests without failures, got %d requests and %d failures", len(trace), report.Requests, report.Failed)
+	}
+	requests := 0
+	for _, pod := range report.Pods {
+		requests += pod.Requests
+		if pod.Requests == 0 {
+			t.Errorf("Expected every pod to get requests, %s got none", pod.Name)
+		}
+	}
+	if requests != len(trace) {
+		t.Errorf("Expected %d requests across pods, got %d", len(trace), requests)
+	}
+	if report.TTFT.P50 <= 0 || report.TTFT.P50 > report.E2ELatency.P50 || report.TTFT.P99 < report.TTFT.P50 {
+		t.Errorf("Unexpected latencies, TTFT %+v, E2E %+v", report.TTFT, report.E2ELatency)
+	}
+	if report.PrefixHitRate != 0 {
+		t.Errorf("Expected no prefix hits for unique prefixes, got %f", report.PrefixHitRate)
+	}
+	if report.LoadImbalance > 1.5 {
+		t.Errorf("Expected the queue scorer to balance the load, got an imbalance of %f", report.LoadImbalance)
+	}
+}
+
+func TestRunQueueing(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPods = 1
+	config.Pod.MaxNumSeqs = 1
+	sim, err := New(newTestScheduler(picker.NewRandomPicker()), config)
+	if err != nil {
+		t.Fatalf("Failed to create simulator: %v", err)
+	}
+	// All the requests arrive at once, each one waits for the previous ones to complete.
+	report, err := sim.Run(context.Background(), newPrefixTrace(10, 10, 0))
+	if err != nil {
+		t.Fatalf("Failed to run simulation: %v", err)
+	}
+	if report.Pods[0].MaxWaitingQueueSize != 9 {
+		t.Errorf("Expected a max waiting queue of 9, got %d", report.Pods[0].MaxWaitingQueueSize)
+	}
+	// The last request waits for the 9 requests before it, i.e. for 90% of the run.
+	if report.QueueTime.Max < 8*report.Duration/10 {
+		t.Errorf("Expected the requests to be processed serially, got queue time %+v and duration %s", report.QueueTime, report.Duration)
+	}
+}
+
+func TestRunComparesPrefixAwareScheduling(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPods = 8
+	trace := newPrefixTrace(200, 10, 50*time.Millisecond)
+
+	hitRates := map[string]float64{}
+	for name, scheduler := range map[string]*scheduling.Scheduler{
+		"random": newTestScheduler(picker.NewRandomPicker()),
+		"prefix": newTestScheduler(picker.NewMaxScorePicker(), framework.NewWeightedScorer(prefix.New(prefix.Config{
+			HashBlockSize:          prefix.DefaultHashBlockSize,
+			MaxPrefixBlocksToMatch: prefix.DefaultMaxPrefixBlocks,
+			LRUCapacityPerServer:   prefix.DefaultLRUCapacityPerServer,
+		}), 1)),
+	} {
+		sim, err := New(scheduler, config)
+		if err != nil {
+			t.Fatalf("Failed to create simulator: %v", err)
+		}
+		report, err := sim.Run(context.Background(), trace)
+		if err != nil {
+			t.Fatalf("Failed to run simulation: %v", err)
+		}
+		hitRates[name] = report.PrefixHitRate
+	}
+
+	// Only the first request of each prefix misses the cache.
+	if want := float64(190*400) / float64(200*512); hitRates["prefix"] != want {
+		t.Errorf("Expected a prefix hit rate of %f with the prefix scorer, got %f", want, hitRates["prefix"])
+	}
+	if hitRates["random"] >= hitRates["prefix"] {
+		t.Errorf("Expected the prefix scorer to improve the prefix hit rate, got %v", hitRates)
+	}
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.NumPods = 0
+	if _, err := New(newTestScheduler(picker.NewRandomPicker()), config); err == nil {
+		t.Errorf("Expected an error for a pool without pods")
+	}
+}
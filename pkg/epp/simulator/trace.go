@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TraceRecord is a single recorded request. A trace is a file of JSON lines, one TraceRecord per line.
+type TraceRecord struct {
+	// Timestamp is the arrival time of the request in seconds, relative to the start of the trace.
+	Timestamp float64 `json:"timestamp"`
+	// Model is the target model of the request.
+	Model string `json:"model"`
+	// PromptTokens is the number of tokens in the prompt.
+	PromptTokens int `json:"promptTokens"`
+	// OutputTokens is the number of tokens generated for the request.
+	OutputTokens int `json:"outputTokens"`
+	// PrefixID optionally identifies a prompt prefix (e.g. a system prompt or a conversation) shared between requests.
+	PrefixID string `json:"prefixId,omitempty"`
+	// PrefixTokens is the number of tokens of the prompt covered by the shared prefix.
+	PrefixTokens int `json:"prefixTokens,omitempty"`
+}
+
+// arrival returns the arrival time of the request relative to the start of the trace.
+func (r TraceRecord) arrival() time.Duration {
+	return time.Duration(r.Timestamp * float64(time.Second))
+}
+
+func (r TraceRecord) validate() error {
+	if r.Timestamp < 0 {
+		return fmt.Errorf("timestamp must not be negative, got %f", r.Timestamp)
+	}
+	if r.Model == "" {
+		return fmt.Errorf("model must be set")
+	}
+	if r.PromptTokens <= 0 || r.OutputTokens <= 0 {
+		return fmt.Errorf("promptTokens and outputTokens must be positive, got %d and %d", r.PromptTokens, r.OutputTokens)
+	}
+	if r.PrefixTokens < 0 || r.PrefixTokens > r.PromptTokens {
+		return fmt.Errorf("prefixTokens must be between 0 and promptTokens (%d), got %d", r.PromptTokens, r.PrefixTokens)
+	}
+	return nil
+}
+
+// LoadTrace reads a trace of JSON lines and returns the records sorted by timestamp.
+func LoadTrace(reader io.Reader) ([]TraceRecord, error) {
+	trace := []TraceRecord{}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		record := TraceRecord{}
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, fmt.Errorf("failed to parse trace line %d: %w", line, err)
+		}
+		if err := record.validate(); err != nil {
+			return nil, fmt.Errorf("invalid trace line %d: %w", line, err)
+		}
+		trace = append(trace, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace: %w", err)
+	}
+	sort.SliceStable(trace, func(i, j int) bool {
+		return trace[i].Timestamp < trace[j].Timestamp
+	})
+	return trace, nil
+}
+
+// LoadTraceFile reads a trace from the given file.
+func LoadTraceFile(fileName string) ([]TraceRecord, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	defer file.Close()
+	return LoadTrace(file)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing provides the OpenTelemetry tracing of the requests handled by the EPP.
+// Spans are created with the global tracer provider, which is a no-op until Setup is called, so instrumented
+// code doesn't need to check whether tracing is enabled.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+)
+
+const (
+	instrumentationName = "sigs.k8s.io/gateway-api-inference-extension/pkg/epp"
+	defaultServiceName  = "endpoint-picker"
+
+	// Sampler names, they follow the values of the OTEL_TRACES_SAMPLER environment variable.
+	AlwaysOnSampler                = "always_on"
+	AlwaysOffSampler               = "always_off"
+	TraceIDRatioSampler            = "traceidratio"
+	ParentBasedAlwaysOnSampler     = "parentbased_always_on"
+	ParentBasedAlwaysOffSampler    = "parentbased_always_off"
+	ParentBasedTraceIDRatioSampler = "parentbased_traceidratio"
+)
+
+// Attribute keys set on the EPP spans.
+const (
+	ModelKey          = attribute.Key("epp.model")
+	TargetModelKey    = attribute.Key("epp.target_model")
+	TargetEndpointKey = attribute.Key("epp.target_endpoint")
+	CriticalityKey    = attribute.Key("epp.criticality")
+	ProfileKey        = attribute.Key("epp.scheduling.profile")
+	PluginTypeKey     = attribute.Key("epp.plugin.type")
+	PluginNameKey     = attribute.Key("epp.plugin.name")
+	CandidatePodsKey  = attribute.Key("epp.scheduling.candidate_pods")
+	FilteredPodsKey   = attribute.Key("epp.scheduling.filtered_pods")
+	ResponseSizeKey   = attribute.Key("epp.response.size")
+	PromptTokensKey   = attribute.Key("epp.usage.prompt_tokens")
+	OutputTokensKey   = attribute.Key("epp.usage.completion_tokens")
+)
+
+// Config configures the export of the traces.
+type Config struct {
+	// Endpoint is the address of the OTLP gRPC collector. If empty, the endpoint is read from the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable, with a fallback to localhost:4317.
+	Endpoint string
+	// Insecure disables the transport security of the connection to the collector.
+	Insecure bool
+	// Sampler is the name of the sampler, one of always_on, always_off, traceidratio, parentbased_always_on,
+	// parentbased_always_off and parentbased_traceidratio.
+	Sampler string
+	// SamplingRatio is the ratio of the sampled traces for the ratio based samplers.
+	SamplingRatio float64
+	// ServiceName is the service name reported on the traces.
+	ServiceName string
+}
+
+// Setup configures the global tracer provider to export the traces over OTLP, and returns a function
+// that flushes and stops the export.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	sampler, err := NewSampler(config.Sampler, config.SamplingRatio)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []otlptracegrpc.Option{}
+	if config.Endpoint != "" {
+		options = append(options, otlptracegrpc.WithEndpoint(config.Endpoint))
+	}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// NewSampler returns the sampler with the given name.
+func NewSampler(name string, ratio float64) (sdktrace.Sampler, error) {
+	if ratio < 0 || ratio > 1 {
+		return nil, fmt.Errorf("sampling ratio must be between 0 and 1, got %v", ratio)
+	}
+	switch strings.ToLower(name) {
+	case AlwaysOnSampler:
+		return sdktrace.AlwaysSample(), nil
+	case AlwaysOffSampler:
+		return sdktrace.NeverSample(), nil
+	case TraceIDRatioSampler:
+		return sdktrace.TraceIDRatioBased(ratio), nil
+	case ParentBasedAlwaysOnSampler:
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case ParentBasedAlwaysOffSampler:
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case ParentBasedTraceIDRatioSampler, "":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, fmt.Errorf("unknown sampler %q", name)
+	}
+}
+
+// Tracer returns the tracer of the EPP.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Extract returns a context carrying the remote span context of the W3C trace context headers (traceparent and
+// tracestate) of the request, if any.
+func Extract(ctx context.Context, headers map[string]string) context.Context {
+	return propagation.TraceContext{}.Extract(ctx, headerCarrier(headers))
+}
+
+// headerCarrier adapts request headers, whose keys are lower case as in HTTP/2, to a propagation.TextMapCarrier.
+type headerCarrier map[string]string
+
+func (c headerCarrier) Get(key string) string {
+	return c[strings.ToLower(key)]
+}
+
+func (c headerCarrier) Set(key string, value string) {
+	c[strings.ToLower(key)] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// StartPluginSpan starts a span for the execution of a plugin at the given extension point.
+func StartPluginSpan(ctx context.Context, extensionPoint string, plugin plugins.Plugin) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, extensionPoint+"/"+plugin.Type(),
+		trace.WithAttributes(PluginTypeKey.String(plugin.Type()), PluginNameKey.String(plugin.Name())))
+}
+
+// EndSpan records the error, if any, as the status of the span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name        string
+		sampler     string
+		ratio       float64
+		wantSampled bool
+		wantErr     bool
+	}{
+		{name: "always on", sampler: AlwaysOnSampler, wantSampled: true},
+		{name: "always off", sampler: AlwaysOffSampler, ratio: 1},
+		{name: "ratio of 1", sampler: TraceIDRatioSampler, ratio: 1, wantSampled: true},
+		{name: "ratio of 0", sampler: TraceIDRatioSampler, ratio: 0},
+		{name: "case insensitive", sampler: "Always_On", wantSampled: true},
+		{name: "default is parent based ratio", sampler: "", ratio: 1, wantSampled: true},
+		{name: "unknown sampler", sampler: "sometimes", wantErr: true},
+		{name: "invalid ratio", sampler: TraceIDRatioSampler, ratio: 1.5, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sampler, err := NewSampler(test.sampler, test.ratio)
+			if test.wantErr != (err != nil) {
+				t.Fatalf("Unexpected error, wantErr %v, got %v", test.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				ParentContext: context.Background(),
+				TraceID:       trace.TraceID{0x01},
+				Name:          "test",
+			})
+			if got := result.Decision == sdktrace.RecordAndSample; got != test.wantSampled {
+				t.Errorf("Unexpected sampling decision, want sampled %v, got %v", test.wantSampled, got)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		wantTraceID string
+		wantValid   bool
+	}{
+		{
+			name:        "traceparent header",
+			headers:     map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			wantTraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			wantValid:   true,
+		},
+		{
+			name:    "no traceparent header",
+			headers: map[string]string{"content-type": "application/json"},
+		},
+		{
+			name:    "invalid traceparent header",
+			headers: map[string]string{"traceparent": "invalid"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spanContext := trace.SpanContextFromContext(Extract(context.Background(), test.headers))
+			if spanContext.IsValid() != test.wantValid {
+				t.Fatalf("Unexpected span context validity, want %v, got %v", test.wantValid, spanContext.IsValid())
+			}
+			if test.wantValid && spanContext.TraceID().String() != test.wantTraceID {
+				t.Errorf("Unexpected trace ID, want %s, got %s", test.wantTraceID, spanContext.TraceID())
+			}
+			if test.wantValid && !spanContext.IsRemote() {
+				t.Errorf("Expected a remote span context")
+			}
+		})
+	}
+}
+
+func TestEndSpan(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	_, span := Tracer().Start(context.Background(), "ok")
+	EndSpan(span, nil)
+	_, span = Tracer().Start(context.Background(), "failed")
+	EndSpan(span, errors.New("failure"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Status.Code != codes.Unset {
+		t.Errorf("Expected an unset status, got %v", spans[0].Status)
+	}
+	if spans[1].Status.Code != codes.Error || spans[1].Status.Description != "failure" || len(spans[1].Events) != 1 {
+		t.Errorf("Expected an error status and event, got %v and %v", spans[1].Status, spans[1].Events)
+	}
+}
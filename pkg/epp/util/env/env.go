@@ -72,3 +72,16 @@ func GetEnvString(key string, defaultVal string, logger logr.Logger) string {
 	parser := func(s string) (string, error) { return s, nil }
 	return getEnvWithParser(key, defaultVal, parser, logger)
 }
+
+// WarnDeprecated logs a deprecation warning for each of the given environment variables that is set, pointing to
+// its replacement. It returns the names of the variables that are set.
+func WarnDeprecated(logger logr.Logger, replacement string, keys ...string) []string {
+	set := []string{}
+	for _, key := range keys {
+		if _, exists := os.LookupEnv(key); exists {
+			logger.Info("Environment variable is deprecated and will be removed in a future release", "key", key, "replacement", replacement)
+			set = append(set, key)
+		}
+	}
+	return set
+}
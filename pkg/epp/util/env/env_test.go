@@ -334,3 +334,14 @@ func TestGetEnvString(t *testing.T) {
 		})
 	}
 }
+
+func TestWarnDeprecated(t *testing.T) {
+	logger := testr.New(t)
+	t.Setenv("TEST_DEPRECATED_SET", "1")
+	t.Setenv("TEST_DEPRECATED_EMPTY", "")
+
+	got := WarnDeprecated(logger, "the replacement", "TEST_DEPRECATED_SET", "TEST_DEPRECATED_MISSING", "TEST_DEPRECATED_EMPTY")
+	if len(got) != 2 || got[0] != "TEST_DEPRECATED_SET" || got[1] != "TEST_DEPRECATED_EMPTY" {
+		t.Errorf("WarnDeprecated() = %v, expected [TEST_DEPRECATED_SET TEST_DEPRECATED_EMPTY]", got)
+	}
+}
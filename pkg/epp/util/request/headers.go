@@ -24,6 +24,9 @@ import (
 
 const (
 	RequestIdHeaderKey = "x-request-id"
+	// SchedulingTraceHeaderKey is the request header that asks for the scheduling decision trace, and the response
+	// header that returns it, when enabled.
+	SchedulingTraceHeaderKey = "x-gateway-scheduling-trace"
 )
 
 func ExtractHeaderValue(req *extProcPb.ProcessingRequest_RequestHeaders, headerKey string) string {
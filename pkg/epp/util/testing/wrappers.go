@@ -208,6 +208,11 @@ func (m *InferencePoolWrapper) ExtensionRef(name string) *InferencePoolWrapper {
 	return m
 }
 
+func (m *InferencePoolWrapper) EndpointPickerConfigRef(name string) *InferencePoolWrapper {
+	m.Spec.EndpointPickerConfigRef = &v1alpha2.EndpointPickerConfigReference{Name: v1alpha2.ObjectName(name)}
+	return m
+}
+
 // Obj returns the wrapped InferencePool.
 func (m *InferencePoolWrapper) ObjRef() *v1alpha2.InferencePool {
 	return &m.InferencePool
@@ -0,0 +1,58 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command fake-vllm runs a fake vLLM model server, to be used together with the EPP and Envoy in local and
+// end-to-end tests. The flag names follow the vLLM command line where applicable.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/gateway-api-inference-extension/test/fakevllm"
+)
+
+func main() {
+	config := fakevllm.DefaultConfig()
+	port := flag.Int("port", 8000, "The port to listen on")
+	loraModules := flag.String("lora-modules", "", "Comma separated list of LoRA adapters loaded at startup")
+	flag.StringVar(&config.Model, "model", config.Model, "The name of the base model")
+	flag.IntVar(&config.MaxLoRAs, "max-loras", config.MaxLoRAs, "Max number of LoRA adapters in a batch")
+	flag.IntVar(&config.MaxCPULoRAs, "max-cpu-loras", config.MaxCPULoRAs, "Max number of loaded LoRA adapters, 0 means unlimited")
+	flag.IntVar(&config.MaxNumSeqs, "max-num-seqs", config.MaxNumSeqs, "Max number of sequences in a batch")
+	flag.IntVar(&config.BlockSize, "block-size", config.BlockSize, "Number of tokens in a KV-cache block")
+	flag.IntVar(&config.NumGPUBlocks, "num-gpu-blocks-override", config.NumGPUBlocks, "Number of KV-cache blocks")
+	flag.DurationVar(&config.TimeToFirstToken, "time-to-first-token", config.TimeToFirstToken, "Simulated prefill latency")
+	flag.DurationVar(&config.TimePerOutputToken, "time-per-output-token", config.TimePerOutputToken, "Simulated latency of each output token")
+	flag.Parse()
+
+	if *loraModules != "" {
+		config.LoRAAdapters = strings.Split(*loraModules, ",")
+	}
+	server, err := fakevllm.NewServer(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create server: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("fake vLLM server listening on port %d, model %s\n", *port, config.Model)
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", *port), server); err != nil {
+		fmt.Fprintf(os.Stderr, "server failed: %v\n", err)
+		os.Exit(1)
+	}
+}
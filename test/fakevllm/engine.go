@@ -0,0 +1,278 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakevllm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	errModelNotFound    = errors.New("model not found")
+	errRequestTooLarge  = errors.New("request does not fit in the KV cache")
+	errTooManyAdapters  = errors.New("max number of loaded LoRA adapters reached")
+	errAdapterNotLoaded = errors.New("LoRA adapter is not loaded")
+)
+
+// sequence is a request tracked by the engine, from the time it is queued until it completes.
+type sequence struct {
+	model        string
+	promptTokens int
+	maxTokens    int
+	blocks       int
+	admitted     chan struct{}
+}
+
+// engine simulates the scheduler of a vLLM server. It admits waiting sequences in FCFS order as long as there are
+// free batch slots and KV-cache blocks, and as long as the number of distinct LoRA adapters in the running batch
+// does not exceed the configured limit.
+// For simplicity, the KV-cache blocks of a sequence (prompt and max output tokens) are reserved on admission.
+type engine struct {
+	config Config
+
+	mu         sync.Mutex
+	waiting    []*sequence
+	running    map[*sequence]struct{}
+	usedBlocks int
+	// adapters holds the set of loaded LoRA adapters.
+	adapters map[string]struct{}
+
+	promptTokensTotal     int
+	generationTokensTotal int
+	requestSuccessTotal   int
+}
+
+func newEngine(config Config) *engine {
+	adapters := make(map[string]struct{}, len(config.LoRAAdapters))
+	for _, adapter := range config.LoRAAdapters {
+		adapters[adapter] = struct{}{}
+	}
+	return &engine{
+		config:   config,
+		running:  map[*sequence]struct{}{},
+		adapters: adapters,
+	}
+}
+
+// enqueue adds a new sequence to the waiting queue and tries to admit it.
+func (e *engine) enqueue(model string, promptTokens, maxTokens int) (*sequence, error) {
+	blocks := (promptTokens + maxTokens + e.config.BlockSize - 1) / e.config.BlockSize
+	if blocks > e.config.NumGPUBlocks {
+		return nil, fmt.Errorf("%w: %d blocks needed, %d blocks available", errRequestTooLarge, blocks, e.config.NumGPUBlocks)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.servesModelLocked(model) {
+		return nil, fmt.Errorf("%w: %s", errModelNotFound, model)
+	}
+	seq := &sequence{
+		model:        model,
+		promptTokens: promptTokens,
+		maxTokens:    maxTokens,
+		blocks:       blocks,
+		admitted:     make(chan struct{}),
+	}
+	e.waiting = append(e.waiting, seq)
+	e.scheduleLocked()
+	return seq, nil
+}
+
+// wait blocks until the sequence is admitted into the running batch, or until the context is done.
+func (e *engine) wait(ctx context.Context, seq *sequence) error {
+	select {
+	case <-seq.admitted:
+		return nil
+	case <-ctx.Done():
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	select {
+	case <-seq.admitted: // admitted concurrently with the cancellation, release the resources.
+		e.releaseLocked(seq)
+	default:
+		for i, waiting := range e.waiting {
+			if waiting == seq {
+				e.waiting = append(e.waiting[:i], e.waiting[i+1:]...)
+				break
+			}
+		}
+	}
+	return ctx.Err()
+}
+
+// recordTokens accounts for generated tokens of a running sequence.
+func (e *engine) recordTokens(count int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.generationTokensTotal += count
+}
+
+// finish releases the resources of a running sequence and admits waiting sequences.
+func (e *engine) finish(seq *sequence, success bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if success {
+		e.requestSuccessTotal++
+	}
+	e.releaseLocked(seq)
+}
+
+func (e *engine) releaseLocked(seq *sequence) {
+	if _, ok := e.running[seq]; !ok {
+		return
+	}
+	delete(e.running, seq)
+	e.usedBlocks -= seq.blocks
+	e.scheduleLocked()
+}
+
+func (e *engine) scheduleLocked() {
+	remaining := make([]*sequence, 0, len(e.waiting))
+	full := false
+	for _, seq := range e.waiting {
+		if full || len(e.running) >= e.config.MaxNumSeqs || e.usedBlocks+seq.blocks > e.config.NumGPUBlocks {
+			// FCFS, sequences behind a sequence that doesn't fit must wait as well.
+			full = true
+			remaining = append(remaining, seq)
+			continue
+		}
+		if !e.adapterFitsLocked(seq.model) {
+			remaining = append(remaining, seq)
+			continue
+		}
+		e.running[seq] = struct{}{}
+		e.usedBlocks += seq.blocks
+		e.promptTokensTotal += seq.promptTokens
+		close(seq.admitted)
+	}
+	e.waiting = remaining
+}
+
+// adapterFitsLocked returns true if a sequence of the given model can join the running batch without exceeding
+// the max number of LoRA adapters in a batch.
+func (e *engine) adapterFitsLocked(model string) bool {
+	if model == e.config.Model {
+		return true
+	}
+	running := e.runningAdaptersLocked()
+	if _, ok := running[model]; ok {
+		return true
+	}
+	return len(running) < e.config.MaxLoRAs
+}
+
+func (e *engine) servesModelLocked(model string) bool {
+	if model == e.config.Model {
+		return true
+	}
+	_, ok := e.adapters[model]
+	return ok
+}
+
+func (e *engine) runningAdaptersLocked() map[string]struct{} {
+	adapters := map[string]struct{}{}
+	for seq := range e.running {
+		if seq.model != e.config.Model {
+			adapters[seq.model] = struct{}{}
+		}
+	}
+	return adapters
+}
+
+func (e *engine) loadAdapter(name string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.adapters[name]; ok {
+		return nil
+	}
+	if e.config.MaxCPULoRAs > 0 && len(e.adapters) >= e.config.MaxCPULoRAs {
+		return errTooManyAdapters
+	}
+	e.adapters[name] = struct{}{}
+	return nil
+}
+
+func (e *engine) unloadAdapter(name string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.adapters[name]; !ok {
+		return errAdapterNotLoaded
+	}
+	delete(e.adapters, name)
+	return nil
+}
+
+// models returns the base model followed by the loaded adapters, sorted by name.
+func (e *engine) models() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	adapters := make([]string, 0, len(e.adapters))
+	for adapter := range e.adapters {
+		adapters = append(adapters, adapter)
+	}
+	sort.Strings(adapters)
+	return append([]string{e.config.Model}, adapters...)
+}
+
+// engineState is a point in time snapshot of the engine state, used to expose metrics.
+type engineState struct {
+	waiting               int
+	running               int
+	kvCacheUsage          float64
+	runningAdapters       []string
+	waitingAdapters       []string
+	promptTokensTotal     int
+	generationTokensTotal int
+	requestSuccessTotal   int
+}
+
+func (e *engine) snapshot() engineState {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	runningAdapters := []string{}
+	for adapter := range e.runningAdaptersLocked() {
+		runningAdapters = append(runningAdapters, adapter)
+	}
+	waitingAdaptersSet := map[string]struct{}{}
+	waitingAdapters := []string{}
+	for _, seq := range e.waiting {
+		if _, ok := waitingAdaptersSet[seq.model]; ok || seq.model == e.config.Model {
+			continue
+		}
+		waitingAdaptersSet[seq.model] = struct{}{}
+		waitingAdapters = append(waitingAdapters, seq.model)
+	}
+	sort.Strings(runningAdapters)
+	sort.Strings(waitingAdapters)
+
+	return engineState{
+		waiting:               len(e.waiting),
+		running:               len(e.running),
+		kvCacheUsage:          float64(e.usedBlocks) / float64(e.config.NumGPUBlocks),
+		runningAdapters:       runningAdapters,
+		waitingAdapters:       waitingAdapters,
+		promptTokensTotal:     e.promptTokensTotal,
+		generationTokensTotal: e.generationTokensTotal,
+		requestSuccessTotal:   e.requestSuccessTotal,
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakevllm
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric names exposed by vLLM, which are the default metric names scraped by the EPP.
+const (
+	NumRequestsWaitingMetric    = "vllm:num_requests_waiting"
+	NumRequestsRunningMetric    = "vllm:num_requests_running"
+	GPUCacheUsageMetric         = "vllm:gpu_cache_usage_perc"
+	LoRARequestsInfoMetric      = "vllm:lora_requests_info"
+	CacheConfigInfoMetric       = "vllm:cache_config_info"
+	PromptTokensTotalMetric     = "vllm:prompt_tokens_total"
+	GenerationTokensTotalMetric = "vllm:generation_tokens_total"
+	RequestSuccessTotalMetric   = "vllm:request_success_total"
+	modelNameLabel              = "model_name"
+	runningLoRAAdaptersLabel    = "running_lora_adapters"
+	waitingLoRAAdaptersLabel    = "waiting_lora_adapters"
+	maxLoRALabel                = "max_lora"
+	blockSizeLabel              = "block_size"
+	numGPUBlocksLabel           = "num_gpu_blocks"
+	requestSuccessFinishReason  = "finished_reason"
+)
+
+var (
+	numRequestsWaitingDesc = prometheus.NewDesc(NumRequestsWaitingMetric,
+		"Number of requests waiting to be processed.", []string{modelNameLabel}, nil)
+	numRequestsRunningDesc = prometheus.NewDesc(NumRequestsRunningMetric,
+		"Number of requests currently running on GPU.", []string{modelNameLabel}, nil)
+	gpuCacheUsageDesc = prometheus.NewDesc(GPUCacheUsageMetric,
+		"GPU KV-cache usage. 1 means 100 percent usage.", []string{modelNameLabel}, nil)
+	loraRequestsInfoDesc = prometheus.NewDesc(LoRARequestsInfoMetric,
+		"Running stats on lora requests.", []string{runningLoRAAdaptersLabel, waitingLoRAAdaptersLabel, maxLoRALabel}, nil)
+	cacheConfigInfoDesc = prometheus.NewDesc(CacheConfigInfoMetric,
+		"Information of the LLMEngine CacheConfig.", []string{blockSizeLabel, numGPUBlocksLabel}, nil)
+	promptTokensTotalDesc = prometheus.NewDesc(PromptTokensTotalMetric,
+		"Number of prefill tokens processed.", []string{modelNameLabel}, nil)
+	generationTokensTotalDesc = prometheus.NewDesc(GenerationTokensTotalMetric,
+		"Number of generation tokens processed.", []string{modelNameLabel}, nil)
+	requestSuccessTotalDesc = prometheus.NewDesc(RequestSuccessTotalMetric,
+		"Count of successfully processed requests.", []string{modelNameLabel, requestSuccessFinishReason}, nil)
+)
+
+// collector exposes the engine state with the same metric names and labels as vLLM.
+type collector struct {
+	engine *engine
+}
+
+// compile-time type assertion
+var _ prometheus.Collector = &collector{}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numRequestsWaitingDesc
+	ch <- numRequestsRunningDesc
+	ch <- gpuCacheUsageDesc
+	ch <- loraRequestsInfoDesc
+	ch <- cacheConfigInfoDesc
+	ch <- promptTokensTotalDesc
+	ch <- generationTokensTotalDesc
+	ch <- requestSuccessTotalDesc
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	state := c.engine.snapshot()
+	config := c.engine.config
+
+	ch <- prometheus.MustNewConstMetric(numRequestsWaitingDesc, prometheus.GaugeValue, float64(state.waiting), config.Model)
+	ch <- prometheus.MustNewConstMetric(numRequestsRunningDesc, prometheus.GaugeValue, float64(state.running), config.Model)
+	ch <- prometheus.MustNewConstMetric(gpuCacheUsageDesc, prometheus.GaugeValue, state.kvCacheUsage, config.Model)
+	// vLLM sets the value of the lora info series to the creation timestamp of the series.
+	ch <- prometheus.MustNewConstMetric(loraRequestsInfoDesc, prometheus.GaugeValue, float64(time.Now().UnixNano())/1e9,
+		strings.Join(state.runningAdapters, ","), strings.Join(state.waitingAdapters, ","), strconv.Itoa(config.MaxLoRAs))
+	ch <- prometheus.MustNewConstMetric(cacheConfigInfoDesc, prometheus.GaugeValue, 1,
+		strconv.Itoa(config.BlockSize), strconv.Itoa(config.NumGPUBlocks))
+	ch <- prometheus.MustNewConstMetric(promptTokensTotalDesc, prometheus.CounterValue, float64(state.promptTokensTotal), config.Model)
+	ch <- prometheus.MustNewConstMetric(generationTokensTotalDesc, prometheus.CounterValue, float64(state.generationTokensTotal), config.Model)
+	ch <- prometheus.MustNewConstMetric(requestSuccessTotalDesc, prometheus.CounterValue, float64(state.requestSuccessTotal), config.Model, "length")
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakevllm implements a fake vLLM model server to be used in hermetic end-to-end tests.
+//
+// The server answers OpenAI completions and chat completions requests (streaming and non-streaming) with synthetic
+// tokens, and simulates the parts of the vLLM engine the EPP cares about: continuous batching with a bounded batch
+// size, request queueing, KV-cache block usage and the max number of LoRA adapters in a batch. Its state is exposed
+// on /metrics with the same metric names as vLLM.
+package fakevllm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// charactersPerToken is used to estimate the number of prompt tokens from the prompt length.
+	charactersPerToken = 4
+	// defaultMaxTokens is the number of tokens generated when the request doesn't specify max_tokens.
+	defaultMaxTokens = 16
+	syntheticToken   = "token "
+)
+
+// Config holds the configuration of the fake server.
+type Config struct {
+	// Model is the name of the base model served.
+	Model string
+	// LoRAAdapters is the list of LoRA adapters loaded at startup.
+	LoRAAdapters []string
+	// MaxLoRAs is the max number of distinct LoRA adapters in a running batch.
+	MaxLoRAs int
+	// MaxCPULoRAs is the max number of loaded LoRA adapters, 0 means unlimited.
+	MaxCPULoRAs int
+	// MaxNumSeqs is the max number of sequences in a running batch.
+	MaxNumSeqs int
+	// BlockSize is the number of tokens in a KV-cache block.
+	BlockSize int
+	// NumGPUBlocks is the number of KV-cache blocks.
+	NumGPUBlocks int
+	// TimeToFirstToken is the simulated prefill latency.
+	TimeToFirstToken time.Duration
+	// TimePerOutputToken is the simulated decode latency of each output token.
+	TimePerOutputToken time.Duration
+}
+
+// DefaultConfig returns a configuration resembling a small vLLM deployment with fast synthetic latencies.
+func DefaultConfig() Config {
+	return Config{
+		Model:              "meta-llama/Llama-3.1-8B-Instruct",
+		MaxLoRAs:           4,
+		MaxNumSeqs:         256,
+		BlockSize:          16,
+		NumGPUBlocks:       2048,
+		TimeToFirstToken:   10 * time.Millisecond,
+		TimePerOutputToken: time.Millisecond,
+	}
+}
+
+// Server is a fake vLLM server. It implements http.Handler.
+type Server struct {
+	engine *engine
+	mux    *http.ServeMux
+}
+
+// NewServer creates a new fake vLLM server with the given configuration.
+func NewServer(config Config) (*Server, error) {
+	if config.Model == "" {
+		return nil, errors.New("model name must be set")
+	}
+	if config.MaxNumSeqs <= 0 || config.BlockSize <= 0 || config.NumGPUBlocks <= 0 {
+		return nil, fmt.Errorf("MaxNumSeqs, BlockSize and NumGPUBlocks must be positive, got %d, %d and %d",
+			config.MaxNumSeqs, config.BlockSize, config.NumGPUBlocks)
+	}
+
+	s := &Server{
+		engine: newEngine(config),
+		mux:    http.NewServeMux(),
+	}
+	registry := prometheus.NewRegistry()
+	if err := registry.Register(&collector{engine: s.engine}); err != nil {
+		return nil, fmt.Errorf("failed to register metrics - %w", err)
+	}
+
+	s.mux.HandleFunc("POST /v1/completions", s.handleCompletions)
+	s.mux.HandleFunc("POST /v1/chat/completions", s.handleChatCompletions)
+	s.mux.HandleFunc("GET /v1/models", s.handleModels)
+	s.mux.HandleFunc("POST /v1/load_lora_adapter", s.handleLoadLoRAAdapter)
+	s.mux.HandleFunc("POST /v1/unload_lora_adapter", s.handleUnloadLoRAAdapter)
+	s.mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	s.mux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type completionRequest struct {
+	Model         string         `json:"model"`
+	Prompt        string         `json:"prompt"`
+	Messages      []chatMessage  `json:"messages"`
+	MaxTokens     *int           `json:"max_tokens"`
+	Stream        bool           `json:"stream"`
+	StreamOptions *streamOptions `json:"stream_options"`
+}
+
+type usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+type choice struct {
+	Index        int          `json:"index"`
+	Text         *string      `json:"text,omitempty"`
+	Message      *chatMessage `json:"message,omitempty"`
+	Delta        *chatMessage `json:"delta,omitempty"`
+	FinishReason *string      `json:"finish_reason"`
+}
+
+type completionResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []choice `json:"choices"`
+	Usage   *usage   `json:"usage"`
+}
+
+func (s *Server) handleCompletions(w http.ResponseWriter, r *http.Request) {
+	s.handleGeneration(w, r, false)
+}
+
+func (s *Server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	s.handleGeneration(w, r, true)
+}
+
+func (s *Server) handleGeneration(w http.ResponseWriter, r *http.Request, chat bool) {
+	req := completionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	promptLength := len(req.Prompt)
+	for _, message := range req.Messages {
+		promptLength += len(message.Content)
+	}
+	promptTokens := max(1, promptLength/charactersPerToken)
+	maxTokens := defaultMaxTokens
+	if req.MaxTokens != nil {
+		maxTokens = *req.MaxTokens
+	}
+	if maxTokens <= 0 {
+		writeError(w, http.StatusBadRequest, "max_tokens must be positive")
+		return
+	}
+
+	seq, err := s.engine.enqueue(req.Model, promptTokens, maxTokens)
+	switch {
+	case errors.Is(err, errModelNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	ctx := r.Context()
+	if err := s.engine.wait(ctx, seq); err != nil {
+		return // the client went away
+	}
+
+	success := false
+	defer func() { s.engine.finish(seq, success) }()
+
+	if !sleep(r, s.engine.config.TimeToFirstToken) {
+		return
+	}
+
+	response := completionResponse{
+		ID:      "cmpl-" + uuid.NewString(),
+		Object:  "text_completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+	}
+	if chat {
+		response.Object = "chat.completion"
+	}
+	tokensUsage := &usage{PromptTokens: promptTokens, CompletionTokens: maxTokens, TotalTokens: promptTokens + maxTokens}
+
+	if !req.Stream {
+		for i := 1; i < maxTokens; i++ {
+			if !sleep(r, s.engine.config.TimePerOutputToken) {
+				return
+			}
+		}
+		s.engine.recordTokens(maxTokens)
+		text := strings.Repeat(syntheticToken, maxTokens)
+		response.Choices = []choice{newChoice(chat, false, text, true)}
+		response.Usage = tokensUsage
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+		success = true
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if chat {
+		response.Object = "chat.completion.chunk"
+	}
+	for i := 0; i < maxTokens; i++ {
+		if i > 0 && !sleep(r, s.engine.config.TimePerOutputToken) {
+			return
+		}
+		s.engine.recordTokens(1)
+		response.Choices = []choice{newChoice(chat, true, syntheticToken, i == maxTokens-1)}
+		if err := writeEvent(w, flusher, response); err != nil {
+			return
+		}
+	}
+	if req.StreamOptions != nil && req.StreamOptions.IncludeUsage {
+		response.Choices = []choice{}
+		response.Usage = tokensUsage
+		if err := writeEvent(w, flusher, response); err != nil {
+			return
+		}
+	}
+	if _, err := fmt.Fprint(w, "data: [DONE]\n\n"); err != nil {
+		return
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	success = true
+}
+
+func newChoice(chat, stream bool, text string, last bool) choice {
+	c := choice{Index: 0}
+	if last {
+		finishReason := "length"
+		c.FinishReason = &finishReason
+	}
+	switch {
+	case !chat:
+		c.Text = &text
+	case stream:
+		c.Delta = &chatMessage{Role: "assistant", Content: text}
+	default:
+		c.Message = &chatMessage{Role: "assistant", Content: text}
+	}
+	return c
+}
+
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, event any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// sleep waits for the given duration, and returns false if the request was cancelled in the meantime.
+func sleep(r *http.Request, d time.Duration) bool {
+	if d <= 0 {
+		return r.Context().Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-r.Context().Done():
+		return false
+	}
+}
+
+func (s *Server) handleModels(w http.ResponseWriter, _ *http.Request) {
+	type model struct {
+		ID      string `json:"id"`
+		Object  string `json:"object"`
+		OwnedBy string `json:"owned_by"`
+		Root    string `json:"root"`
+	}
+	models := []model{}
+	for _, name := range s.engine.models() {
+		models = append(models, model{ID: name, Object: "model", OwnedBy: "vllm", Root: s.engine.config.Model})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"object": "list", "data": models})
+}
+
+type loraAdapterRequest struct {
+	LoRAName string `json:"lora_name"`
+	LoRAPath string `json:"lora_path"`
+}
+
+func (s *Server) handleLoadLoRAAdapter(w http.ResponseWriter, r *http.Request) {
+	req := loraAdapterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.LoRAName == "" {
+		writeError(w, http.StatusBadRequest, "lora_name must be set")
+		return
+	}
+	if err := s.engine.loadAdapter(req.LoRAName); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Success: LoRA adapter '%s' added successfully.", req.LoRAName)
+}
+
+func (s *Server) handleUnloadLoRAAdapter(w http.ResponseWriter, r *http.Request) {
+	req := loraAdapterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.LoRAName == "" {
+		writeError(w, http.StatusBadRequest, "lora_name must be set")
+		return
+	}
+	if err := s.engine.unloadAdapter(req.LoRAName); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Success: LoRA adapter '%s' removed successfully.", req.LoRAName)
+}
+
+// writeError writes an error in the OpenAI error format.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"object":  "error",
+		"message": message,
+		"type":    http.StatusText(code),
+		"code":    strconv.Itoa(code),
+	})
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakevllm
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/gateway-api-inference-extension/api/v1alpha2"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend"
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+)
+
+func newTestServer(t *testing.T, config Config) *httptest.Server {
+	server, err := NewServer(config)
+	if err != nil {
+		t.Fatalf("Failed to create server: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func post(t *testing.T, url, body string) *http.Response {
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to send request: %v", err)
+	}
+	return resp
+}
+
+func TestCompletions(t *testing.T) {
+	config := DefaultConfig()
+	config.Model = "base"
+	config.LoRAAdapters = []string{"sql-lora"}
+	ts := newTestServer(t, config)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantObject string
+		wantUsage  usage
+	}{
+		{
+			name:       "completion on base model",
+			path:       "/v1/completions",
+			body:       `{"model":"base","prompt":"12345678","max_tokens":3}`,
+			wantStatus: http.StatusOK,
+			wantObject: "text_completion",
+			wantUsage:  usage{PromptTokens: 2, CompletionTokens: 3, TotalTokens: 5},
+		},
+		{
+			name:       "chat completion on adapter",
+			path:       "/v1/chat/completions",
+			body:       `{"model":"sql-lora","messages":[{"role":"user","content":"1234"}]}`,
+			wantStatus: http.StatusOK,
+			wantObject: "chat.completion",
+			wantUsage:  usage{PromptTokens: 1, CompletionTokens: defaultMaxTokens, TotalTokens: 1 + defaultMaxTokens},
+		},
+		{
+			name:       "unknown model",
+			path:       "/v1/completions",
+			body:       `{"model":"unknown","prompt":"hello"}`,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "request larger than the KV cache",
+			path:       "/v1/completions",
+			body:       `{"model":"base","prompt":"hello","max_tokens":1000000}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := post(t, ts.URL+test.path, test.body)
+			defer resp.Body.Close()
+			if resp.StatusCode != test.wantStatus {
+				t.Fatalf("Unexpected status code, want %d, got %d", test.wantStatus, resp.StatusCode)
+			}
+			if test.wantStatus != http.StatusOK {
+				return
+			}
+			got := completionResponse{}
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if got.Object != test.wantObject {
+				t.Errorf("Unexpected object, want %s, got %s", test.wantObject, got.Object)
+			}
+			if diff := cmp.Diff(test.wantUsage, *got.Usage); diff != "" {
+				t.Errorf("Unexpected usage (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestStreamingCompletions(t *testing.T) {
+	config := DefaultConfig()
+	config.Model = "base"
+	ts := newTestServer(t, config)
+
+	resp := post(t, ts.URL+"/v1/chat/completions",
+		`{"model":"base","messages":[{"role":"user","content":"1234"}],"max_tokens":4,"stream":true,"stream_options":{"include_usage":true}}`)
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("Unexpected content type %q", got)
+	}
+
+	events := []string{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			events = append(events, strings.TrimPrefix(line, "data: "))
+		}
+	}
+	// 4 tokens, usage and [DONE]
+	if len(events) != 6 {
+		t.Fatalf("Expected 6 events, got %d: %v", len(events), events)
+	}
+	if events[5] != "[DONE]" {
+		t.Errorf("Expected last event to be [DONE], got %s", events[5])
+	}
+	last := completionResponse{}
+	if err := json.Unmarshal([]byte(events[4]), &last); err != nil {
+		t.Fatalf("Failed to decode usage event: %v", err)
+	}
+	if diff := cmp.Diff(usage{PromptTokens: 1, CompletionTokens: 4, TotalTokens: 5}, *last.Usage); diff != "" {
+		t.Errorf("Unexpected usage (-want +got): %s", diff)
+	}
+}
+
+// TestMetricsScrapedByEPP validates that the EPP metrics client reads the simulated engine state.
+func TestMetricsScrapedByEPP(t *testing.T) {
+	config := DefaultConfig()
+	config.Model = "base"
+	config.LoRAAdapters = []string{"lora-a", "lora-b"}
+	config.MaxLoRAs = 1
+	config.MaxNumSeqs = 2
+	config.NumGPUBlocks = 100
+	config.TimePerOutputToken = 50 * time.Millisecond
+	ts := newTestServer(t, config)
+
+	// lora-a occupies the single adapter slot, so lora-b must wait while the base model request joins the batch.
+	for _, model := range []string{"lora-a", "lora-b", "base"} {
+		go func() {
+			resp, err := http.Post(ts.URL+"/v1/completions", "application/json",
+				strings.NewReader(`{"model":"`+model+`","prompt":"hello","max_tokens":20}`))
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+		}()
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	host, port, _ := net.SplitHostPort(strings.TrimPrefix(ts.URL, "http://"))
+	portNumber, _ := strconv.Atoi(port)
+	mapping, err := backendmetrics.NewMetricMapping(NumRequestsWaitingMetric, NumRequestsRunningMetric, GPUCacheUsageMetric,
+		CacheConfigInfoMetric, LoRARequestsInfoMetric)
+	if err != nil {
+		t.Fatalf("Failed to create metric mapping: %v", err)
+	}
+	client := &backendmetrics.PodMetricsClientImpl{MetricMapping: mapping}
+	pool := &v1alpha2.InferencePool{Spec: v1alpha2.InferencePoolSpec{TargetPortNumber: int32(portNumber)}}
+	got, err := client.FetchMetrics(context.Background(), &backend.Pod{Address: host}, &backendmetrics.MetricsState{}, pool)
+	if err != nil {
+		t.Fatalf("Failed to fetch metrics: %v", err)
+	}
+
+	want := &backendmetrics.MetricsState{
+		ActiveModels:            map[string]int{"lora-a": 0},
+		WaitingModels:           map[string]int{"lora-b": 0},
+		MaxActiveModels:         1,
+		RunningQueueSize:        2,
+		WaitingQueueSize:        1,
+		KVCacheUsagePercent:     0.04, // 2 running requests of 2 blocks each (1 prompt token and 20 output tokens)
+		KvCacheMaxTokenCapacity: config.NumGPUBlocks * config.BlockSize,
+		KvCacheBlockSize:        config.BlockSize,
+		KvCacheNumGPUBlocks:     config.NumGPUBlocks,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected metrics (-want +got): %s", diff)
+	}
+}
+
+func TestLoadAndUnloadLoRAAdapter(t *testing.T) {
+	config := DefaultConfig()
+	config.Model = "base"
+	config.MaxCPULoRAs = 1
+	ts := newTestServer(t, config)
+
+	steps := []struct {
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{path: "/v1/completions", body: `{"model":"lora-a","prompt":"hello"}`, wantStatus: http.StatusNotFound},
+		{path: "/v1/load_lora_adapter", body: `{"lora_name":"lora-a","lora_path":"/adapters/a"}`, wantStatus: http.StatusOK},
+		{path: "/v1/load_lora_adapter", body: `{"lora_name":"lora-b","lora_path":"/adapters/b"}`, wantStatus: http.StatusBadRequest},
+		{path: "/v1/completions", body: `{"model":"lora-a","prompt":"hello"}`, wantStatus: http.StatusOK},
+		{path: "/v1/unload_lora_adapter", body: `{"lora_name":"lora-a"}`, wantStatus: http.StatusOK},
+		{path: "/v1/completions", body: `{"model":"lora-a","prompt":"hello"}`, wantStatus: http.StatusNotFound},
+	}
+	for i, step := range steps {
+		resp := post(t, ts.URL+step.path, step.body)
+		_ = resp.Body.Close()
+		if resp.StatusCode != step.wantStatus {
+			t.Errorf("Step %d: unexpected status code, want %d, got %d", i, step.wantStatus, resp.StatusCode)
+		}
+	}
+}
@@ -16,17 +16,31 @@ limitations under the License.
 
 package utils
 
-import "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	backendmetrics "sigs.k8s.io/gateway-api-inference-extension/pkg/epp/backend/metrics"
+	"sigs.k8s.io/gateway-api-inference-extension/pkg/epp/plugins"
+)
 
 // testHandle is an implmentation of plugins.Handle for test purposes
 type testHandle struct {
-	plugins plugins.HandlePlugins
+	plugins       plugins.HandlePlugins
+	customMetrics []string
 }
 
 func (h *testHandle) Plugins() plugins.HandlePlugins {
 	return h.plugins
 }
 
+func (h *testHandle) PodGet(_ types.NamespacedName) backendmetrics.PodMetrics {
+	return nil
+}
+
+func (h *testHandle) CustomMetrics() []string {
+	return h.customMetrics
+}
+
 type testHandlePlugins struct {
 	thePlugins map[string]plugins.Plugin
 }
@@ -51,10 +65,11 @@ func (h *testHandlePlugins) GetAllPluginsWithNames() map[string]plugins.Plugin {
 	return h.thePlugins
 }
 
-func NewTestHandle() plugins.Handle {
+func NewTestHandle(customMetrics ...string) plugins.Handle {
 	return &testHandle{
 		plugins: &testHandlePlugins{
 			thePlugins: map[string]plugins.Plugin{},
 		},
+		customMetrics: customMetrics,
 	}
 }